@@ -24,24 +24,77 @@ func main() {
 		&models.CarePlan{},
 		&models.Interaction{},
 		&models.Device{},
+		&models.DeviceAssignment{},
+		&models.Shipment{},
+		&models.ShipmentItem{},
+		&models.DeviceSyncRun{},
+		&models.DeviceSyncEntry{},
 		&models.DeviceStatusData{},
 		&models.DeviceTelemetryData{},
 		&models.DeviceLogData{},
+		&models.DeviceAlert{},
 		&models.UserVerification{},
 		&models.AlertThreshold{},
+		&models.AlertTrendRule{},
+		&models.ThresholdTemplate{},
 		&models.InteractionSetting{},
 		&models.TelemetryAlert{},
+		&models.AlertNotificationRule{},
+		&models.AlertNotification{},
+		&models.AlertNotificationClaim{},
+		&models.TelemetryAlertNote{},
+		&models.AdherenceReminder{},
+		&models.TelemetryImport{},
+		&models.TelemetryImportError{},
+		&models.TelemetryArchive{},
+		&models.TelemetryDailySummary{},
+		&models.ClinicalSummary{},
+		&models.APIKey{},
+		&models.UserMFA{},
+		&models.MFARecoveryCode{},
+		&models.MFAChallenge{},
+		&models.MFAPolicy{},
 		&models.Service{},
 		&models.PatientService{},
 		&models.Bill{},
 		&models.LastBillEntry{},
 		&models.Diagnosis{},
 		&models.PatientDiagnosis{},
+		&models.DataMigration{},
 	)
 
 	if err != nil {
 		panic("Could not migrate database")
 	}
 
+	if err := models.BackfillAlertNotificationClaims(); err != nil {
+		panic("Could not backfill alert notification claims")
+	}
+
+	if err := models.BackfillAlertMeasurementType(); err != nil {
+		panic("Could not backfill alert measurement type")
+	}
+
+	if err := models.BackfillDevicePool(); err != nil {
+		panic("Could not backfill device pool")
+	}
+
+	if err := models.BackfillDeviceStatus(); err != nil {
+		panic("Could not backfill device status")
+	}
+
+	if err := models.BackfillReadingQuality(); err != nil {
+		panic("Could not backfill reading quality")
+	}
+
+	// Runs after the quality backfill so control and invalid samples keep their quality
+	if err := models.BackfillTruncatedGlucose(); err != nil {
+		panic("Could not flag truncated glucose readings")
+	}
+
+	if err := models.RunDataMigration("remove-diagnosis-threshold-copies", models.RemoveDiagnosisThresholdCopies); err != nil {
+		panic("Could not remove diagnosis threshold copies")
+	}
+
 	fmt.Println("Database migrated successfully.")
 }
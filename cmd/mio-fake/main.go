@@ -0,0 +1,20 @@
+package main
+
+import (
+	mioFake "MedKick-backend/pkg/mio/fake"
+	"flag"
+	"log"
+	"net/http"
+)
+
+// Runs the fake Mio Connect API locally. Start the backend with MIO_API_URL=http://localhost:8090/v1
+// and the same MIO_API_KEY to order and track shipments without touching Mio.
+// Move a shipment along with POST /v1/shipments/{id}/status {"status": "shipped"}.
+func main() {
+	addr := flag.String("addr", ":8090", "listen address")
+	apiKey := flag.String("key", "test", "API key the fake expects in x-api-key")
+	flag.Parse()
+
+	log.Printf("Fake Mio Connect API listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mioFake.NewServer(*apiKey)))
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	mioApi "MedKick-backend/pkg/mio/api"
+	mioFake "MedKick-backend/pkg/mio/fake"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// The status endpoint is how a shipment is moved along by hand when the backend runs
+// against this fake
+func TestMoveShipmentOverHTTP(t *testing.T) {
+	server := httptest.NewServer(mioFake.NewServer("test"))
+	defer server.Close()
+
+	client := mioApi.NewClient("test", mioApi.WithBaseURL(server.URL+"/v1"))
+	ctx := context.Background()
+
+	shipment, err := client.ShipItem(ctx, mioApi.ShipmentRequest{
+		Address: mioApi.ShipmentAddress{
+			ZipCode:     "75001",
+			City:        "Dallas",
+			State:       "TX",
+			AddressLine: "123 Main St",
+		},
+		Items: []mioApi.ShipmentItem{
+			{DeviceId: "GBS-2104-G", Count: 1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, status := range []string{mioApi.ShipmentShipped, mioApi.ShipmentDelivered} {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/v1/shipments/"+shipment.ID+"/status", strings.NewReader(`{"status": "`+status+`"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("x-api-key", "test")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var moved mioApi.Shipment
+		err = json.NewDecoder(resp.Body).Decode(&moved)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK || moved.Status != status {
+			t.Fatalf("got %d with status %s, want 200 with %s", resp.StatusCode, moved.Status, status)
+		}
+	}
+
+	shipment, err = client.GetShipment(ctx, shipment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shipment.Status != mioApi.ShipmentDelivered || shipment.TrackingNumber == "" {
+		t.Errorf("got %+v, want a delivered shipment with tracking", shipment)
+	}
+}
+
+func TestRejectsWrongKey(t *testing.T) {
+	server := httptest.NewServer(mioFake.NewServer("test"))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/v1/devices", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("x-api-key", "wrong")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("got %d, want 401", resp.StatusCode)
+	}
+}
@@ -44,7 +44,7 @@ const docTemplate = `{
         },
         "/auth/login": {
             "post": {
-                "description": "Login",
+                "description": "Login. When the user has MFA on, the session only holds a challenge and the login is finished with /auth/mfa/verify.",
                 "consumes": [
                     "application/json"
                 ],
@@ -70,7 +70,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "$ref": "#/definitions/user.LoginResponse"
                         }
                     },
                     "400": {
@@ -123,6 +123,111 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/mfa/email": {
+            "post": {
+                "description": "Email a verification code for the pending login, for users who turned on the email fallback. A new code can be requested once a minute and replaces the previous one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Send MFA Email Code",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/verify": {
+            "post": {
+                "description": "Finish a login of a user with MFA on with a code from the authenticator app, a recovery code or a code sent by email. After 5 wrong codes, or 10 minutes, the user has to log in again. After 10 wrong codes in a row, over any number of logins, codes are refused for 15 minutes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Verify MFA",
+                "parameters": [
+                    {
+                        "description": "Verification Code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.MFAVerifyData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/register": {
             "post": {
                 "description": "Register",
@@ -532,9 +637,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/cron/clear-pwd-reset": {
+        "/cron/adherence-reminders": {
             "post": {
-                "description": "CRON ONLY - Clears all password reset tokens that are older than 24 hours",
+                "description": "CRON ONLY - Reminds at risk RPM patients to take their readings, for organizations with reminders turned on",
                 "consumes": [
                     "application/json"
                 ],
@@ -544,7 +649,7 @@ const docTemplate = `{
                 "tags": [
                     "CRON"
                 ],
-                "summary": "Clear old password reset tokens",
+                "summary": "Process Adherence Reminders",
                 "parameters": [
                     {
                         "description": "Token Request",
@@ -575,9 +680,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/cron/clear-test-billings": {
+        "/cron/alert-notifications": {
             "post": {
-                "description": "CRON ONLY - Clears all test billings",
+                "description": "CRON ONLY - Notifies new telemetry alerts and escalates unacknowledged ones",
                 "consumes": [
                     "application/json"
                 ],
@@ -587,7 +692,7 @@ const docTemplate = `{
                 "tags": [
                     "CRON"
                 ],
-                "summary": "Clear Test Billings",
+                "summary": "Process Alert Notifications",
                 "parameters": [
                     {
                         "description": "Token Request",
@@ -618,9 +723,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/cron/sync-devices": {
+        "/cron/archive-telemetry": {
             "post": {
-                "description": "CRON ONLY - Pulls and Syncs devices from Mio-Connect",
+                "description": "CRON ONLY - Moves readings and device status reports older than their hot window to archive files in s3, keeping daily summaries of the readings",
                 "consumes": [
                     "application/json"
                 ],
@@ -630,7 +735,7 @@ const docTemplate = `{
                 "tags": [
                     "CRON"
                 ],
-                "summary": "Sync Devices from Mio Connect",
+                "summary": "Archive Telemetry",
                 "parameters": [
                     {
                         "description": "Token Request",
@@ -643,8 +748,8 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -661,9 +766,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/cron/trigger-cpt-worker": {
+        "/cron/clear-pwd-reset": {
             "post": {
-                "description": "CRON ONLY - Triggers CPT Worker",
+                "description": "CRON ONLY - Clears all password reset tokens that are older than 24 hours",
                 "consumes": [
                     "application/json"
                 ],
@@ -673,7 +778,7 @@ const docTemplate = `{
                 "tags": [
                     "CRON"
                 ],
-                "summary": "Trigger CPT Worker",
+                "summary": "Clear old password reset tokens",
                 "parameters": [
                     {
                         "description": "Token Request",
@@ -704,9 +809,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/device/assign-device": {
-            "patch": {
-                "description": "Assign Device",
+        "/cron/clear-test-billings": {
+            "post": {
+                "description": "CRON ONLY - Clears all test billings",
                 "consumes": [
                     "application/json"
                 ],
@@ -714,26 +819,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "CRON"
                 ],
-                "summary": "Assign Device",
+                "summary": "Clear Test Billings",
                 "parameters": [
                     {
-                        "description": "Assign Device",
-                        "name": "request",
+                        "description": "Token Request",
+                        "name": "CronToken",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/device.DeviceAssignRequest"
+                            "$ref": "#/definitions/cron.Request"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -750,25 +852,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/device/available-devices": {
-            "get": {
-                "description": "Get Available Devices",
+        "/cron/clinical-summaries": {
+            "post": {
+                "description": "CRON ONLY - Generates last month's clinical summary PDF for every RPM patient that doesn't have one yet",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "CRON"
                 ],
-                "summary": "Get Available Devices",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Generate Clinical Summaries",
+                "parameters": [
+                    {
+                        "description": "Token Request",
+                        "name": "CronToken",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.DeviceDTO"
-                            }
+                            "$ref": "#/definitions/cron.Request"
                         }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -785,9 +895,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/device/{id}": {
-            "get": {
-                "description": "Get devices by id, set id to 'all' to get all devices",
+        "/cron/device-health": {
+            "post": {
+                "description": "CRON ONLY - Raises and resolves device alerts for offline, low battery and weak signal devices",
                 "consumes": [
                     "application/json"
                 ],
@@ -795,26 +905,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "CRON"
                 ],
-                "summary": "Get Devices",
+                "summary": "Process Device Health",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Device ID",
-                        "name": "id",
-                        "in": "path"
+                        "description": "Token Request",
+                        "name": "CronToken",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cron.Request"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Device"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -822,12 +929,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -835,9 +936,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete Device",
+            }
+        },
+        "/cron/shipments": {
+            "post": {
+                "description": "CRON ONLY - Pulls the status of open device shipments from Mio Connect and links delivered devices to their patient",
                 "consumes": [
                     "application/json"
                 ],
@@ -845,24 +948,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "CRON"
                 ],
-                "summary": "Delete Device",
+                "summary": "Process Shipments",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Device ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Token Request",
+                        "name": "CronToken",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cron.Request"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -870,18 +972,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -889,9 +979,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update Device",
+            }
+        },
+        "/cron/sync-devices": {
+            "post": {
+                "description": "CRON ONLY - Pulls devices from Mio-Connect into the unassigned pool, refreshes known devices and stores a reconciliation report",
                 "consumes": [
                     "application/json"
                 ],
@@ -899,24 +991,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Devices"
+                    "CRON"
                 ],
-                "summary": "Update Device",
+                "summary": "Sync Devices from Mio Connect",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Device ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Device",
-                        "name": "request",
+                        "description": "Token Request",
+                        "name": "CronToken",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/device.UpdateRequest"
+                            "$ref": "#/definitions/cron.Request"
                         }
                     }
                 ],
@@ -924,7 +1009,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "$ref": "#/definitions/models.DeviceSyncRun"
                         }
                     },
                     "400": {
@@ -933,20 +1018,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "502": {
+                        "description": "Bad Gateway",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -954,9 +1033,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/diagnoses": {
-            "get": {
-                "description": "List Diagnosis Codes",
+        "/cron/trigger-cpt-worker": {
+            "post": {
+                "description": "CRON ONLY - Triggers CPT Worker",
                 "consumes": [
                     "application/json"
                 ],
@@ -964,17 +1043,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "CRON"
+                ],
+                "summary": "Trigger CPT Worker",
+                "parameters": [
+                    {
+                        "description": "Token Request",
+                        "name": "CronToken",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cron.Request"
+                        }
+                    }
                 ],
-                "summary": "List Diagnosis Codes",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Diagnosis"
-                            }
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
@@ -986,9 +1076,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/interaction": {
-            "post": {
-                "description": "Create an interaction",
+        "/device/assign-device": {
+            "patch": {
+                "description": "Assign Device",
                 "consumes": [
                     "application/json"
                 ],
@@ -996,25 +1086,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Interaction"
+                    "Devices"
                 ],
-                "summary": "Create Interaction",
+                "summary": "Assign Device",
                 "parameters": [
                     {
-                        "description": "Create Request",
-                        "name": "create",
+                        "description": "Assign Device",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/interaction.CreateRequest"
+                            "$ref": "#/definitions/device.DeviceAssignRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Interaction"
+                            "$ref": "#/definitions/dto.MessageResponse"
                         }
                     },
                     "400": {
@@ -1032,34 +1122,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/interaction/{id}": {
+        "/device/available-devices": {
             "get": {
-                "description": "Get interaction(s)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get Available Devices",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Interaction"
-                ],
-                "summary": "Get Interaction(s)",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path"
-                    }
+                    "Devices"
                 ],
+                "summary": "Get Available Devices",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Interaction"
+                                "$ref": "#/definitions/models.DeviceDTO"
                             }
                         }
                     },
@@ -1069,12 +1148,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1082,9 +1155,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete an interaction",
+            }
+        },
+        "/device/firmware-report": {
+            "get": {
+                "description": "Report which firmware versions are deployed per device model and the quality of the readings uploaded with each version, to spot firmware regressions. Admins see the whole fleet or one organization, org admins their organization.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1092,23 +1167,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Interaction"
+                    "Devices"
                 ],
-                "summary": "Delete an Interaction",
+                "summary": "Get Firmware Report",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Organization ID, admins only",
+                        "name": "organization_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days of readings to include (default 30, max 365)",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.FirmwareVersionReport"
+                            }
                         }
                     },
                     "400": {
@@ -1130,9 +1213,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update an interaction",
+            }
+        },
+        "/device/shipment": {
+            "get": {
+                "description": "List device shipments, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -1140,32 +1225,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Interaction"
+                    "Devices"
                 ],
-                "summary": "Update an Interaction",
+                "summary": "List Shipments",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patient_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Update Request",
-                        "name": "update",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/interaction.UpdateRequest"
-                        }
+                        "enum": [
+                            "Pending",
+                            "Ordered",
+                            "Shipped",
+                            "Delivered",
+                            "Cancelled",
+                            "Failed"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Organization ID (admin only)",
+                        "name": "organization_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Shipment"
+                            }
                         }
                     },
                     "400": {
@@ -1187,11 +1285,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/mio/forwardstatus": {
+            },
             "post": {
-                "description": "Mio Connect Status Ingestion Endpoint (Webhook)",
+                "description": "Order devices from Mio Connect to the patient's address. A failed order is kept with the error Mio returned.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1199,23 +1295,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mio (DO NOT USE)"
+                    "Devices"
                 ],
-                "summary": "Ingest Status",
+                "summary": "Create Shipment",
                 "parameters": [
                     {
-                        "description": "Request",
-                        "name": "create",
+                        "description": "Shipment",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/device.RequestStatus"
+                            "$ref": "#/definitions/device.CreateShipmentRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Shipment"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1229,18 +1328,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/mio/forwardtelemetry": {
-            "post": {
-                "description": "Mio Connect Data Ingestion Endpoint (Webhook)",
+        "/device/shipment/{id}": {
+            "get": {
+                "description": "Get a device shipment with its items and linked devices",
                 "consumes": [
                     "application/json"
                 ],
@@ -1248,23 +1365,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mio (DO NOT USE)"
+                    "Devices"
                 ],
-                "summary": "Ingest Data",
+                "summary": "Get Shipment",
                 "parameters": [
                     {
-                        "description": "Request",
-                        "name": "create",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/device.RequestTelemetry"
-                        }
+                        "type": "string",
+                        "description": "Shipment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Shipment"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1278,8 +1396,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1287,9 +1411,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/mio/status/{id}": {
-            "get": {
-                "description": "Get Status Data for given ID",
+        "/device/shipment/{id}/refresh": {
+            "post": {
+                "description": "Pull the shipment's status from Mio Connect now instead of waiting for the scheduled sync",
                 "consumes": [
                     "application/json"
                 ],
@@ -1297,37 +1421,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mio"
+                    "Devices"
                 ],
-                "summary": "Get Status Data",
+                "summary": "Refresh Shipment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Device ID",
+                        "description": "Shipment ID",
                         "name": "id",
-                        "in": "path"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query"
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.DeviceStatusData"
-                            }
+                            "$ref": "#/definitions/models.Shipment"
                         }
                     },
                     "400": {
@@ -1342,18 +1452,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    }
-                }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
             }
         },
-        "/mio/telemetry/{id}": {
+        "/device/sync-run": {
             "get": {
-                "description": "Get Telemetry Data for given ID",
+                "description": "List the latest device sync runs with their counts, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -1361,27 +1489,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mio"
+                    "Devices"
                 ],
-                "summary": "Get Telemetry Data",
+                "summary": "List Device Sync Runs",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Device ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
+                        "type": "integer",
+                        "description": "Number of runs (default 20, max 100)",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -1391,7 +1506,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.DeviceTelemetryData"
+                                "$ref": "#/definitions/models.DeviceSyncRun"
                             }
                         }
                     },
@@ -1407,6 +1522,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1414,11 +1535,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/mio/telemetry/{id}/count": {
-            "get": {
-                "description": "Get Number of Telemetry Entries This Week for given ID",
+            },
+            "post": {
+                "description": "Sync the device inventory with Mio Connect now and return the reconciliation report",
                 "consumes": [
                     "application/json"
                 ],
@@ -1426,33 +1545,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mio"
-                ],
-                "summary": "Get Number of Telemetry Entries This Week",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Device ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Devices"
                 ],
+                "summary": "Sync Devices",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/models.DeviceSyncRun"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1466,9 +1576,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/mio/telemetry/{id}/latest": {
+        "/device/sync-run/{id}": {
             "get": {
-                "description": "Get Latest Telemetry Data for given ID",
+                "description": "Get a device sync run with its findings: added, updated, missing upstream, unknown model and failed devices",
                 "consumes": [
                     "application/json"
                 ],
@@ -1476,13 +1586,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mio"
+                    "Devices"
                 ],
-                "summary": "Get Latest Telemetry Data",
+                "summary": "Get Device Sync Run",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Device ID",
+                        "description": "Sync Run ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1492,7 +1602,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.DeviceTelemetryData"
+                            "$ref": "#/definitions/models.DeviceSyncRun"
                         }
                     },
                     "400": {
@@ -1507,54 +1617,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/organization": {
-            "post": {
-                "description": "ADMIN ONLY - Create Organization",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Organization"
-                ],
-                "summary": "Create Organization",
-                "parameters": [
-                    {
-                        "description": "Create Request",
-                        "name": "create",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/organization.CreateRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1562,9 +1632,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/organization/{id}": {
+        "/device/{id}": {
             "get": {
-                "description": "Get Organization by ID, if ID is not provided, get self Organization, if admin \"all\" gets all",
+                "description": "Get devices by id, set id to 'all' to get all devices",
                 "consumes": [
                     "application/json"
                 ],
@@ -1572,16 +1642,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Devices"
                 ],
-                "summary": "Get Organization",
+                "summary": "Get Devices",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Organization ID",
+                        "type": "string",
+                        "description": "Device ID",
                         "name": "id",
-                        "in": "path",
-                        "required": true
+                        "in": "path"
                     }
                 ],
                 "responses": {
@@ -1590,7 +1659,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Organization"
+                                "$ref": "#/definitions/models.Device"
                             }
                         }
                     },
@@ -1600,6 +1669,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1609,7 +1684,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "ADMIN ONLY - Delete Organization",
+                "description": "Delete Device",
                 "consumes": [
                     "application/json"
                 ],
@@ -1617,13 +1692,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Devices"
                 ],
-                "summary": "Delete Organization",
+                "summary": "Delete Device",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Organization ID",
+                        "type": "string",
+                        "description": "Device ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1642,6 +1717,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1651,7 +1738,7 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "DOCTOR/ADMIN ONLY - Update Organization",
+                "description": "Update Device",
                 "consumes": [
                     "application/json"
                 ],
@@ -1659,24 +1746,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Devices"
                 ],
-                "summary": "update Organization",
+                "summary": "Update Device",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Organization ID",
+                        "type": "string",
+                        "description": "Device ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update Request",
-                        "name": "update",
+                        "description": "Update Device",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/organization.UpdateRequest"
+                            "$ref": "#/definitions/device.UpdateRequest"
                         }
                     }
                 ],
@@ -1693,6 +1780,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1702,9 +1801,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/organization/{id}/billing-report": {
+        "/device/{id}/assignments": {
             "get": {
-                "description": "Get Billing Report",
+                "description": "List the patients that held a device, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -1712,49 +1811,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Devices"
                 ],
-                "summary": "Get Billing Report",
+                "summary": "List Device Assignments",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Organization ID",
+                        "type": "string",
+                        "description": "Device ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "RPM",
-                            "CCM",
-                            "PCM",
-                            "BHI"
-                        ],
-                        "type": "string",
-                        "description": "Service",
-                        "name": "service",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/organization.BillingReportResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DeviceAssignment"
+                            }
                         }
                     },
                     "400": {
@@ -1763,6 +1839,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1772,9 +1866,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/organization/{id}/devices": {
+        "/device/{id}/logs": {
             "get": {
-                "description": "Get Devices in Organization",
+                "description": "List the hardware and firmware versions a device reported, newest first. A new log is kept every time a version changes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1782,13 +1876,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Devices"
                 ],
-                "summary": "Get Devices in Organization",
+                "summary": "List Device Logs",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Organization ID",
+                        "type": "string",
+                        "description": "Device ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1800,7 +1894,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Device"
+                                "$ref": "#/definitions/models.DeviceLogData"
                             }
                         }
                     },
@@ -1810,6 +1904,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1819,9 +1931,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/organization/{id}/interaction-setting": {
-            "get": {
-                "description": "Get Interaction Setting",
+        "/device/{id}/status": {
+            "patch": {
+                "description": "Move a device through its inventory lifecycle. Returned, lost and retired devices are taken back from their patient.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1829,31 +1941,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Devices"
                 ],
-                "summary": "Get Interaction Setting",
+                "summary": "Update Device Status",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Organization ID",
+                        "type": "string",
+                        "description": "Device ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Filter",
-                        "name": "filter",
-                        "in": "query",
-                        "required": true
+                        "description": "Device Status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/device.DeviceStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.Device"
                         }
                     },
                     "400": {
@@ -1862,6 +1975,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1869,9 +2000,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Upsert Interaction Setting",
+            }
+        },
+        "/diagnoses": {
+            "get": {
+                "description": "List Diagnosis Codes",
                 "consumes": [
                     "application/json"
                 ],
@@ -1881,22 +2014,47 @@ const docTemplate = `{
                 "tags": [
                     "Organization"
                 ],
-                "summary": "Upsert Interaction Setting",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Organization ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                "summary": "List Diagnosis Codes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Diagnosis"
+                            }
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/interaction": {
+            "post": {
+                "description": "Create an interaction",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Interaction"
+                ],
+                "summary": "Create Interaction",
+                "parameters": [
                     {
-                        "description": "Upsert Request",
-                        "name": "upsert",
+                        "description": "Create Request",
+                        "name": "create",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/organization.InteractionSettingData"
+                            "$ref": "#/definitions/interaction.CreateRequest"
                         }
                     }
                 ],
@@ -1904,7 +2062,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "$ref": "#/definitions/models.Interaction"
                         }
                     },
                     "400": {
@@ -1922,9 +2080,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/organization/{id}/telemetry-alert": {
+        "/interaction/{id}": {
             "get": {
-                "description": "List Telemetry Alert",
+                "description": "Get interaction(s)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1932,53 +2090,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Interaction"
                 ],
-                "summary": "List Telemetry Alert",
+                "summary": "Get Interaction(s)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Organization ID",
+                        "description": "ID",
                         "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Size",
-                        "name": "size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort By",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort Direction",
-                        "name": "sort_direction",
-                        "in": "query"
+                        "in": "path"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/organization.TelemetryAlertResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Interaction"
+                            }
                         }
                     },
                     "400": {
@@ -1987,6 +2117,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1994,11 +2130,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/organization/{id}/telemetry-alert/{alert}/resolve": {
-            "patch": {
-                "description": "Resolve Telemetry Alert",
+            },
+            "delete": {
+                "description": "Delete an interaction",
                 "consumes": [
                     "application/json"
                 ],
@@ -2006,23 +2140,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Interaction"
                 ],
-                "summary": "Resolve Telemetry Alert",
+                "summary": "Delete an Interaction",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Organization ID",
+                        "description": "ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Alert ID",
-                        "name": "alert",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -2038,6 +2165,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2045,11 +2178,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/patient/{id}": {
-            "get": {
-                "description": "Gets patients, if ID is specified, gets specific patient, if ID is \"all\", gets all patients",
+            },
+            "patch": {
+                "description": "Update an interaction",
                 "consumes": [
                     "application/json"
                 ],
@@ -2057,31 +2188,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Interaction"
                 ],
-                "summary": "Get Patients(s)",
+                "summary": "Update an Interaction",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Patient ID",
+                        "type": "integer",
+                        "description": "ID",
                         "name": "id",
-                        "in": "path"
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Org Filter",
-                        "name": "org",
-                        "in": "query"
+                        "description": "Update Request",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/interaction.UpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.UserResponse"
-                            }
+                            "$ref": "#/definitions/dto.MessageResponse"
                         }
                     },
                     "400": {
@@ -2096,12 +2228,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2111,9 +2237,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/services": {
-            "get": {
-                "description": "List Services",
+        "/mio/forwardlog": {
+            "post": {
+                "description": "Mio Connect Device Log Ingestion Endpoint (Webhook). Stores the hardware, MCU, app, modem and BPM algorithm versions the device reports and keeps the device's firmware version current. A log with the same versions as the last one only refreshes it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2121,17 +2247,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Mio (DO NOT USE)"
                 ],
-                "summary": "List Services",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Ingest Log",
+                "parameters": [
+                    {
+                        "description": "Request",
+                        "name": "create",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Service"
-                            }
+                            "$ref": "#/definitions/device.RequestLog"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
@@ -2143,9 +2286,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user": {
+        "/mio/forwardstatus": {
             "post": {
-                "description": "ADMIN ONLY - Create User",
+                "description": "Mio Connect Status Ingestion Endpoint (Webhook)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2153,26 +2296,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Mio (DO NOT USE)"
                 ],
-                "summary": "Create User",
+                "summary": "Ingest Status",
                 "parameters": [
                     {
-                        "description": "Create Request",
+                        "description": "Request",
                         "name": "create",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.CreateRequest"
+                            "$ref": "#/definitions/device.RequestStatus"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2186,12 +2326,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2201,9 +2335,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/avatar": {
+        "/mio/forwardtelemetry": {
             "post": {
-                "description": "Upload User Avatar",
+                "description": "Mio Connect Data Ingestion Endpoint (Webhook)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2211,37 +2345,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Mio (DO NOT USE)"
                 ],
-                "summary": "Upload User Avatar",
+                "summary": "Ingest Data",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Avatar",
-                        "name": "avatar",
-                        "in": "formData",
-                        "required": true
+                        "description": "Request",
+                        "name": "create",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/device.RequestTelemetry"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/avatar/{avatarPath}": {
+        "/mio/status/{id}": {
             "get": {
-                "description": "Get User Avatar",
+                "description": "Get the status history of a device. The range is given either as dates (end date inclusive) or as RFC3339 timestamps, and defaults to the last 7 days.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2249,34 +2394,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Mio"
                 ],
-                "summary": "Get User Avatar",
+                "summary": "Get Status Data",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Avatar Path",
-                        "name": "avatarPath",
-                        "in": "path",
-                        "required": true
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From (RFC3339), overrides start_date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To (RFC3339), overrides end_date",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DeviceStatusData"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/count": {
-            "get": {
-                "description": "ADMIN ONLY - Count Users",
+        "/mio/telemetry/manual": {
+            "post": {
+                "description": "Record a reading that was called in, taken at the clinic or read off a device by hand. The reading keeps who entered it and goes through the same alerting as device uploads, but never counts towards device supply billing like 99453 and 99454. Patients can only report their own readings.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2284,44 +2470,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Mio"
                 ],
-                "summary": "Count Users",
+                "summary": "Create Manual Reading",
                 "parameters": [
                     {
-                        "enum": [
-                            "admin",
-                            "doctor",
-                            "nurse",
-                            "patient",
-                            "doctornv",
-                            "nursenv",
-                            "patientnv"
-                        ],
-                        "type": "string",
-                        "description": "Role Filter",
-                        "name": "filter",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "critical",
-                            "warning"
-                        ],
-                        "type": "string",
-                        "description": "Status Filter",
-                        "name": "status",
-                        "in": "query"
+                        "description": "Manual Reading",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/device.ManualReadingRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
-                            }
+                            "$ref": "#/definitions/models.DeviceTelemetryData"
                         }
                     },
                     "400": {
@@ -2342,6 +2509,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2351,9 +2524,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/org/{id}": {
-            "get": {
-                "description": "ADMIN \u0026 DOCTOR ONLY - if ID is specified, gets users in that organization, if ID is not specified, gets users in self's organization",
+        "/mio/telemetry/reading/{id}/invalidate": {
+            "patch": {
+                "description": "Mark a reading invalid with a reason. The reading is kept but no longer counts towards billing reading days, alerts or stats, and open alerts raised by this reading alone are resolved.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2361,50 +2534,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Mio"
                 ],
-                "summary": "Get Users in Organization",
+                "summary": "Invalidate Reading",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Organization ID",
-                        "name": "id",
-                        "in": "path"
-                    },
-                    {
-                        "enum": [
-                            "admin",
-                            "doctor",
-                            "nurse",
-                            "patient",
-                            "doctornv",
-                            "nursenv",
-                            "patientnv"
-                        ],
                         "type": "string",
-                        "description": "Role Filter",
-                        "name": "filter",
-                        "in": "query"
+                        "description": "Reading ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "critical",
-                            "warning"
-                        ],
-                        "type": "string",
-                        "description": "Status Filter",
-                        "name": "filter",
-                        "in": "query"
+                        "description": "Invalidate Reading",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/device.InvalidateReadingRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.User"
-                            }
+                            "$ref": "#/definitions/models.DeviceTelemetryData"
                         }
                     },
                     "400": {
@@ -2425,6 +2580,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2434,9 +2595,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/org/{id}/count": {
-            "get": {
-                "description": "Count Users in Organization",
+        "/mio/telemetry/reading/{id}/restore": {
+            "patch": {
+                "description": "Undo the invalidation of a reading, its quality is derived from the device data again. Alerts resolved on invalidation are not reopened.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2444,52 +2605,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Mio"
                 ],
-                "summary": "Count Users in Organization",
+                "summary": "Restore Reading",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Organization ID",
+                        "type": "string",
+                        "description": "Reading ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "enum": [
-                            "admin",
-                            "doctor",
-                            "nurse",
-                            "patient",
-                            "doctornv",
-                            "nursenv",
-                            "patientnv"
-                        ],
-                        "type": "string",
-                        "description": "Role Filter",
-                        "name": "filter",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "critical",
-                            "warning"
-                        ],
-                        "type": "string",
-                        "description": "Status Filter",
-                        "name": "status",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
-                            }
-                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DeviceTelemetryData"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2509,6 +2642,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2518,9 +2657,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/verifyUserField": {
+        "/mio/telemetry/{id}": {
             "get": {
-                "description": "Verify if field already exists\nwhen is_available is true that means the phone/email does not already exist in the DB and can be used and If the response is false that means the value already exists",
+                "description": "Get Telemetry Data for given ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2528,34 +2667,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Mio"
                 ],
-                "summary": "Verify if field already exists Field",
+                "summary": "Get Telemetry Data",
                 "parameters": [
                     {
-                        "enum": [
-                            "email",
-                            "phone"
-                        ],
                         "type": "string",
-                        "description": "Field",
-                        "name": "field",
-                        "in": "query",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Value",
-                        "name": "value",
-                        "in": "query",
-                        "required": true
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.VerifyUserFieldResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DeviceTelemetryData"
+                            }
                         }
                     },
                     "400": {
@@ -2563,13 +2706,25 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/{id}": {
+        "/mio/telemetry/{id}/count": {
             "get": {
-                "description": "Gets users, if ID is specified, gets specific user, if ID is \"all\", gets all users",
+                "description": "Get Number of Telemetry Entries over the last seven days, today included, for given ID. Days follow the patient's timezone.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2577,50 +2732,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Mio"
                 ],
-                "summary": "Get User(s)",
+                "summary": "Get Number of Telemetry Entries This Week",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Device ID",
                         "name": "id",
-                        "in": "path"
-                    },
-                    {
-                        "enum": [
-                            "admin",
-                            "doctor",
-                            "nurse",
-                            "patient",
-                            "doctornv",
-                            "nursenv",
-                            "patientnv"
-                        ],
-                        "type": "string",
-                        "description": "Role Filter",
-                        "name": "filter",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "critical",
-                            "warning"
-                        ],
-                        "type": "string",
-                        "description": "Status Filter",
-                        "name": "filter",
-                        "in": "query"
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.User"
-                            }
+                            "type": "integer"
                         }
                     },
                     "400": {
@@ -2635,12 +2763,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2648,9 +2770,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Admin \u0026 Doctor ONLY - Delete User",
+            }
+        },
+        "/mio/telemetry/{id}/latest": {
+            "get": {
+                "description": "Get Latest Telemetry Data for given ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2658,13 +2782,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Mio"
                 ],
-                "summary": "Delete User",
+                "summary": "Get Latest Telemetry Data",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Device ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2674,7 +2798,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "$ref": "#/definitions/models.DeviceTelemetryData"
                         }
                     },
                     "400": {
@@ -2696,9 +2820,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Updates user, if ID is specified, updates specific user, if ID is not specified, updates self",
+            }
+        },
+        "/organization": {
+            "post": {
+                "description": "ADMIN ONLY - Create Organization",
                 "consumes": [
                     "application/json"
                 ],
@@ -2706,31 +2832,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Organization"
                 ],
-                "summary": "Update User",
+                "summary": "Create Organization",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path"
-                    },
-                    {
-                        "description": "Update Request",
-                        "name": "update",
+                        "description": "Create Request",
+                        "name": "create",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.UpdateRequest"
+                            "$ref": "#/definitions/organization.CreateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/dto.MessageResponse"
                         }
                     },
                     "400": {
@@ -2739,18 +2859,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2760,20 +2868,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{id}/alert-threshold": {
+        "/organization/{id}": {
             "get": {
-                "description": "List Alert Thresholds",
+                "description": "Get Organization by ID, if ID is not provided, get self Organization, if admin \"all\" gets all",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Organization"
                 ],
-                "summary": "List Alert Thresholds",
+                "summary": "Get Organization",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2785,7 +2896,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/user.AlertThresholdData"
+                                "$ref": "#/definitions/models.Organization"
                             }
                         }
                     },
@@ -2803,8 +2914,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Upsert Alert Threshold",
+            "delete": {
+                "description": "ADMIN ONLY - Delete Organization",
                 "consumes": [
                     "application/json"
                 ],
@@ -2812,30 +2923,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Organization"
                 ],
-                "summary": "Upsert Alert Threshold",
+                "summary": "Delete Organization",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Upsert Request",
-                        "name": "upsert",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.AlertThresholdData"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/dto.MessageResponse"
                         }
@@ -2853,11 +2955,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user/{id}/careplans": {
-            "get": {
-                "description": "If ID is specified, gets care plans in that user, if ID is not specified, gets care plans in self",
+            },
+            "patch": {
+                "description": "DOCTOR/ADMIN ONLY - Update Organization",
                 "consumes": [
                     "application/json"
                 ],
@@ -2865,22 +2965,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Organization"
                 ],
-                "summary": "Get care plans in User",
+                "summary": "update Organization",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Organization ID",
                         "name": "id",
-                        "in": "path"
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Request",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/organization.UpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CareplanSheetResponse"
+                            "$ref": "#/definitions/dto.MessageResponse"
                         }
                     },
                     "400": {
@@ -2889,18 +2999,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2910,9 +3008,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{id}/devices": {
+        "/organization/{id}/alert-notification-rule": {
             "get": {
-                "description": "If ID is specified, gets devices in that user, if ID is not specified, gets devices in self",
+                "description": "List the alert notification rules of an organization ordered by tier",
                 "consumes": [
                     "application/json"
                 ],
@@ -2920,15 +3018,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Organization"
                 ],
-                "summary": "Get Devices in User",
+                "summary": "List Alert Notification Rules",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Organization ID",
                         "name": "id",
-                        "in": "path"
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2937,7 +3036,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Device"
+                                "$ref": "#/definitions/models.AlertNotificationRule"
                             }
                         }
                     },
@@ -2947,14 +3046,53 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a rule for who is notified about telemetry alerts, over which channel and at which escalation tier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Create Alert Notification Rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    {
+                        "description": "Create Request",
+                        "name": "create",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/organization.AlertNotificationRuleData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.AlertNotificationRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2968,9 +3106,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{id}/diagnoses": {
-            "get": {
-                "description": "Get Diagnoses",
+        "/organization/{id}/alert-notification-rule/{rule}": {
+            "delete": {
+                "description": "Delete an alert notification rule of an organization",
                 "consumes": [
                     "application/json"
                 ],
@@ -2978,26 +3116,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Organization"
                 ],
-                "summary": "Get Diagnoses",
+                "summary": "Delete Alert Notification Rule",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "rule",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Diagnoses",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.MessageResponse"
                         }
                     },
                     "400": {
@@ -3013,9 +3155,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Upsert Diagnoses",
+            }
+        },
+        "/organization/{id}/api-key": {
+            "get": {
+                "description": "List the organization's API keys with their scopes, expiry, last use and revocation, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -3023,32 +3167,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Organization"
                 ],
-                "summary": "Upsert Diagnoses",
+                "summary": "List API Keys",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Upsert Request",
-                        "name": "upsert",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.DiagnosisData"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.APIKey"
+                            }
                         }
                     },
                     "400": {
@@ -3057,6 +3195,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3064,11 +3208,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user/{id}/interactions": {
-            "get": {
-                "description": "If ID is specified, gets interactions in that user, if ID is not specified, gets interactions in self",
+            },
+            "post": {
+                "description": "Issue an API key for an integration or script of the organization. The key acts as one of the organization's users, limited to its scopes, and is sent as \"Authorization: Bearer \u003ctoken\u003e\". The token is only returned in this response. API keys can't manage API keys.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3076,38 +3218,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Organization"
                 ],
-                "summary": "Get Interactions in User",
+                "summary": "Create API Key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "API Key",
+                        "name": "key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/organization.APIKeyData"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Interaction"
-                            }
+                            "$ref": "#/definitions/organization.APIKeyCreatedResponse"
                         }
                     },
                     "400": {
@@ -3137,9 +3273,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{id}/interactions/duration": {
-            "get": {
-                "description": "Get the sum of the interaction durations for a user",
+        "/organization/{id}/api-key/{key}/revoke": {
+            "post": {
+                "description": "Revoke an API key, requests with its token are rejected from then on",
                 "consumes": [
                     "application/json"
                 ],
@@ -3147,35 +3283,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Interaction"
+                    "Organization"
                 ],
-                "summary": "Total user interaction duration",
+                "summary": "Revoke API Key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "API Key ID",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/models.APIKey"
                         }
                     },
                     "400": {
@@ -3190,6 +3321,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3199,9 +3342,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{id}/patient-service": {
+        "/organization/{id}/billing-report": {
             "get": {
-                "description": "List Patient Services",
+                "description": "Get Billing Report",
                 "consumes": [
                     "application/json"
                 ],
@@ -3209,51 +3352,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Organization"
                 ],
-                "summary": "List Patient Services",
+                "summary": "Get Billing Report",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "enum": [
-                            "active",
-                            "inactive",
-                            "all"
-                        ],
                         "type": "string",
-                        "description": "Status",
-                        "name": "status",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
                         "in": "query",
                         "required": true
                     },
-                    {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Size",
-                        "name": "size",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Sort By",
-                        "name": "sort_by",
-                        "in": "query"
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
                     },
                     {
+                        "enum": [
+                            "RPM",
+                            "CCM",
+                            "PCM",
+                            "BHI"
+                        ],
                         "type": "string",
-                        "description": "Sort Direction",
-                        "name": "sort_direction",
+                        "description": "Service",
+                        "name": "service",
                         "in": "query"
                     }
                 ],
@@ -3261,7 +3394,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/user.PatientServiceResponse"
+                            "$ref": "#/definitions/organization.BillingReportResponse"
                         }
                     },
                     "400": {
@@ -3277,9 +3410,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Upsert Patient Services",
+            }
+        },
+        "/organization/{id}/clinical-summary": {
+            "get": {
+                "description": "List the clinical summaries of the organization's patients for a month with their status and sign-off",
                 "consumes": [
                     "application/json"
                 ],
@@ -3287,32 +3422,93 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Organization"
                 ],
-                "summary": "Upsert Patient Services",
+                "summary": "List Clinical Summaries",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Upsert Request",
-                        "name": "upsert",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.PatientServiceData"
+                        "type": "string",
+                        "description": "Month (YYYY-MM)",
+                        "name": "period",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ClinicalSummary"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Generate the month's clinical summary PDF for every RPM patient of the organization. Existing unsigned summaries are regenerated, signed ones are kept. The reports are generated in the background, list the month's summaries for their status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Generate Clinical Summaries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Month (YYYY-MM)",
+                        "name": "period",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/organization.ClinicalSummaryPeriodData"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ClinicalSummary"
+                            }
                         }
                     },
                     "400": {
@@ -3321,6 +3517,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3329,1131 +3531,7486 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "careplan.CreateRequest": {
-            "type": "object",
-            "required": [
-                "user_id"
-            ],
-            "properties": {
-                "doctor_id": {
-                    "type": "integer"
-                },
-                "user_id": {
-                    "type": "integer"
+        },
+        "/organization/{id}/device-health": {
+            "get": {
+                "description": "Health of the organization's assigned devices with their open device alerts (no readings, low battery, weak signal, no heartbeat)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Get Device Health",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only devices with open alerts",
+                        "name": "unhealthy",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/organization.DeviceHealthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "cron.Request": {
-            "type": "object",
-            "required": [
-                "token"
-            ],
-            "properties": {
-                "token": {
-                    "type": "string"
+        "/organization/{id}/devices": {
+            "get": {
+                "description": "Get Devices in Organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Get Devices in Organization",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Device"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "device.DeviceAssignRequest": {
-            "type": "object",
-            "required": [
-                "device_id",
-                "user_id"
-            ],
-            "properties": {
-                "device_id": {
-                    "type": "integer"
-                },
-                "user_id": {
-                    "type": "integer"
+        "/organization/{id}/events": {
+            "get": {
+                "description": "Server-sent events stream of the organization's new telemetry (telemetry.created), raised, updated and resolved alerts (alert.raised, alert.updated, alert.resolved) and device status reports (device.status). Each message carries an Event as JSON.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Stream Organization Events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/event.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "device.MioData": {
-            "type": "object",
-            "required": [
-                "bat",
-                "data_type",
-                "imei"
-            ],
-            "properties": {
-                "bat": {
-                    "type": "integer"
-                },
-                "data": {
-                    "type": "integer"
-                },
-                "data_type": {
-                    "type": "string"
-                },
-                "dia": {
-                    "type": "integer"
-                },
-                "hand": {
-                    "type": "boolean"
+        "/organization/{id}/interaction-setting": {
+            "get": {
+                "description": "Get Interaction Setting",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Get Interaction Setting",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter",
+                        "name": "filter",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Upsert Interaction Setting",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Upsert Interaction Setting",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Upsert Request",
+                        "name": "upsert",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/organization.InteractionSettingData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/mfa-policy": {
+            "get": {
+                "description": "Get the roles of the organization whose users must use multi-factor authentication",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Get MFA Policy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MFAPolicy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Set the roles of the organization whose users must use multi-factor authentication. Users of those roles without MFA have to set it up at their next login before they can use the API, and can't turn it off.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Upsert MFA Policy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "MFA Policy",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/organization.MFAPolicyData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MFAPolicy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/reading-adherence": {
+            "get": {
+                "description": "Outreach worklist of the organization's RPM patients for this month: reading days so far, days remaining, whether 16 reading days are still achievable and streak breaks. Patients needing outreach come first, numbered by priority.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Get Reading Adherence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "Met",
+                            "OnTrack",
+                            "AtRisk",
+                            "Missed"
+                        ],
+                        "type": "string",
+                        "description": "Only patients with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include patients that need no outreach",
+                        "name": "all",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/organization.ReadingAdherenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/telemetry-alert": {
+            "get": {
+                "description": "List Telemetry Alert",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "List Telemetry Alert",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status (active, unacknowledged, acknowledged, inactive, auto_resolved)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Assigned To User ID",
+                        "name": "assigned_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort By",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort Direction",
+                        "name": "sort_direction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/organization.TelemetryAlertResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/telemetry-alert-sla": {
+            "get": {
+                "description": "Time to acknowledge and time to resolve of the alerts raised in the date range, measured against the organization's targets",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Get Telemetry Alert SLA Report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start Date (MM-DD-YYYY)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (MM-DD-YYYY)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/organization.TelemetryAlertSLAResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/telemetry-alert/{alert}/acknowledge": {
+            "patch": {
+                "description": "Acknowledge a telemetry alert, which stops its notification escalation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Acknowledge Telemetry Alert",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Alert ID",
+                        "name": "alert",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/telemetry-alert/{alert}/assign": {
+            "patch": {
+                "description": "Assign a telemetry alert to a staff member of the organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Assign Telemetry Alert",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Alert ID",
+                        "name": "alert",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assign Request",
+                        "name": "assign",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/organization.AssignTelemetryAlertData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/telemetry-alert/{alert}/note": {
+            "get": {
+                "description": "List the notes of a telemetry alert",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "List Telemetry Alert Notes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Alert ID",
+                        "name": "alert",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TelemetryAlertNote"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a note to a telemetry alert",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Add Telemetry Alert Note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Alert ID",
+                        "name": "alert",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note Request",
+                        "name": "note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/organization.TelemetryAlertNoteData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.TelemetryAlertNote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/telemetry-alert/{alert}/notification": {
+            "get": {
+                "description": "List the notification delivery attempts of a telemetry alert",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "List Alert Notifications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Alert ID",
+                        "name": "alert",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AlertNotification"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/telemetry-alert/{alert}/resolve": {
+            "patch": {
+                "description": "Resolve Telemetry Alert, optionally with a closing note, an outcome and the resulting interaction",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Resolve Telemetry Alert",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Alert ID",
+                        "name": "alert",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resolve Request",
+                        "name": "resolve",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/organization.ResolveTelemetryAlertData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/telemetry-alert/{alert}/triage": {
+            "patch": {
+                "description": "Set the outcome of a telemetry alert and link it to the interaction it resulted in",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Update Telemetry Alert Triage",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Alert ID",
+                        "name": "alert",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Triage Request",
+                        "name": "triage",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/organization.TelemetryAlertTriageData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/telemetry-import": {
+            "get": {
+                "description": "List the organization's latest telemetry import batches with their counts, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "List Telemetry Imports",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of imports (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TelemetryImport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Import historical readings from another vendor's CSV or JSON export. Rows are matched to the organization's patients by MRN, name and date of birth, or email, and columns are mapped to our fields with column_map, e.g. {\"mrn\":\"Patient ID\",\"measured_at\":\"Date\",\"weight\":\"Weight (kg)\",\"weight_unit\":\"Unit\"}. Fields: mrn, email, first_name, last_name, dob, measured_at, systolic, diastolic, pulse, irregular_heartbeat, weight, weight_unit (lb or kg, default lb), glucose, glucose_unit (mg/dL or mmol/L, default mg/dL), meal. Times without an offset are read in the patient's timezone. Rows matching an existing reading are skipped as duplicates. The import runs in the background, poll the batch for its status.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Import Telemetry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV or JSON export",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "CSV",
+                            "JSON"
+                        ],
+                        "type": "string",
+                        "description": "File format, taken from the file extension if not set",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "MRN",
+                            "NameDOB",
+                            "Email"
+                        ],
+                        "type": "string",
+                        "description": "How rows are matched to patients (default MRN)",
+                        "name": "patient_match",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object mapping our fields to the file's column names",
+                        "name": "column_map",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.TelemetryImport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/telemetry-import/{import}": {
+            "get": {
+                "description": "Get a telemetry import batch with its status and counts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Get Telemetry Import",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Import ID",
+                        "name": "import",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TelemetryImport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/telemetry-import/{import}/errors": {
+            "get": {
+                "description": "Download the rows of an import batch that could not be imported as CSV, with the line, column and reason of each",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Download Telemetry Import Errors",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Import ID",
+                        "name": "import",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV error report",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/telemetry-import/{import}/revert": {
+            "post": {
+                "description": "Delete every reading an import batch wrote. Batches that are still running or older than 30 days can't be reverted. A batch whose import was stopped by a restart is failed after 6 hours and can be reverted then.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Revert Telemetry Import",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Import ID",
+                        "name": "import",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TelemetryImport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/threshold-template": {
+            "get": {
+                "description": "List the organization default and diagnosis alert threshold templates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "List Threshold Templates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ThresholdTemplate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Upsert organization default alert thresholds, or the thresholds for patients with an ICD-10 diagnosis when diagnosis_code is set",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Upsert Threshold Templates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Upsert Request",
+                        "name": "upsert",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/organization.ThresholdTemplateData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/threshold-template/{template}": {
+            "delete": {
+                "description": "Delete an alert threshold template of the organization",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Delete Threshold Template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patient/{id}": {
+            "get": {
+                "description": "Gets patients, if ID is specified, gets specific patient, if ID is \"all\", gets all patients",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Patients(s)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Org Filter",
+                        "name": "org",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.UserResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services": {
+            "get": {
+                "description": "List Services",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "List Services",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Service"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "post": {
+                "description": "ADMIN ONLY - Create User",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create User",
+                "parameters": [
+                    {
+                        "description": "Create Request",
+                        "name": "create",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.CreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/avatar": {
+            "post": {
+                "description": "Upload User Avatar",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Upload User Avatar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Avatar",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/avatar/{avatarPath}": {
+            "get": {
+                "description": "Get User Avatar",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User Avatar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Avatar Path",
+                        "name": "avatarPath",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/count": {
+            "get": {
+                "description": "ADMIN ONLY - Count Users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Count Users",
+                "parameters": [
+                    {
+                        "enum": [
+                            "admin",
+                            "doctor",
+                            "nurse",
+                            "patient",
+                            "doctornv",
+                            "nursenv",
+                            "patientnv"
+                        ],
+                        "type": "string",
+                        "description": "Role Filter",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "critical",
+                            "warning"
+                        ],
+                        "type": "string",
+                        "description": "Status Filter",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/mfa": {
+            "get": {
+                "description": "Get whether MFA is on for the logged in user, whether it is required for them and how many recovery codes are left",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get MFA Status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.MFAStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/mfa/disable": {
+            "post": {
+                "description": "Turn MFA off for the logged in user, removing their authenticator app and recovery codes. Not allowed for platform admins, or when the user's organization requires MFA for their role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Turn Off MFA",
+                "parameters": [
+                    {
+                        "description": "Authenticator or Recovery Code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.MFACodeData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/mfa/email": {
+            "patch": {
+                "description": "Turn logging in with a code sent to the logged in user's email, instead of the authenticator app, on or off",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update MFA Email Fallback",
+                "parameters": [
+                    {
+                        "description": "Email Fallback",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.MFAEmailFallbackData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.MFAStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/mfa/recovery-codes": {
+            "post": {
+                "description": "Replace the logged in user's recovery codes, the previous ones stop working. The response holds the new codes, which are only shown once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Regenerate MFA Recovery Codes",
+                "parameters": [
+                    {
+                        "description": "Authenticator or Recovery Code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.MFACodeData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.MFARecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/mfa/totp": {
+            "post": {
+                "description": "Create an authenticator app secret for the logged in user. MFA is turned on once a code from the app is confirmed, starting again replaces an unconfirmed secret.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Start MFA Setup",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.MFAEnrollmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/mfa/totp/confirm": {
+            "post": {
+                "description": "Turn MFA on with a code from the authenticator app. The response holds the recovery codes, which are only shown once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Confirm MFA Setup",
+                "parameters": [
+                    {
+                        "description": "Authenticator Code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.MFACodeData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.MFARecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/org/{id}": {
+            "get": {
+                "description": "ADMIN \u0026 DOCTOR ONLY - if ID is specified, gets users in that organization, if ID is not specified, gets users in self's organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Users in Organization",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "enum": [
+                            "admin",
+                            "doctor",
+                            "nurse",
+                            "patient",
+                            "doctornv",
+                            "nursenv",
+                            "patientnv"
+                        ],
+                        "type": "string",
+                        "description": "Role Filter",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "critical",
+                            "warning"
+                        ],
+                        "type": "string",
+                        "description": "Status Filter",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/org/{id}/count": {
+            "get": {
+                "description": "Count Users in Organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Count Users in Organization",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "admin",
+                            "doctor",
+                            "nurse",
+                            "patient",
+                            "doctornv",
+                            "nursenv",
+                            "patientnv"
+                        ],
+                        "type": "string",
+                        "description": "Role Filter",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "critical",
+                            "warning"
+                        ],
+                        "type": "string",
+                        "description": "Status Filter",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/verifyUserField": {
+            "get": {
+                "description": "Verify if field already exists\nwhen is_available is true that means the phone/email does not already exist in the DB and can be used and If the response is false that means the value already exists",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Verify if field already exists Field",
+                "parameters": [
+                    {
+                        "enum": [
+                            "email",
+                            "phone"
+                        ],
+                        "type": "string",
+                        "description": "Field",
+                        "name": "field",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Value",
+                        "name": "value",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VerifyUserFieldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}": {
+            "get": {
+                "description": "Gets users, if ID is specified, gets specific user, if ID is \"all\", gets all users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User(s)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "enum": [
+                            "admin",
+                            "doctor",
+                            "nurse",
+                            "patient",
+                            "doctornv",
+                            "nursenv",
+                            "patientnv"
+                        ],
+                        "type": "string",
+                        "description": "Role Filter",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "critical",
+                            "warning"
+                        ],
+                        "type": "string",
+                        "description": "Status Filter",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Admin \u0026 Doctor ONLY - Delete User",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates user, if ID is specified, updates specific user, if ID is not specified, updates self. API keys can't change email or password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "Update Request",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.UpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/adherence-reminder": {
+            "get": {
+                "description": "List the reading reminders sent to the patient, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List Adherence Reminders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AdherenceReminder"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/alert-threshold": {
+            "get": {
+                "description": "List Alert Thresholds",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List Alert Thresholds",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include thresholds inherited from organization and diagnosis templates",
+                        "name": "include_inherited",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/user.AlertThresholdData"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Upsert Alert Threshold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Upsert Alert Threshold",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Upsert Request",
+                        "name": "upsert",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.AlertThresholdData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/alert-trend-rule": {
+            "get": {
+                "description": "List Alert Trend Rules",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List Alert Trend Rules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AlertTrendRule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Upsert trend based alert rules (change over a time window) for a patient",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Upsert Alert Trend Rules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Upsert Request",
+                        "name": "upsert",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.AlertTrendRuleData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/alert-trend-rule/{rule}": {
+            "delete": {
+                "description": "Delete Alert Trend Rule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete Alert Trend Rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "rule",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/careplans": {
+            "get": {
+                "description": "If ID is specified, gets care plans in that user, if ID is not specified, gets care plans in self",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get care plans in User",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CareplanSheetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/clinical-summary": {
+            "get": {
+                "description": "List the patient's monthly clinical summaries with their sign-off, latest month first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List Clinical Summaries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ClinicalSummary"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Generate the patient's one page PDF summary of a month for provider review: BP, weight and glucose trends, averages against the patient's goals, alerts, interventions and RPM management minutes. Regenerating replaces the month's report, signed summaries can't be regenerated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Generate Clinical Summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Month (YYYY-MM)",
+                        "name": "period",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.ClinicalSummaryPeriodData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ClinicalSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/clinical-summary/{summary}/file": {
+            "get": {
+                "description": "Download the PDF of a patient's clinical summary",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Download Clinical Summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Summary ID",
+                        "name": "summary",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF report",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/clinical-summary/{summary}/sign": {
+            "post": {
+                "description": "Record the provider's sign-off of a generated clinical summary",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Sign Clinical Summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Summary ID",
+                        "name": "summary",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ClinicalSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/devices": {
+            "get": {
+                "description": "If ID is specified, gets devices in that user, if ID is not specified, gets devices in self",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Devices in User",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Device"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/diagnoses": {
+            "get": {
+                "description": "Get Diagnoses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Diagnoses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Diagnoses",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Upsert Diagnoses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Upsert Diagnoses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Upsert Request",
+                        "name": "upsert",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.DiagnosisData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/events": {
+            "get": {
+                "description": "Server-sent events stream of one patient's new telemetry, alerts and device status reports, see the organization stream for the event types",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Stream Patient Events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/event.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/interactions": {
+            "get": {
+                "description": "If ID is specified, gets interactions in that user, if ID is not specified, gets interactions in self",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Interactions in User",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Interaction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/interactions/duration": {
+            "get": {
+                "description": "Get the sum of the interaction durations for a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Interaction"
+                ],
+                "summary": "Total user interaction duration",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/mfa/reset": {
+            "post": {
+                "description": "Turn MFA off for a user who lost their authenticator app and recovery codes. If their organization requires MFA, they have to set it up again at their next login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Reset User MFA",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/patient-service": {
+            "get": {
+                "description": "List Patient Services",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List Patient Services",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "inactive",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort By",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort Direction",
+                        "name": "sort_direction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.PatientServiceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Upsert Patient Services",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Upsert Patient Services",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Upsert Request",
+                        "name": "upsert",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.PatientServiceData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/reading-adherence": {
+            "get": {
+                "description": "Get the patient's progress towards 16 reading days this month, counted in the patient's timezone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Reading Adherence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReadingAdherence"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/telemetry-stats": {
+            "get": {
+                "description": "Aggregate a patient's readings of one measurement into day, week or month buckets with min, max, mean, median, reading days and time in range against the patient's alert threshold. Systolic and diastolic stats also compare morning and evening blood pressure.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Telemetry Stats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "Systolic",
+                            "Diastolic",
+                            "Pulse",
+                            "Weight",
+                            "FastingGlucose",
+                            "PostMealGlucose"
+                        ],
+                        "type": "string",
+                        "description": "Measurement Type",
+                        "name": "measurement_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start Date (MM-DD-YYYY)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date, inclusive (MM-DD-YYYY), defaults to today",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "Bucket Size",
+                        "name": "bucket",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TelemetryStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/telemetry-summary": {
+            "get": {
+                "description": "Get the patient's readings per day and device type with the min, max and mean of each measurement, in the patient's timezone. Summaries cover archived readings too, so long ranges don't need the archive.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Telemetry Summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start Date (MM-DD-YYYY)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date, inclusive (MM-DD-YYYY), defaults to today",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DailySummary"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "careplan.CreateRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "doctor_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "cron.Request": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "device.CreateShipmentRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "patient_id"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/device.ShipmentItemRequest"
+                    }
+                },
+                "patient_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "device.DeviceAssignRequest": {
+            "type": "object",
+            "required": [
+                "device_id",
+                "user_id"
+            ],
+            "properties": {
+                "device_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "device.DeviceStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "InStock",
+                        "Shipped",
+                        "Delivered",
+                        "Active",
+                        "Returned",
+                        "Lost",
+                        "Retired"
+                    ],
+                    "example": "Shipped"
+                }
+            }
+        },
+        "device.InvalidateReadingRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Cuff was on over clothing"
+                }
+            }
+        },
+        "device.ManualReadingRequest": {
+            "type": "object",
+            "required": [
+                "device_type",
+                "patient_id",
+                "source"
+            ],
+            "properties": {
+                "blood_glucose": {
+                    "description": "Blood Glucose Meter\nBloodGlucose is in Unit, mmol/L values like 6.1 are stored converted to mg/dL",
+                    "type": "number",
+                    "maximum": 1000,
+                    "example": 110
+                },
+                "device_id": {
+                    "description": "DeviceID is the patient's device the reading was read off, if any",
+                    "type": "integer",
+                    "example": 1
+                },
+                "device_type": {
+                    "enum": [
+                        "BloodPressure",
+                        "BloodGlucose",
+                        "WeightScale"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceType"
+                        }
+                    ],
+                    "example": "BloodPressure"
+                },
+                "diastolic_bp": {
+                    "type": "integer",
+                    "maximum": 200,
+                    "example": 80
+                },
+                "irregular_heartbeat": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "meal": {
+                    "type": "string",
+                    "enum": [
+                        "before meal",
+                        "after meal"
+                    ],
+                    "example": "before meal"
+                },
+                "measured_at": {
+                    "type": "string",
+                    "example": "2021-01-01T08:00:00Z"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Taken at the clinic after a 5 minute rest"
+                },
+                "patient_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pulse": {
+                    "type": "integer",
+                    "maximum": 250,
+                    "example": 72
+                },
+                "source": {
+                    "enum": [
+                        "PatientReported",
+                        "ClinicMeasured",
+                        "Device"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ReadingSource"
+                        }
+                    ],
+                    "example": "ClinicMeasured"
+                },
+                "systolic_bp": {
+                    "description": "Sphygmomanometer",
+                    "type": "integer",
+                    "maximum": 300,
+                    "example": 120
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "mg/dL",
+                        "mmol/L"
+                    ],
+                    "example": "mg/dL"
+                },
+                "weight": {
+                    "description": "Weight Scale",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "example": 180
+                }
+            }
+        },
+        "device.MioData": {
+            "type": "object",
+            "required": [
+                "bat",
+                "data_type",
+                "imei"
+            ],
+            "properties": {
+                "bat": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "number"
+                },
+                "data_type": {
+                    "type": "string"
+                },
+                "dia": {
+                    "type": "integer"
+                },
+                "hand": {
+                    "type": "boolean"
+                },
+                "iccid": {
+                    "type": "string"
+                },
+                "ihb": {
+                    "type": "boolean"
+                },
+                "imei": {
+                    "type": "string"
+                },
+                "lts": {
+                    "type": "integer"
+                },
+                "meal": {
+                    "type": "integer"
+                },
+                "pul": {
+                    "type": "integer"
+                },
+                "sample": {
+                    "type": "integer"
+                },
+                "sample_type": {
+                    "type": "integer"
+                },
+                "sig": {
+                    "type": "integer"
+                },
+                "sig_lvl": {
+                    "type": "integer"
+                },
+                "sn": {
+                    "type": "string"
+                },
+                "sys": {
+                    "type": "integer"
+                },
+                "tri": {
+                    "type": "boolean"
+                },
+                "ts": {
+                    "type": "integer"
+                },
+                "tz": {
+                    "type": "string"
+                },
+                "uid": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "integer"
+                },
+                "upload_time": {
+                    "type": "integer"
+                },
+                "uptime": {
+                    "type": "integer"
+                },
+                "user": {
+                    "type": "integer"
+                },
+                "wet": {
+                    "type": "integer"
+                },
+                "wt": {
+                    "type": "integer"
+                }
+            }
+        },
+        "device.MioLog": {
+            "type": "object",
+            "required": [
+                "data_type",
+                "imei"
+            ],
+            "properties": {
+                "algo_ver": {
+                    "type": "string"
+                },
+                "app_ver": {
+                    "type": "string"
+                },
+                "bat": {
+                    "type": "integer"
+                },
+                "data_type": {
+                    "type": "string"
+                },
+                "hw_ver": {
+                    "type": "string"
+                },
+                "imei": {
+                    "type": "string"
+                },
+                "mcu_ver": {
+                    "type": "string"
+                },
+                "modem_ver": {
+                    "type": "string"
+                }
+            }
+        },
+        "device.MioStatus": {
+            "type": "object",
+            "required": [
+                "data_type",
+                "imei"
+            ],
+            "properties": {
+                "at_t": {
+                    "type": "integer"
+                },
+                "bat": {
+                    "type": "integer"
+                },
+                "data_type": {
+                    "type": "string"
+                },
+                "imei": {
+                    "type": "string"
+                },
+                "me_num": {
+                    "type": "integer"
+                },
+                "net": {
+                    "type": "string"
+                },
+                "ops": {
+                    "type": "string"
+                },
+                "sig": {
+                    "type": "integer"
+                },
+                "tp": {
+                    "type": "integer"
+                },
+                "tz": {
+                    "type": "string"
+                }
+            }
+        },
+        "device.RequestLog": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "deviceId",
+                "modelNumber"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "integer"
+                },
+                "deviceId": {
+                    "type": "string"
+                },
+                "isTest": {
+                    "type": "boolean"
+                },
+                "log": {
+                    "$ref": "#/definitions/device.MioLog"
+                },
+                "modelNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "device.RequestStatus": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "deviceId",
+                "modelNumber"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "integer"
+                },
+                "deviceId": {
+                    "type": "string"
+                },
+                "isTest": {
+                    "type": "boolean"
+                },
+                "modelNumber": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/device.MioStatus"
+                }
+            }
+        },
+        "device.RequestTelemetry": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "deviceId",
+                "modelNumber"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/device.MioData"
+                },
+                "deviceId": {
+                    "type": "string"
+                },
+                "isTest": {
+                    "type": "boolean"
+                },
+                "modelNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "device.ShipmentItemRequest": {
+            "type": "object",
+            "required": [
+                "count",
+                "model_number"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "model_number": {
+                    "type": "string",
+                    "example": "TBM-2092-G"
+                }
+            }
+        },
+        "device.UpdateRequest": {
+            "type": "object",
+            "properties": {
+                "battery_level": {
+                    "type": "integer"
+                },
+                "firmware_version": {
+                    "type": "string"
+                },
+                "imei": {
+                    "type": "string"
+                },
+                "model_number": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "serial_number": {
+                    "type": "string"
+                },
+                "signal_strength": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CareplanSheetResponse": {
+            "type": "object",
+            "properties": {
+                "a-fib_goals": {
+                    "type": "string"
+                },
+                "a-fib_improvement_plan": {
+                    "type": "string"
+                },
+                "activities_of_daily_living_improvement_goals": {
+                    "type": "string"
+                },
+                "adl_nutrition_plan": {
+                    "type": "string"
+                },
+                "ambulation_walking_and_using_stairs": {
+                    "type": "string"
+                },
+                "anemia_goals": {
+                    "type": "string"
+                },
+                "anemia_improvement_plan": {
+                    "type": "string"
+                },
+                "bathing_showering": {
+                    "type": "string"
+                },
+                "bone_health_goals": {
+                    "type": "string"
+                },
+                "bone_health_plan": {
+                    "type": "string"
+                },
+                "bowel_regularity_plan": {
+                    "type": "string"
+                },
+                "care_coordinator": {
+                    "type": "string"
+                },
+                "chf_goals": {
+                    "type": "string"
+                },
+                "chf_improvement_plan": {
+                    "type": "string"
+                },
+                "cholesterol_goals": {
+                    "type": "string"
+                },
+                "cholesterol_improvement_plan": {
+                    "type": "string"
+                },
+                "chronic_conditions": {
+                    "type": "string"
+                },
+                "chronic_headache_migraine_goals": {
+                    "type": "string"
+                },
+                "chronic_headache_migraine_improvement_plan": {
+                    "type": "string"
+                },
+                "cleaning": {
+                    "type": "string"
+                },
+                "coronary_artery_disease_goals": {
+                    "type": "string"
+                },
+                "coronary_artery_disease_plan": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "diabetes_goals": {
+                    "type": "string"
+                },
+                "diabetes_improvement_plan": {
+                    "type": "string"
+                },
+                "diet_goals": {
+                    "type": "string"
+                },
+                "diet_improvement_plan": {
+                    "type": "string"
+                },
+                "dob": {
+                    "type": "string"
+                },
+                "dressing": {
+                    "type": "string"
+                },
+                "energy_improvement_plan": {
+                    "type": "string"
+                },
+                "exercise": {
+                    "type": "string"
+                },
+                "exercise_activity_plan": {
+                    "type": "string"
+                },
+                "exercise_goals": {
+                    "type": "string"
+                },
+                "eye_health_goals": {
+                    "type": "string"
+                },
+                "eye_health_improvement_plan": {
+                    "type": "string"
+                },
+                "fall_prevention_goals": {
+                    "type": "string"
+                },
+                "fall_prevention_plan": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "food_preparation_ideas": {
+                    "type": "string"
+                },
+                "gerd_goals": {
+                    "type": "string"
+                },
+                "gerd_plan": {
+                    "type": "string"
+                },
+                "goals_areas": {
+                    "type": "string"
+                },
+                "hobbies": {
+                    "type": "string"
+                },
+                "hydration_goals": {
+                    "type": "string"
+                },
+                "hydration_improvement_plan": {
+                    "type": "string"
+                },
+                "hypertension_goals": {
+                    "type": "string"
+                },
+                "hypertension_plan": {
+                    "type": "string"
+                },
+                "improving_hair_and_skin_plan": {
+                    "type": "string"
+                },
+                "input153_goals": {
+                    "type": "string"
+                },
+                "input153_plan": {
+                    "type": "string"
+                },
+                "kidney_health_goals": {
+                    "type": "string"
+                },
+                "kidney_health_plan": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "low_blood_pressure_avoidance_plan": {
+                    "type": "string"
+                },
+                "low_blood_pressure_improvement_goals": {
+                    "type": "string"
+                },
+                "managing_finances": {
+                    "type": "string"
+                },
+                "medication_management": {
+                    "type": "string"
+                },
+                "medication_reconciliation": {
+                    "type": "string"
+                },
+                "memory_care_goals": {
+                    "type": "string"
+                },
+                "memory_care_plan": {
+                    "type": "string"
+                },
+                "mental_health_goals": {
+                    "type": "string"
+                },
+                "mental_health_improvement_plan": {
+                    "type": "string"
+                },
+                "no_label": {
+                    "type": "string"
+                },
+                "no_label_2": {
+                    "type": "string"
+                },
+                "obstacles": {
+                    "type": "string"
+                },
+                "office_email": {
+                    "type": "string"
+                },
+                "other_diagnosis_not_listed": {
+                    "type": "string"
+                },
+                "oxygen_safety_goals": {
+                    "type": "string"
+                },
+                "oxygen_safety_plan": {
+                    "type": "string"
+                },
+                "pain_goals": {
+                    "type": "string"
+                },
+                "pain_reduction_plan": {
+                    "type": "string"
+                },
+                "pet_care": {
+                    "type": "string"
+                },
+                "post_heart_attack_goals": {
+                    "type": "string"
+                },
+                "post_heart_attack_plan": {
+                    "type": "string"
+                },
+                "post_stroke_tia_goals": {
+                    "type": "string"
+                },
+                "post_stroke_tia_plan": {
+                    "type": "string"
+                },
+                "preventatives_due": {
+                    "type": "string"
+                },
+                "respiratory_challenges_and_breathing_improvement_plan": {
+                    "type": "string"
+                },
+                "respiratory_goals": {
+                    "type": "string"
+                },
+                "running_errands_transportation": {
+                    "type": "string"
+                },
+                "self_care_goals": {
+                    "type": "string"
+                },
+                "self_care_plan": {
+                    "type": "string"
+                },
+                "sleep_goals": {
+                    "type": "string"
+                },
+                "sleep_improvement_plan": {
+                    "type": "string"
+                },
+                "social_activities": {
+                    "type": "string"
+                },
+                "sodium_reduction_plan": {
+                    "type": "string"
+                },
+                "standing_getting_out_of_chair_balance": {
+                    "type": "string"
+                },
+                "stress_goals": {
+                    "type": "string"
+                },
+                "stress_reduction_plan": {
+                    "type": "string"
+                },
+                "submission_date": {
+                    "type": "string"
+                },
+                "submission_id": {
+                    "type": "string"
+                },
+                "thyroid_health_goals": {
+                    "type": "string"
+                },
+                "thyroid_health_improvement_plan": {
+                    "type": "string"
+                },
+                "tobacco_use_goals": {
+                    "type": "string"
+                },
+                "tobacco_use_plan": {
+                    "type": "string"
+                },
+                "toileting": {
+                    "type": "string"
+                },
+                "using_phone_technology": {
+                    "type": "string"
+                },
+                "weight_loss_goals": {
+                    "type": "string"
+                },
+                "weight_loss_plan": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.VerifyUserFieldResponse": {
+            "type": "object",
+            "properties": {
+                "is_available": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "event.Event": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "data": {},
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "organization_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "patient_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/event.Type"
+                        }
+                    ],
+                    "example": "telemetry.created"
+                }
+            }
+        },
+        "event.Type": {
+            "type": "string",
+            "enum": [
+                "telemetry.created",
+                "alert.raised",
+                "alert.updated",
+                "alert.resolved",
+                "device.status"
+            ],
+            "x-enum-varnames": [
+                "TelemetryCreated",
+                "AlertRaised",
+                "AlertUpdated",
+                "AlertResolved",
+                "DeviceStatus"
+            ]
+        },
+        "interaction.CreateRequest": {
+            "type": "object",
+            "required": [
+                "cost_category",
+                "duration",
+                "notes",
+                "session_date",
+                "user_id"
+            ],
+            "properties": {
+                "cost_category": {
+                    "type": "string",
+                    "enum": [
+                        "RPM",
+                        "CCM",
+                        "PCM",
+                        "BHI",
+                        "RTM"
+                    ]
+                },
+                "doctor_id": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "session_date": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "interaction.UpdateRequest": {
+            "type": "object",
+            "properties": {
+                "cost_category": {
+                    "type": "string"
+                },
+                "doctor_id": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "session_date": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "created_by_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2021-06-01T00:00:00Z"
+                },
+                "last_used_ip": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "EHR integration"
+                },
+                "organization_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prefix": {
+                    "description": "Prefix is the start of the token, enough to tell keys apart",
+                    "type": "string",
+                    "example": "mk_3fZq9Lw2"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2021-07-01T00:00:00Z"
+                },
+                "revoked_by_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "scopes": {
+                    "description": "Scopes is a comma separated list of APIKeyScopes",
+                    "type": "string",
+                    "example": "user:read,device:read"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.AdherenceReminder": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotificationChannel"
+                        }
+                    ],
+                    "example": "SMS"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "days_needed": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "error": {
+                    "type": "string",
+                    "example": "twilio responded with status code 400"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "organization_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "patient_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reading_days": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "reason": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AdherenceStatus"
+                        }
+                    ],
+                    "example": "AtRisk"
+                },
+                "recipient": {
+                    "type": "string",
+                    "example": "+1 555 555 5555"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotificationStatus"
+                        }
+                    ],
+                    "example": "Sent"
+                }
+            }
+        },
+        "models.AdherenceStatus": {
+            "type": "string",
+            "enum": [
+                "Met",
+                "OnTrack",
+                "AtRisk",
+                "Missed"
+            ],
+            "x-enum-varnames": [
+                "AdherenceMet",
+                "AdherenceOnTrack",
+                "AdherenceAtRisk",
+                "AdherenceMissed"
+            ]
+        },
+        "models.AlertNotification": {
+            "type": "object",
+            "properties": {
+                "alert_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "channel": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotificationChannel"
+                        }
+                    ],
+                    "example": "Email"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "webhook responded with status code 500"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recipient": {
+                    "type": "string",
+                    "example": "john@doe.com"
+                },
+                "recipient_user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rule_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotificationStatus"
+                        }
+                    ],
+                    "example": "Sent"
+                },
+                "tier": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.AlertNotificationRule": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotificationChannel"
+                        }
+                    ],
+                    "example": "Email"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "escalate_after_minutes": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "min_alert_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AlertType"
+                        }
+                    ],
+                    "example": "Critical"
+                },
+                "organization": {
+                    "$ref": "#/definitions/models.Organization"
+                },
+                "organization_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recipient_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotificationRecipient"
+                        }
+                    ],
+                    "example": "CareManager"
+                },
+                "recipient_user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "recipient_user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "target": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/alerts"
+                },
+                "tier": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.AlertOutcome": {
+            "type": "string",
+            "enum": [
+                "CalledPatient",
+                "MedicationChange",
+                "SentToER",
+                "NoActionNeeded",
+                "Other"
+            ],
+            "x-enum-varnames": [
+                "OutcomeCalledPatient",
+                "OutcomeMedicationChange",
+                "OutcomeSentToER",
+                "OutcomeNoActionNeeded",
+                "OutcomeOther"
+            ]
+        },
+        "models.AlertThreshold": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "critical_high": {
+                    "type": "integer",
+                    "example": 140
+                },
+                "critical_low": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "device_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceType"
+                        }
+                    ],
+                    "example": "BloodPressure"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "measurement_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeasurementType"
+                        }
+                    ],
+                    "example": "Systolic"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "This is a note"
+                },
+                "patient": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "patient_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "source": {
+                    "description": "Source tells where an effective threshold came from, it is not stored",
+                    "type": "string",
+                    "example": "Patient"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "warning_high": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "warning_low": {
+                    "type": "integer",
+                    "example": 80
+                }
+            }
+        },
+        "models.AlertTrendRule": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "critical_change": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "device_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceType"
+                        }
+                    ],
+                    "example": "WeightScale"
+                },
+                "direction": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TrendDirection"
+                        }
+                    ],
+                    "example": "Increase"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "measurement_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeasurementType"
+                        }
+                    ],
+                    "example": "Weight"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "CHF weight gain"
+                },
+                "patient": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "patient_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rule_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TrendRuleType"
+                        }
+                    ],
+                    "example": "Delta"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "warning_change": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "window_hours": {
+                    "type": "integer",
+                    "example": 24
+                }
+            }
+        },
+        "models.AlertType": {
+            "type": "string",
+            "enum": [
+                "Critical",
+                "Warning",
+                "Ok"
+            ],
+            "x-enum-varnames": [
+                "AlertCritical",
+                "AlertWarning",
+                "AlertOk"
+            ]
+        },
+        "models.BloodPressureAverage": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "diastolic": {
+                    "type": "number",
+                    "example": 84.1
+                },
+                "systolic": {
+                    "type": "number",
+                    "example": 131.2
+                }
+            }
+        },
+        "models.BloodPressureTimeOfDay": {
+            "type": "object",
+            "properties": {
+                "evening": {
+                    "$ref": "#/definitions/models.BloodPressureAverage"
+                },
+                "morning": {
+                    "$ref": "#/definitions/models.BloodPressureAverage"
+                }
+            }
+        },
+        "models.CarePlan": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "doctor": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "doctor_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://cdn.med-kick.com/xxx.pdf"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.ClinicalSummary": {
+            "type": "object",
+            "properties": {
+                "alert_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "failed to upload the report"
+                },
+                "generated_at": {
+                    "type": "string",
+                    "example": "2021-02-01T06:00:00Z"
+                },
+                "generated_by_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "interaction_count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "management_minutes": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "organization_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "patient": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "patient_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2021-01"
+                },
+                "reading_days": {
+                    "description": "The headline numbers of the report, so lists don't need the PDF",
+                    "type": "integer",
+                    "example": 18
+                },
+                "signed_at": {
+                    "type": "string",
+                    "example": "2021-02-03T14:00:00Z"
+                },
+                "signed_by": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "signed_by_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ClinicalSummaryStatus"
+                        }
+                    ],
+                    "example": "Generated"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.ClinicalSummaryStatus": {
+            "type": "string",
+            "enum": [
+                "Pending",
+                "Generated",
+                "Failed"
+            ],
+            "x-enum-varnames": [
+                "ClinicalSummaryPending",
+                "ClinicalSummaryGenerated",
+                "ClinicalSummaryFailed"
+            ]
+        },
+        "models.DailySummary": {
+            "type": "object",
+            "properties": {
+                "countable": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "day": {
+                    "type": "string",
+                    "example": "2021-01-01"
+                },
+                "device_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceType"
+                        }
+                    ],
+                    "example": "BloodPressure"
+                },
+                "diastolic": {
+                    "$ref": "#/definitions/models.SummaryRange"
+                },
+                "glucose": {
+                    "$ref": "#/definitions/models.SummaryRange"
+                },
+                "pulse": {
+                    "$ref": "#/definitions/models.SummaryRange"
+                },
+                "readings": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "systolic": {
+                    "$ref": "#/definitions/models.SummaryRange"
+                },
+                "weight": {
+                    "$ref": "#/definitions/models.SummaryRange"
+                }
+            }
+        },
+        "models.Device": {
+            "type": "object",
+            "properties": {
+                "battery_level": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "deviceTelemetryData": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DeviceTelemetryData"
+                    }
+                },
+                "firmware_version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "imei": {
+                    "type": "string",
+                    "example": "123456789"
+                },
+                "last_reading_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "last_status_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "model_number": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Sphygmomanometer/Weight Scale/Blood Glucose Meter"
+                },
+                "network_format": {
+                    "type": "string",
+                    "example": "eMTC"
+                },
+                "network_ops": {
+                    "type": "string",
+                    "example": "T-Mobile"
+                },
+                "serial_number": {
+                    "type": "string",
+                    "example": "123456789"
+                },
+                "signal_strength": {
+                    "type": "string",
+                    "example": "100"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceLifecycle"
+                        }
+                    ],
+                    "example": "Active"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.DeviceAlert": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "data": {
+                    "type": "object"
+                },
+                "device": {
+                    "$ref": "#/definitions/models.Device"
+                },
+                "device_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "organization_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "patient_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "resolved_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceAlertType"
+                        }
+                    ],
+                    "example": "NoReadings"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.DeviceAlertType": {
+            "type": "string",
+            "enum": [
+                "NoReadings",
+                "LowBattery",
+                "WeakSignal",
+                "NoHeartbeat"
+            ],
+            "x-enum-varnames": [
+                "DeviceAlertNoReadings",
+                "DeviceAlertLowBattery",
+                "DeviceAlertWeakSignal",
+                "DeviceAlertNoHeartbeat"
+            ]
+        },
+        "models.DeviceAssignment": {
+            "type": "object",
+            "properties": {
+                "assigned_by_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "device_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ended_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.DeviceDTO": {
+            "type": "object",
+            "properties": {
+                "battery_level": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "firmware_version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "imei": {
+                    "type": "string",
+                    "example": "123456789"
+                },
+                "model_number": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Sphygmomanometer/Weight Scale/Blood Glucose Meter"
+                },
+                "serial_number": {
+                    "type": "string",
+                    "example": "123456789"
+                },
+                "signal_strength": {
+                    "type": "string",
+                    "example": "100"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceLifecycle"
+                        }
+                    ],
+                    "example": "InStock"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.DeviceHealthLimits": {
+            "type": "object",
+            "properties": {
+                "heartbeat_hours": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "low_battery_percent": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "no_reading_days": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "weak_signal": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "models.DeviceLifecycle": {
+            "type": "string",
+            "enum": [
+                "InStock",
+                "Shipped",
+                "Delivered",
+                "Active",
+                "Returned",
+                "Lost",
+                "Retired"
+            ],
+            "x-enum-varnames": [
+                "DeviceInStock",
+                "DeviceShipped",
+                "DeviceDelivered",
+                "DeviceActive",
+                "DeviceReturned",
+                "DeviceLost",
+                "DeviceRetired"
+            ]
+        },
+        "models.DeviceLogData": {
+            "type": "object",
+            "properties": {
+                "app_version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                },
+                "battery": {
+                    "description": "Device",
+                    "type": "string",
+                    "example": "100"
+                },
+                "bpm_algo": {
+                    "type": "string",
+                    "example": "1.0.0"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "device": {
+                    "$ref": "#/definitions/models.Device"
+                },
+                "device_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "hardware_version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "mcu_version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                },
+                "modem_version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.DeviceResponse": {
+            "type": "object",
+            "properties": {
+                "battery_level": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deviceTelemetryData": {
+                    "$ref": "#/definitions/models.DeviceTelemetryDataResponse"
+                },
+                "firmware_version": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "imei": {
+                    "type": "string"
+                },
+                "model_number": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "serial_number": {
+                    "type": "string"
+                },
+                "signal_strength": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DeviceStatusData": {
+            "type": "object",
+            "properties": {
+                "attach_time": {
+                    "type": "string",
+                    "example": "100"
+                },
+                "battery": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "device": {
+                    "$ref": "#/definitions/models.Device"
+                },
+                "device_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "measure_count": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "network_format": {
+                    "type": "string",
+                    "example": "GSM;eMTC;NB-IoT"
+                },
+                "network_ops": {
+                    "type": "string",
+                    "example": "T-Mobile;Verizon"
+                },
+                "signal": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "temperature": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "timezone": {
+                    "description": "Device",
+                    "type": "string",
+                    "example": "UTC+6"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.DeviceSyncEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "device_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "imei": {
+                    "type": "string",
+                    "example": "123456789"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "firmware_version 1.0.0 -\u003e 1.0.1"
+                },
+                "mio_device_id": {
+                    "type": "string",
+                    "example": "5f7b1b1b1b1b1b1b1b1b1b1b"
+                },
+                "model_number": {
+                    "type": "string",
+                    "example": "TBM-2092-G"
+                },
+                "result": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceSyncResult"
+                        }
+                    ],
+                    "example": "Added"
+                },
+                "run_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.DeviceSyncResult": {
+            "type": "string",
+            "enum": [
+                "Added",
+                "Updated",
+                "MissingUpstream",
+                "UnknownModel",
+                "Error"
+            ],
+            "x-enum-varnames": [
+                "DeviceSyncAdded",
+                "DeviceSyncUpdated",
+                "DeviceSyncMissingUpstream",
+                "DeviceSyncUnknownModel",
+                "DeviceSyncError"
+            ]
+        },
+        "models.DeviceSyncRun": {
+            "type": "object",
+            "properties": {
+                "added_count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DeviceSyncEntry"
+                    }
+                },
+                "error": {
+                    "type": "string",
+                    "example": "mio: GET /devices responded with status code 503"
+                },
+                "error_count": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "missing_upstream_count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceSyncStatus"
+                        }
+                    ],
+                    "example": "Completed"
+                },
+                "unchanged_count": {
+                    "type": "integer",
+                    "example": 113
+                },
+                "unknown_model_count": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "updated_count": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "upstream_count": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "models.DeviceSyncStatus": {
+            "type": "string",
+            "enum": [
+                "Running",
+                "Completed",
+                "CompletedWithErrors",
+                "Failed"
+            ],
+            "x-enum-varnames": [
+                "DeviceSyncRunning",
+                "DeviceSyncCompleted",
+                "DeviceSyncCompletedWithErrors",
+                "DeviceSyncFailed"
+            ]
+        },
+        "models.DeviceTelemetryData": {
+            "type": "object",
+            "properties": {
+                "blood_glucose": {
+                    "description": "Blood Glucose Meter",
+                    "type": "integer",
+                    "example": 80
                 },
-                "iccid": {
-                    "type": "string"
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "ihb": {
-                    "type": "boolean"
+                "device": {
+                    "$ref": "#/definitions/models.Device"
                 },
-                "imei": {
-                    "type": "string"
+                "device_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "lts": {
-                    "type": "integer"
+                "diastolic_bp": {
+                    "type": "integer",
+                    "example": 80
                 },
-                "meal": {
-                    "type": "integer"
+                "entered_by_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "pul": {
-                    "type": "integer"
+                "firmware_version": {
+                    "description": "FirmwareVersion is the firmware the device ran when it uploaded the reading",
+                    "type": "string",
+                    "example": "1.0.0"
                 },
-                "sample": {
-                    "type": "integer"
+                "hand_shaking": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "sample_type": {
-                    "type": "integer"
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "sig": {
-                    "type": "integer"
+                "import_batch_id": {
+                    "description": "ImportBatchID is set on historical readings imported from another vendor's export",
+                    "type": "integer",
+                    "example": 1
                 },
-                "sig_lvl": {
-                    "type": "integer"
+                "invalid_reason": {
+                    "type": "string",
+                    "example": "Cuff was on over clothing"
                 },
-                "sn": {
-                    "type": "string"
+                "invalidated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "sys": {
-                    "type": "integer"
+                "invalidated_by_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "tri": {
-                    "type": "boolean"
+                "irregular_heartbeat": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "ts": {
-                    "type": "integer"
+                "meal": {
+                    "type": "string",
+                    "example": "1. Before Meal; 2. After Meal"
                 },
-                "tz": {
-                    "type": "string"
+                "measured_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "uid": {
-                    "type": "string"
+                "note": {
+                    "type": "string",
+                    "example": "Taken at the clinic after a 5 minute rest"
                 },
-                "unit": {
-                    "type": "integer"
+                "pulse": {
+                    "type": "integer",
+                    "example": 80
                 },
-                "upload_time": {
-                    "type": "integer"
+                "quality": {
+                    "description": "Quality is computed at ingest, only Valid and Questionable readings are counted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ReadingQuality"
+                        }
+                    ],
+                    "example": "Valid"
                 },
-                "uptime": {
-                    "type": "integer"
+                "quality_flags": {
+                    "type": "string",
+                    "example": "HandShaking"
                 },
-                "user": {
-                    "type": "integer"
+                "sample_type": {
+                    "type": "string",
+                    "example": "1. Blood or Resistance; 2. Quality Control Liquid; 3. Sample is invalid"
                 },
-                "wet": {
-                    "type": "integer"
+                "source": {
+                    "description": "Source tells device uploads from readings entered by hand, EnteredByID is set on the latter",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ReadingSource"
+                        }
+                    ],
+                    "example": "Device"
                 },
-                "wt": {
-                    "type": "integer"
-                }
-            }
-        },
-        "device.MioStatus": {
-            "type": "object",
-            "required": [
-                "data_type",
-                "imei"
-            ],
-            "properties": {
-                "at_t": {
-                    "type": "integer"
+                "systolic_bp": {
+                    "description": "Sphygmomanometer",
+                    "type": "integer",
+                    "example": 120
                 },
-                "bat": {
-                    "type": "integer"
+                "test_paper": {
+                    "type": "string",
+                    "example": "1. GOD; 2. GDH"
                 },
-                "data_type": {
-                    "type": "string"
+                "triple_measurement": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "imei": {
-                    "type": "string"
+                "unit": {
+                    "type": "string",
+                    "example": "mg/dL"
                 },
-                "me_num": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "net": {
-                    "type": "string"
+                "user": {
+                    "$ref": "#/definitions/models.User"
                 },
-                "ops": {
-                    "type": "string"
+                "user_id": {
+                    "description": "patient_time serves reads of a patient's readings over a date range",
+                    "type": "integer",
+                    "example": 1
                 },
-                "sig": {
-                    "type": "integer"
+                "weight": {
+                    "description": "Weight Scale",
+                    "type": "integer",
+                    "example": 80
                 },
-                "tp": {
-                    "type": "integer"
+                "weight_lock_count": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "tz": {
-                    "type": "string"
+                "weight_stable_time": {
+                    "type": "integer",
+                    "example": 5
                 }
             }
         },
-        "device.RequestStatus": {
+        "models.DeviceTelemetryDataResponse": {
             "type": "object",
-            "required": [
-                "createdAt",
-                "deviceId",
-                "modelNumber"
-            ],
             "properties": {
-                "createdAt": {
+                "blood_glucose": {
+                    "description": "Blood Glucose Meter",
                     "type": "integer"
                 },
-                "deviceId": {
-                    "type": "string"
-                },
-                "isTest": {
-                    "type": "boolean"
-                },
-                "modelNumber": {
+                "created_at": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/device.MioStatus"
-                }
-            }
-        },
-        "device.RequestTelemetry": {
-            "type": "object",
-            "required": [
-                "createdAt",
-                "deviceId",
-                "modelNumber"
-            ],
-            "properties": {
-                "createdAt": {
+                "device_id": {
                     "type": "integer"
                 },
-                "data": {
-                    "$ref": "#/definitions/device.MioData"
-                },
-                "deviceId": {
-                    "type": "string"
+                "diastolic_bp": {
+                    "type": "integer"
                 },
-                "isTest": {
+                "hand_shaking": {
                     "type": "boolean"
                 },
-                "modelNumber": {
-                    "type": "string"
-                }
-            }
-        },
-        "device.UpdateRequest": {
-            "type": "object",
-            "properties": {
-                "battery_level": {
+                "id": {
                     "type": "integer"
                 },
-                "firmware_version": {
-                    "type": "string"
-                },
-                "imei": {
-                    "type": "string"
-                },
-                "model_number": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
+                "irregular_heartbeat": {
+                    "type": "boolean"
                 },
-                "serial_number": {
+                "meal": {
                     "type": "string"
                 },
-                "signal_strength": {
+                "measured_at": {
                     "type": "string"
                 },
-                "user_id": {
+                "pulse": {
                     "type": "integer"
-                }
-            }
-        },
-        "dto.CareplanSheetResponse": {
-            "type": "object",
-            "properties": {
-                "a-fib_goals": {
-                    "type": "string"
                 },
-                "a-fib_improvement_plan": {
-                    "type": "string"
+                "quality": {
+                    "$ref": "#/definitions/models.ReadingQuality"
                 },
-                "activities_of_daily_living_improvement_goals": {
+                "quality_flags": {
                     "type": "string"
                 },
-                "adl_nutrition_plan": {
+                "sample_type": {
                     "type": "string"
                 },
-                "ambulation_walking_and_using_stairs": {
-                    "type": "string"
+                "source": {
+                    "$ref": "#/definitions/models.ReadingSource"
                 },
-                "anemia_goals": {
-                    "type": "string"
+                "systolic_bp": {
+                    "description": "Sphygmomanometer",
+                    "type": "integer"
                 },
-                "anemia_improvement_plan": {
+                "test_paper": {
                     "type": "string"
                 },
-                "bathing_showering": {
+                "triple_measurement": {
+                    "type": "boolean"
+                },
+                "unit": {
                     "type": "string"
                 },
-                "bone_health_goals": {
+                "updated_at": {
                     "type": "string"
                 },
-                "bone_health_plan": {
-                    "type": "string"
+                "weight": {
+                    "description": "Weight Scale",
+                    "type": "integer"
                 },
-                "bowel_regularity_plan": {
-                    "type": "string"
+                "weight_lock_count": {
+                    "type": "integer"
                 },
-                "care_coordinator": {
-                    "type": "string"
+                "weight_stable_time": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DeviceType": {
+            "type": "string",
+            "enum": [
+                "BloodPressure",
+                "BloodGlucose",
+                "WeightScale"
+            ],
+            "x-enum-varnames": [
+                "BloodPressure",
+                "BloodGlucose",
+                "WeightScale"
+            ]
+        },
+        "models.Diagnosis": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "I10"
                 },
-                "chf_goals": {
-                    "type": "string"
+                "diagnosis_name": {
+                    "type": "string",
+                    "example": "Essential (primary) hypertension"
                 },
-                "chf_improvement_plan": {
-                    "type": "string"
+                "short_mnemonic": {
+                    "type": "string",
+                    "example": "Essential (primary) hypertension"
+                }
+            }
+        },
+        "models.DignosesResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "cholesterol_goals": {
-                    "type": "string"
+                "diagnosis": {
+                    "$ref": "#/definitions/models.Diagnosis"
                 },
-                "cholesterol_improvement_plan": {
-                    "type": "string"
+                "diagnosis_id": {
+                    "type": "integer"
                 },
-                "chronic_conditions": {
-                    "type": "string"
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FirmwareVersionReport": {
+            "type": "object",
+            "properties": {
+                "active_devices": {
+                    "type": "integer",
+                    "example": 98
                 },
-                "chronic_headache_migraine_goals": {
-                    "type": "string"
+                "control": {
+                    "type": "integer",
+                    "example": 5
                 },
-                "chronic_headache_migraine_improvement_plan": {
-                    "type": "string"
+                "device_name": {
+                    "type": "string",
+                    "example": "Sphygmomanometer"
                 },
-                "cleaning": {
-                    "type": "string"
+                "devices": {
+                    "description": "Devices excludes lost and retired devices, ActiveDevices are with a patient",
+                    "type": "integer",
+                    "example": 120
                 },
-                "coronary_artery_disease_goals": {
-                    "type": "string"
+                "firmware_version": {
+                    "type": "string",
+                    "example": "1.0.0"
                 },
-                "coronary_artery_disease_plan": {
-                    "type": "string"
+                "invalid": {
+                    "type": "integer",
+                    "example": 15
                 },
-                "date": {
-                    "type": "string"
+                "invalid_rate": {
+                    "type": "number",
+                    "example": 0.6
                 },
-                "diabetes_goals": {
-                    "type": "string"
+                "model_number": {
+                    "type": "string",
+                    "example": "TBM-2092-G"
                 },
-                "diabetes_improvement_plan": {
-                    "type": "string"
+                "questionable": {
+                    "type": "integer",
+                    "example": 80
                 },
-                "diet_goals": {
-                    "type": "string"
+                "questionable_rate": {
+                    "description": "QuestionableRate and InvalidRate are percentages of the readings",
+                    "type": "number",
+                    "example": 3.3
                 },
-                "diet_improvement_plan": {
-                    "type": "string"
+                "readings": {
+                    "type": "integer",
+                    "example": 2400
                 },
-                "dob": {
-                    "type": "string"
+                "valid": {
+                    "type": "integer",
+                    "example": 2300
+                }
+            }
+        },
+        "models.ImportFormat": {
+            "type": "string",
+            "enum": [
+                "CSV",
+                "JSON"
+            ],
+            "x-enum-varnames": [
+                "ImportCSV",
+                "ImportJSON"
+            ]
+        },
+        "models.ImportStatus": {
+            "type": "string",
+            "enum": [
+                "Running",
+                "Completed",
+                "CompletedWithErrors",
+                "Failed",
+                "Reverted"
+            ],
+            "x-enum-varnames": [
+                "ImportRunning",
+                "ImportCompleted",
+                "ImportCompletedWithErrors",
+                "ImportFailed",
+                "ImportReverted"
+            ]
+        },
+        "models.Interaction": {
+            "type": "object",
+            "properties": {
+                "cost_category": {
+                    "type": "string",
+                    "example": ""
                 },
-                "dressing": {
-                    "type": "string"
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "energy_improvement_plan": {
-                    "type": "string"
+                "doctor": {
+                    "$ref": "#/definitions/models.User"
                 },
-                "exercise": {
-                    "type": "string"
+                "doctor_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "exercise_activity_plan": {
-                    "type": "string"
+                "duration": {
+                    "type": "integer",
+                    "example": 30
                 },
-                "exercise_goals": {
-                    "type": "string"
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "eye_health_goals": {
-                    "type": "string"
+                "notes": {
+                    "type": "string",
+                    "example": "Patient is doing well"
                 },
-                "eye_health_improvement_plan": {
-                    "type": "string"
+                "session_date": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "fall_prevention_goals": {
-                    "type": "string"
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "fall_prevention_plan": {
-                    "type": "string"
+                "user": {
+                    "$ref": "#/definitions/models.User"
                 },
-                "first_name": {
-                    "type": "string"
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.InteractionSettingType": {
+            "type": "string",
+            "enum": [
+                "ColorThreshold",
+                "AutoResolveNormalReadings",
+                "AlertAckSLAMinutes",
+                "AlertResolveSLAMinutes",
+                "DeviceNoReadingDays",
+                "DeviceLowBatteryPercent",
+                "DeviceWeakSignal",
+                "DeviceHeartbeatHours",
+                "AdherenceStreakBreakDays",
+                "AdherenceReminders"
+            ],
+            "x-enum-varnames": [
+                "ColorThreshold",
+                "AutoResolveNormalReadings",
+                "AlertAckSLAMinutes",
+                "AlertResolveSLAMinutes",
+                "DeviceNoReadingDays",
+                "DeviceLowBatteryPercent",
+                "DeviceWeakSignal",
+                "DeviceHeartbeatHours",
+                "AdherenceStreakBreakDays",
+                "AdherenceReminders"
+            ]
+        },
+        "models.MFAPolicy": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "food_preparation_ideas": {
-                    "type": "string"
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "gerd_goals": {
-                    "type": "string"
+                "organization_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "gerd_plan": {
-                    "type": "string"
+                "roles": {
+                    "description": "Roles is a comma separated list of user roles",
+                    "type": "string",
+                    "example": "org_admin,care_manager"
                 },
-                "goals_areas": {
-                    "type": "string"
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "hobbies": {
-                    "type": "string"
+                "updated_by_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.MeasurementStats": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 14
                 },
-                "hydration_goals": {
-                    "type": "string"
+                "max": {
+                    "type": "integer",
+                    "example": 148
                 },
-                "hydration_improvement_plan": {
-                    "type": "string"
+                "mean": {
+                    "type": "number",
+                    "example": 127.5
                 },
-                "hypertension_goals": {
-                    "type": "string"
+                "median": {
+                    "type": "number",
+                    "example": 125
                 },
-                "hypertension_plan": {
-                    "type": "string"
+                "min": {
+                    "type": "integer",
+                    "example": 112
                 },
-                "improving_hair_and_skin_plan": {
-                    "type": "string"
+                "reading_days": {
+                    "type": "integer",
+                    "example": 7
                 },
-                "input153_goals": {
-                    "type": "string"
+                "time_in_range": {
+                    "$ref": "#/definitions/models.TimeInRange"
+                }
+            }
+        },
+        "models.MeasurementType": {
+            "type": "string",
+            "enum": [
+                "Systolic",
+                "Diastolic",
+                "Pulse",
+                "Weight",
+                "FastingGlucose",
+                "PostMealGlucose",
+                "IrregularHeartBeat"
+            ],
+            "x-enum-varnames": [
+                "Systolic",
+                "Diastolic",
+                "Pulse",
+                "Weight",
+                "FastingGlucose",
+                "PostMealGlucose",
+                "IrregularHeartBeat"
+            ]
+        },
+        "models.NotificationChannel": {
+            "type": "string",
+            "enum": [
+                "Email",
+                "SMS",
+                "Webhook"
+            ],
+            "x-enum-varnames": [
+                "ChannelEmail",
+                "ChannelSMS",
+                "ChannelWebhook"
+            ]
+        },
+        "models.NotificationRecipient": {
+            "type": "string",
+            "enum": [
+                "CareManager",
+                "Provider",
+                "OnCall"
+            ],
+            "x-enum-varnames": [
+                "RecipientCareManager",
+                "RecipientProvider",
+                "RecipientOnCall"
+            ]
+        },
+        "models.NotificationStatus": {
+            "type": "string",
+            "enum": [
+                "Sent",
+                "Failed"
+            ],
+            "x-enum-varnames": [
+                "NotificationSent",
+                "NotificationFailed"
+            ]
+        },
+        "models.Organization": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "123 Main St"
                 },
-                "input153_plan": {
-                    "type": "string"
+                "address2": {
+                    "type": "string",
+                    "example": "Apt 1"
                 },
-                "kidney_health_goals": {
-                    "type": "string"
+                "city": {
+                    "type": "string",
+                    "example": "Baltimore"
                 },
-                "kidney_health_plan": {
-                    "type": "string"
+                "country": {
+                    "type": "string",
+                    "example": "USA"
                 },
-                "last_name": {
-                    "type": "string"
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "low_blood_pressure_avoidance_plan": {
-                    "type": "string"
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "low_blood_pressure_improvement_goals": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "John Hopkins"
                 },
-                "managing_finances": {
-                    "type": "string"
+                "phone": {
+                    "type": "string",
+                    "example": "08123456789"
                 },
-                "medication_management": {
-                    "type": "string"
+                "state": {
+                    "type": "string",
+                    "example": "MD"
                 },
-                "medication_reconciliation": {
-                    "type": "string"
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "memory_care_goals": {
-                    "type": "string"
+                "zip": {
+                    "type": "string",
+                    "example": "12345"
+                }
+            }
+        },
+        "models.PatientDiagnosis": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "memory_care_plan": {
-                    "type": "string"
+                "diagnosis": {
+                    "$ref": "#/definitions/models.Diagnosis"
                 },
-                "mental_health_goals": {
-                    "type": "string"
+                "diagnosis_id": {
+                    "type": "integer"
                 },
-                "mental_health_improvement_plan": {
-                    "type": "string"
+                "patient": {
+                    "$ref": "#/definitions/models.User"
                 },
-                "no_label": {
-                    "type": "string"
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PatientMatch": {
+            "type": "string",
+            "enum": [
+                "MRN",
+                "NameDOB",
+                "Email"
+            ],
+            "x-enum-varnames": [
+                "MatchMRN",
+                "MatchNameDOB",
+                "MatchEmail"
+            ]
+        },
+        "models.ReadingAdherence": {
+            "type": "object",
+            "properties": {
+                "achievable": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "no_label_2": {
-                    "type": "string"
+                "days_needed": {
+                    "type": "integer",
+                    "example": 7
                 },
-                "obstacles": {
-                    "type": "string"
+                "days_remaining": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "office_email": {
-                    "type": "string"
+                "days_since_last_reading": {
+                    "type": "integer",
+                    "example": 4
                 },
-                "other_diagnosis_not_listed": {
-                    "type": "string"
+                "last_reading_at": {
+                    "type": "string",
+                    "example": "2021-01-20T08:00:00Z"
                 },
-                "oxygen_safety_goals": {
-                    "type": "string"
+                "month": {
+                    "type": "string",
+                    "example": "2021-01"
                 },
-                "oxygen_safety_plan": {
-                    "type": "string"
+                "organization_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "pain_goals": {
-                    "type": "string"
+                "patient_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "pain_reduction_plan": {
-                    "type": "string"
+                "patient_name": {
+                    "type": "string",
+                    "example": "John Doe"
                 },
-                "pet_care": {
-                    "type": "string"
+                "phone": {
+                    "type": "string",
+                    "example": "+1 555 555 5555"
                 },
-                "post_heart_attack_goals": {
-                    "type": "string"
+                "priority": {
+                    "description": "Priority is the position on the outreach list, 1 is the most urgent. Patients that\nneed no outreach have 0.",
+                    "type": "integer",
+                    "example": 1
                 },
-                "post_heart_attack_plan": {
-                    "type": "string"
+                "reading_days": {
+                    "type": "integer",
+                    "example": 9
                 },
-                "post_stroke_tia_goals": {
-                    "type": "string"
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AdherenceStatus"
+                        }
+                    ],
+                    "example": "AtRisk"
                 },
-                "post_stroke_tia_plan": {
-                    "type": "string"
+                "streak_broken": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "preventatives_due": {
-                    "type": "string"
+                "target_days": {
+                    "type": "integer",
+                    "example": 16
                 },
-                "respiratory_challenges_and_breathing_improvement_plan": {
-                    "type": "string"
+                "timezone": {
+                    "type": "string",
+                    "example": "America/New_York"
+                }
+            }
+        },
+        "models.ReadingQuality": {
+            "type": "string",
+            "enum": [
+                "Valid",
+                "Questionable",
+                "Control",
+                "Invalid"
+            ],
+            "x-enum-varnames": [
+                "ReadingValid",
+                "ReadingQuestionable",
+                "ReadingControl",
+                "ReadingInvalid"
+            ]
+        },
+        "models.ReadingSource": {
+            "type": "string",
+            "enum": [
+                "Device",
+                "PatientReported",
+                "ClinicMeasured",
+                "Imported"
+            ],
+            "x-enum-varnames": [
+                "SourceDevice",
+                "SourcePatientReported",
+                "SourceClinicMeasured",
+                "SourceImported"
+            ]
+        },
+        "models.Service": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "respiratory_goals": {
-                    "type": "string"
+                "description": {
+                    "type": "string",
+                    "example": "Remote Patient Monitoring"
                 },
-                "running_errands_transportation": {
-                    "type": "string"
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "self_care_goals": {
-                    "type": "string"
+                "is_enabled": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "self_care_plan": {
-                    "type": "string"
+                "service_code": {
+                    "type": "string",
+                    "example": "RPM"
                 },
-                "sleep_goals": {
-                    "type": "string"
+                "service_name": {
+                    "type": "string",
+                    "example": "Remote Patient Monitoring"
                 },
-                "sleep_improvement_plan": {
-                    "type": "string"
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.Shipment": {
+            "type": "object",
+            "properties": {
+                "address_line": {
+                    "type": "string",
+                    "example": "123 Main St"
                 },
-                "social_activities": {
-                    "type": "string"
+                "carrier": {
+                    "type": "string",
+                    "example": "USPS"
                 },
-                "sodium_reduction_plan": {
-                    "type": "string"
+                "city": {
+                    "type": "string",
+                    "example": "Dallas"
                 },
-                "standing_getting_out_of_chair_balance": {
-                    "type": "string"
+                "country": {
+                    "type": "string",
+                    "example": "USA"
                 },
-                "stress_goals": {
-                    "type": "string"
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "stress_reduction_plan": {
-                    "type": "string"
+                "delivered_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "submission_date": {
-                    "type": "string"
+                "error": {
+                    "type": "string",
+                    "example": "API responded with status code 422: Address is incomplete"
                 },
-                "submission_id": {
-                    "type": "string"
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "thyroid_health_goals": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ShipmentItem"
+                    }
                 },
-                "thyroid_health_improvement_plan": {
-                    "type": "string"
+                "mio_shipment_id": {
+                    "type": "string",
+                    "example": "shp_1"
                 },
-                "tobacco_use_goals": {
-                    "type": "string"
+                "ordered_by_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "tobacco_use_plan": {
-                    "type": "string"
+                "organization_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "toileting": {
-                    "type": "string"
+                "patient": {
+                    "$ref": "#/definitions/models.User"
                 },
-                "using_phone_technology": {
-                    "type": "string"
+                "patient_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "weight_loss_goals": {
-                    "type": "string"
+                "phone": {
+                    "type": "string",
+                    "example": "08123456789"
                 },
-                "weight_loss_plan": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.MessageResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.VerifyUserFieldResponse": {
-            "type": "object",
-            "properties": {
-                "is_available": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "interaction.CreateRequest": {
-            "type": "object",
-            "required": [
-                "cost_category",
-                "duration",
-                "notes",
-                "session_date",
-                "user_id"
-            ],
-            "properties": {
-                "cost_category": {
+                "shipped_at": {
                     "type": "string",
-                    "enum": [
-                        "RPM",
-                        "CCM",
-                        "PCM",
-                        "BHI",
-                        "RTM"
-                    ]
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "doctor_id": {
-                    "type": "integer"
+                "state": {
+                    "type": "string",
+                    "example": "TX"
                 },
-                "duration": {
-                    "type": "integer"
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ShipmentStatus"
+                        }
+                    ],
+                    "example": "Ordered"
                 },
-                "notes": {
-                    "type": "string"
+                "tracking_number": {
+                    "type": "string",
+                    "example": "9400100000000000000000"
                 },
-                "session_date": {
+                "updated_at": {
                     "type": "string",
                     "example": "2021-01-01T00:00:00Z"
                 },
-                "user_id": {
-                    "type": "integer"
+                "zipcode": {
+                    "type": "string",
+                    "example": "75001"
                 }
             }
         },
-        "interaction.UpdateRequest": {
+        "models.ShipmentItem": {
             "type": "object",
             "properties": {
-                "cost_category": {
-                    "type": "string"
+                "count": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "doctor_id": {
-                    "type": "integer"
+                "device": {
+                    "$ref": "#/definitions/models.Device"
                 },
-                "duration": {
-                    "type": "integer"
+                "device_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "notes": {
-                    "type": "string"
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "session_date": {
+                "imei": {
                     "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                    "example": "123456789"
                 },
-                "user_id": {
-                    "type": "integer"
+                "model_number": {
+                    "type": "string",
+                    "example": "TBM-2092-G"
+                },
+                "shipment_id": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "models.AlertType": {
+        "models.ShipmentStatus": {
             "type": "string",
             "enum": [
-                "Critical",
-                "Warning",
-                "Ok"
+                "Pending",
+                "Ordered",
+                "Shipped",
+                "Delivered",
+                "Cancelled",
+                "Failed"
             ],
             "x-enum-varnames": [
-                "AlertCritical",
-                "AlertWarning",
-                "AlertOk"
+                "ShipmentPending",
+                "ShipmentOrdered",
+                "ShipmentShipped",
+                "ShipmentDelivered",
+                "ShipmentCancelled",
+                "ShipmentFailed"
             ]
         },
-        "models.CarePlan": {
+        "models.SummaryRange": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                "max": {
+                    "type": "integer",
+                    "example": 131
                 },
-                "doctor": {
-                    "$ref": "#/definitions/models.User"
+                "mean": {
+                    "type": "number",
+                    "example": 124.5
                 },
-                "doctor_id": {
+                "min": {
+                    "type": "integer",
+                    "example": 118
+                }
+            }
+        },
+        "models.TelemetryAlertNote": {
+            "type": "object",
+            "properties": {
+                "alert_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "id": {
+                "author": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "author_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "updated_at": {
+                "created_at": {
                     "type": "string",
                     "example": "2021-01-01T00:00:00Z"
                 },
-                "url": {
-                    "type": "string",
-                    "example": "https://cdn.med-kick.com/xxx.pdf"
-                },
-                "user": {
-                    "$ref": "#/definitions/models.User"
-                },
-                "user_id": {
+                "id": {
                     "type": "integer",
                     "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Called patient, BP retaken at 135/85"
                 }
             }
         },
-        "models.Device": {
+        "models.TelemetryBucketSize": {
+            "type": "string",
+            "enum": [
+                "day",
+                "week",
+                "month"
+            ],
+            "x-enum-varnames": [
+                "BucketDay",
+                "BucketWeek",
+                "BucketMonth"
+            ]
+        },
+        "models.TelemetryImport": {
             "type": "object",
             "properties": {
-                "battery_level": {
-                    "type": "integer",
-                    "example": 100
+                "column_map": {
+                    "type": "object"
                 },
                 "created_at": {
                     "type": "string",
                     "example": "2021-01-01T00:00:00Z"
                 },
-                "deviceTelemetryData": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.DeviceTelemetryData"
-                    }
+                "duplicate_count": {
+                    "type": "integer",
+                    "example": 5
                 },
-                "firmware_version": {
+                "error": {
                     "type": "string",
-                    "example": "1.0.0"
+                    "example": "file has no rows"
                 },
-                "id": {
+                "error_count": {
                     "type": "integer",
-                    "example": 1
+                    "example": 3
                 },
-                "imei": {
+                "file_name": {
                     "type": "string",
-                    "example": "123456789"
+                    "example": "readings.csv"
                 },
-                "model_number": {
+                "finished_at": {
                     "type": "string",
-                    "example": "123456"
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "Sphygmomanometer/Weight Scale/Blood Glucose Meter"
+                "format": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ImportFormat"
+                        }
+                    ],
+                    "example": "CSV"
                 },
-                "serial_number": {
-                    "type": "string",
-                    "example": "123456789"
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "signal_strength": {
-                    "type": "string",
-                    "example": "100"
+                "imported_count": {
+                    "type": "integer",
+                    "example": 112
                 },
-                "updated_at": {
+                "organization_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "patient_match": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PatientMatch"
+                        }
+                    ],
+                    "example": "MRN"
+                },
+                "reverted_at": {
                     "type": "string",
                     "example": "2021-01-01T00:00:00Z"
                 },
-                "user": {
-                    "$ref": "#/definitions/models.User"
-                },
-                "user_id": {
+                "reverted_by_id": {
                     "type": "integer",
                     "example": 1
-                }
-            }
-        },
-        "models.DeviceDTO": {
-            "type": "object",
-            "properties": {
-                "battery_level": {
+                },
+                "reverted_count": {
                     "type": "integer",
-                    "example": 100
+                    "example": 0
                 },
-                "created_at": {
+                "started_at": {
                     "type": "string",
                     "example": "2021-01-01T00:00:00Z"
                 },
-                "firmware_version": {
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ImportStatus"
+                        }
+                    ],
+                    "example": "Completed"
+                },
+                "total_rows": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "updated_at": {
                     "type": "string",
-                    "example": "1.0.0"
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "id": {
+                "uploaded_by_id": {
                     "type": "integer",
                     "example": 1
+                }
+            }
+        },
+        "models.TelemetryStats": {
+            "type": "object",
+            "properties": {
+                "blood_pressure": {
+                    "$ref": "#/definitions/models.BloodPressureTimeOfDay"
                 },
-                "imei": {
-                    "type": "string",
-                    "example": "123456789"
+                "bucket_size": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TelemetryBucketSize"
+                        }
+                    ],
+                    "example": "day"
                 },
-                "model_number": {
-                    "type": "string",
-                    "example": "123456"
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TelemetryStatsBucket"
+                    }
                 },
-                "name": {
+                "end": {
                     "type": "string",
-                    "example": "Sphygmomanometer/Weight Scale/Blood Glucose Meter"
+                    "example": "2021-01-31T00:00:00-05:00"
                 },
-                "serial_number": {
-                    "type": "string",
-                    "example": "123456789"
+                "measurement_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeasurementType"
+                        }
+                    ],
+                    "example": "Systolic"
                 },
-                "signal_strength": {
+                "overall": {
+                    "$ref": "#/definitions/models.MeasurementStats"
+                },
+                "patient_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start": {
                     "type": "string",
-                    "example": "100"
+                    "example": "2021-01-01T00:00:00-05:00"
                 },
-                "updated_at": {
+                "threshold": {
+                    "$ref": "#/definitions/models.AlertThreshold"
+                },
+                "timezone": {
                     "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                    "example": "America/New_York"
                 }
             }
         },
-        "models.DeviceResponse": {
+        "models.TelemetryStatsBucket": {
             "type": "object",
             "properties": {
-                "battery_level": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "deviceTelemetryData": {
-                    "$ref": "#/definitions/models.DeviceTelemetryDataResponse"
-                },
-                "firmware_version": {
-                    "type": "string"
+                "count": {
+                    "type": "integer",
+                    "example": 14
                 },
-                "id": {
-                    "type": "integer"
+                "end": {
+                    "type": "string",
+                    "example": "2021-01-02T00:00:00-05:00"
                 },
-                "imei": {
-                    "type": "string"
+                "max": {
+                    "type": "integer",
+                    "example": 148
                 },
-                "model_number": {
-                    "type": "string"
+                "mean": {
+                    "type": "number",
+                    "example": 127.5
                 },
-                "name": {
-                    "type": "string"
+                "median": {
+                    "type": "number",
+                    "example": 125
                 },
-                "serial_number": {
-                    "type": "string"
+                "min": {
+                    "type": "integer",
+                    "example": 112
                 },
-                "signal_strength": {
-                    "type": "string"
+                "reading_days": {
+                    "type": "integer",
+                    "example": 7
                 },
-                "updated_at": {
-                    "type": "string"
+                "start": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00-05:00"
                 },
-                "user_id": {
-                    "type": "integer"
+                "time_in_range": {
+                    "$ref": "#/definitions/models.TimeInRange"
                 }
             }
         },
-        "models.DeviceStatusData": {
+        "models.ThresholdTemplate": {
             "type": "object",
             "properties": {
-                "attach_time": {
-                    "type": "string",
-                    "example": "100"
-                },
                 "created_at": {
                     "type": "string",
                     "example": "2021-01-01T00:00:00Z"
                 },
-                "device": {
-                    "$ref": "#/definitions/models.Device"
+                "critical_high": {
+                    "type": "integer",
+                    "example": 180
                 },
-                "device_id": {
+                "critical_low": {
                     "type": "integer",
-                    "example": 1
+                    "example": 90
+                },
+                "device_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceType"
+                        }
+                    ],
+                    "example": "BloodPressure"
+                },
+                "diagnosis_code": {
+                    "type": "string",
+                    "example": "I10"
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "measure_count": {
-                    "type": "integer",
-                    "example": 100
+                "measurement_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeasurementType"
+                        }
+                    ],
+                    "example": "Systolic"
                 },
-                "network_format": {
+                "note": {
                     "type": "string",
-                    "example": "GSM;eMTC;NB-IoT"
+                    "example": "Hypertension protocol"
                 },
-                "network_ops": {
+                "organization_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
                     "type": "string",
-                    "example": "T-Mobile;Verizon"
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "signal": {
+                "warning_high": {
                     "type": "integer",
-                    "example": 100
+                    "example": 140
                 },
-                "temperature": {
+                "warning_low": {
                     "type": "integer",
                     "example": 100
+                }
+            }
+        },
+        "models.TimeInRange": {
+            "type": "object",
+            "properties": {
+                "critical_high": {
+                    "type": "number",
+                    "example": 0
                 },
-                "timezone": {
-                    "description": "Device",
-                    "type": "string",
-                    "example": "UTC+6"
+                "critical_low": {
+                    "type": "number",
+                    "example": 0
                 },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                "in_range": {
+                    "type": "number",
+                    "example": 85.7
+                },
+                "warning_high": {
+                    "type": "number",
+                    "example": 14.3
+                },
+                "warning_low": {
+                    "type": "number",
+                    "example": 0
                 }
             }
         },
-        "models.DeviceTelemetryData": {
+        "models.TrendDirection": {
+            "type": "string",
+            "enum": [
+                "Increase",
+                "Decrease",
+                "Any"
+            ],
+            "x-enum-varnames": [
+                "TrendIncrease",
+                "TrendDecrease",
+                "TrendAny"
+            ]
+        },
+        "models.TrendRuleType": {
+            "type": "string",
+            "enum": [
+                "Delta",
+                "RollingAverage",
+                "OccurrenceCount"
+            ],
+            "x-enum-varnames": [
+                "TrendDelta",
+                "TrendRollingAverage",
+                "TrendOccurrenceCount"
+            ]
+        },
+        "models.User": {
             "type": "object",
             "properties": {
-                "blood_glucose": {
-                    "description": "Blood Glucose Meter",
-                    "type": "integer",
-                    "example": 80
+                "address": {
+                    "type": "string",
+                    "example": "123 Main St"
+                },
+                "avatar_src": {
+                    "type": "string",
+                    "example": "https://cdn.med-kick.com/xxx.jpg"
+                },
+                "city": {
+                    "type": "string",
+                    "example": "Dallas"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "USA"
                 },
                 "created_at": {
                     "type": "string",
                     "example": "2021-01-01T00:00:00Z"
                 },
                 "device": {
-                    "$ref": "#/definitions/models.Device"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Device"
+                    }
                 },
-                "device_id": {
-                    "type": "integer",
-                    "example": 1
+                "dob": {
+                    "type": "string",
+                    "example": "2000-01-01"
                 },
-                "diastolic_bp": {
-                    "type": "integer",
-                    "example": 80
+                "email": {
+                    "type": "string"
                 },
-                "hand_shaking": {
-                    "type": "boolean",
-                    "example": false
+                "first_name": {
+                    "type": "string",
+                    "example": "John"
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "irregular_heartbeat": {
+                "insurance_id": {
+                    "type": "string",
+                    "example": "123456789"
+                },
+                "insurance_provider": {
+                    "type": "string",
+                    "example": "Aetna"
+                },
+                "interaction": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Interaction"
+                    }
+                },
+                "is_deleted": {
                     "type": "boolean",
                     "example": false
                 },
-                "meal": {
+                "last_name": {
                     "type": "string",
-                    "example": "1. Before Meal; 2. After Meal"
+                    "example": "Doe"
                 },
-                "measured_at": {
+                "location": {
                     "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                    "example": "Dallas, TX"
                 },
-                "pulse": {
+                "mrn": {
+                    "type": "string",
+                    "example": "A-10023"
+                },
+                "organization": {
+                    "$ref": "#/definitions/models.Organization"
+                },
+                "organization_id": {
                     "type": "integer",
-                    "example": 80
+                    "example": 1
                 },
-                "sample_type": {
+                "password": {
                     "type": "string",
-                    "example": "1. Blood or Resistance; 2. Quality Control Liquid; 3. Sample is invalid"
+                    "example": "123456"
                 },
-                "systolic_bp": {
-                    "description": "Sphygmomanometer",
-                    "type": "integer",
-                    "example": 120
+                "patientDiagnosis": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PatientDiagnosis"
+                    }
                 },
-                "test_paper": {
+                "phone": {
                     "type": "string",
-                    "example": "1. GOD; 2. GDH"
+                    "example": "08123456789"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "Test Provider"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "admin"
                 },
-                "triple_measurement": {
-                    "type": "boolean",
-                    "example": false
+                "state": {
+                    "type": "string",
+                    "example": "TX"
                 },
-                "unit": {
+                "timezone": {
                     "type": "string",
-                    "example": "mg/dL"
+                    "example": "America/Chicago"
                 },
                 "updated_at": {
                     "type": "string",
                     "example": "2021-01-01T00:00:00Z"
                 },
-                "user": {
-                    "$ref": "#/definitions/models.User"
-                },
-                "user_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "weight": {
-                    "description": "Weight Scale",
-                    "type": "integer",
-                    "example": 80
-                },
-                "weight_lock_count": {
-                    "type": "integer",
-                    "example": 3
-                },
-                "weight_stable_time": {
-                    "type": "integer",
-                    "example": 5
+                "zipcode": {
+                    "type": "string",
+                    "example": "32343"
                 }
             }
         },
-        "models.DeviceTelemetryDataResponse": {
+        "models.UserResponse": {
             "type": "object",
             "properties": {
-                "blood_glucose": {
-                    "description": "Blood Glucose Meter",
-                    "type": "integer"
+                "address": {
+                    "type": "string"
                 },
-                "created_at": {
+                "avatar_src": {
                     "type": "string"
                 },
-                "device_id": {
-                    "type": "integer"
+                "city": {
+                    "type": "string"
                 },
-                "diastolic_bp": {
-                    "type": "integer"
+                "country": {
+                    "type": "string"
                 },
-                "hand_shaking": {
-                    "type": "boolean"
+                "created_at": {
+                    "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DeviceResponse"
+                    }
                 },
-                "irregular_heartbeat": {
-                    "type": "boolean"
+                "dob": {
+                    "type": "string"
                 },
-                "meal": {
+                "email": {
                     "type": "string"
                 },
-                "measured_at": {
+                "first_name": {
                     "type": "string"
                 },
-                "pulse": {
+                "id": {
                     "type": "integer"
                 },
-                "sample_type": {
+                "insurance_id": {
                     "type": "string"
                 },
-                "systolic_bp": {
-                    "description": "Sphygmomanometer",
-                    "type": "integer"
-                },
-                "test_paper": {
+                "insurance_provider": {
                     "type": "string"
                 },
-                "triple_measurement": {
-                    "type": "boolean"
+                "last_name": {
+                    "type": "string"
                 },
-                "unit": {
+                "location": {
                     "type": "string"
                 },
-                "updated_at": {
+                "mrn": {
                     "type": "string"
                 },
-                "weight": {
-                    "description": "Weight Scale",
-                    "type": "integer"
+                "organization": {
+                    "$ref": "#/definitions/models.Organization"
                 },
-                "weight_lock_count": {
-                    "type": "integer"
+                "patient_diagnosis": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DignosesResponse"
+                    }
                 },
-                "weight_stable_time": {
-                    "type": "integer"
-                }
-            }
-        },
-        "models.DeviceType": {
-            "type": "string",
-            "enum": [
-                "BloodPressure",
-                "BloodGlucose",
-                "WeightScale"
-            ],
-            "x-enum-varnames": [
-                "BloodPressure",
-                "BloodGlucose",
-                "WeightScale"
-            ]
-        },
-        "models.Diagnosis": {
-            "type": "object",
-            "properties": {
-                "code": {
+                "reading_date": {
                     "type": "string",
-                    "example": "I10"
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "diagnosis_name": {
-                    "type": "string",
-                    "example": "Essential (primary) hypertension"
+                "readings": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "short_mnemonic": {
-                    "type": "string",
-                    "example": "Essential (primary) hypertension"
+                "role": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "total_duration": {
+                    "description": "Interactions      MainInterActionsResponse ` + "`" + `json:\"interactions,omitempty\"` + "`" + `",
+                    "type": "integer",
+                    "example": 30
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "zipcode": {
+                    "type": "string"
                 }
             }
         },
-        "models.DignosesResponse": {
+        "organization.APIKeyCreatedResponse": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string",
                     "example": "2021-01-01T00:00:00Z"
                 },
-                "diagnosis": {
-                    "$ref": "#/definitions/models.Diagnosis"
+                "created_by_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "diagnosis_id": {
-                    "type": "integer"
+                "expires_at": {
+                    "type": "string",
+                    "example": "2022-01-01T00:00:00Z"
                 },
-                "user_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "models.Interaction": {
-            "type": "object",
-            "properties": {
-                "cost_category": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_used_at": {
                     "type": "string",
-                    "example": ""
+                    "example": "2021-06-01T00:00:00Z"
                 },
-                "created_at": {
+                "last_used_ip": {
                     "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                    "example": "203.0.113.7"
                 },
-                "doctor": {
-                    "$ref": "#/definitions/models.User"
+                "name": {
+                    "type": "string",
+                    "example": "EHR integration"
                 },
-                "doctor_id": {
+                "organization_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "duration": {
-                    "type": "integer",
-                    "example": 30
+                "prefix": {
+                    "description": "Prefix is the start of the token, enough to tell keys apart",
+                    "type": "string",
+                    "example": "mk_3fZq9Lw2"
                 },
-                "id": {
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2021-07-01T00:00:00Z"
+                },
+                "revoked_by_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "notes": {
+                "scopes": {
+                    "description": "Scopes is a comma separated list of APIKeyScopes",
                     "type": "string",
-                    "example": "Patient is doing well"
+                    "example": "user:read,device:read"
                 },
-                "session_date": {
+                "token": {
+                    "description": "Token is only returned when the key is created",
                     "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                    "example": "mk_3fZq9Lw2bV0cJx7TnR1yUe5sKd8hGa4pWm6oQiLz2fE"
                 },
                 "updated_at": {
                     "type": "string",
@@ -4468,488 +11025,678 @@ const docTemplate = `{
                 }
             }
         },
-        "models.InteractionSettingType": {
-            "type": "string",
-            "enum": [
-                "ColorThreshold"
+        "organization.APIKeyData": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
             ],
-            "x-enum-varnames": [
-                "ColorThreshold"
-            ]
+            "properties": {
+                "expires_in_days": {
+                    "type": "integer",
+                    "maximum": 730,
+                    "minimum": 1,
+                    "example": 90
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "EHR integration"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "user:read",
+                        "device:read"
+                    ]
+                },
+                "user_id": {
+                    "description": "UserID is the organization user the key acts as, the caller when not set",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "organization.AlertNotificationRuleData": {
+            "type": "object",
+            "required": [
+                "channel",
+                "escalate_after_minutes",
+                "min_alert_type",
+                "recipient_type",
+                "tier"
+            ],
+            "properties": {
+                "channel": {
+                    "enum": [
+                        "Email",
+                        "SMS",
+                        "Webhook"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotificationChannel"
+                        }
+                    ],
+                    "example": "Email"
+                },
+                "escalate_after_minutes": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 15
+                },
+                "min_alert_type": {
+                    "enum": [
+                        "Warning",
+                        "Critical"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AlertType"
+                        }
+                    ],
+                    "example": "Critical"
+                },
+                "recipient_type": {
+                    "enum": [
+                        "CareManager",
+                        "Provider",
+                        "OnCall"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotificationRecipient"
+                        }
+                    ],
+                    "example": "CareManager"
+                },
+                "recipient_user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "target": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/alerts"
+                },
+                "tier": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
         },
-        "models.MeasurementType": {
-            "type": "string",
-            "enum": [
-                "Systolic",
-                "Diastolic",
-                "Pulse",
-                "Weight"
+        "organization.AssignTelemetryAlertData": {
+            "type": "object",
+            "required": [
+                "assigned_to_id"
             ],
-            "x-enum-varnames": [
-                "Systolic",
-                "Diastolic",
-                "Pulse",
-                "Weight"
-            ]
+            "properties": {
+                "assigned_to_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
         },
-        "models.Organization": {
+        "organization.BillingRecordBody": {
             "type": "object",
             "properties": {
-                "address": {
+                "cpt_codes": {
                     "type": "string",
-                    "example": "123 Main St"
+                    "example": "1,2,3"
                 },
-                "address2": {
+                "dob": {
                     "type": "string",
-                    "example": "Apt 1"
+                    "example": "01/01/2000"
                 },
-                "city": {
+                "dos": {
                     "type": "string",
-                    "example": "Baltimore"
+                    "example": "01/01/2021"
                 },
-                "country": {
+                "first_name": {
                     "type": "string",
-                    "example": "USA"
+                    "example": "John"
                 },
-                "created_at": {
+                "icd10": {
                     "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                    "example": "A00.0"
                 },
-                "name": {
+                "last_name": {
                     "type": "string",
-                    "example": "John Hopkins"
+                    "example": "Doe"
                 },
-                "phone": {
+                "provider": {
                     "type": "string",
-                    "example": "08123456789"
-                },
-                "state": {
+                    "example": "Dr. John Doe"
+                }
+            }
+        },
+        "organization.BillingReportResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
                     "type": "string",
-                    "example": "MD"
+                    "example": "2006-01-02"
                 },
-                "updated_at": {
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/organization.BillingRecordBody"
+                    }
+                },
+                "service": {
                     "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                    "example": "RPM"
                 },
-                "zip": {
+                "start_date": {
                     "type": "string",
-                    "example": "12345"
+                    "example": "2006-01-02"
                 }
             }
         },
-        "models.PatientDiagnosis": {
+        "organization.ClinicalSummaryPeriodData": {
             "type": "object",
+            "required": [
+                "period"
+            ],
             "properties": {
-                "created_at": {
+                "period": {
                     "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
-                },
-                "diagnosis": {
-                    "$ref": "#/definitions/models.Diagnosis"
-                },
-                "diagnosis_id": {
-                    "type": "integer"
-                },
-                "patient": {
-                    "$ref": "#/definitions/models.User"
-                },
-                "user_id": {
-                    "type": "integer"
+                    "example": "2021-01"
                 }
             }
         },
-        "models.Service": {
+        "organization.CreateRequest": {
             "type": "object",
+            "required": [
+                "address",
+                "city",
+                "country",
+                "name",
+                "phone",
+                "state",
+                "zip"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                "address": {
+                    "type": "string"
                 },
-                "description": {
-                    "type": "string",
-                    "example": "Remote Patient Monitoring"
+                "address2": {
+                    "type": "string"
                 },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "city": {
+                    "type": "string"
                 },
-                "is_enabled": {
-                    "type": "boolean",
-                    "example": true
+                "country": {
+                    "type": "string"
                 },
-                "service_code": {
-                    "type": "string",
-                    "example": "RPM"
+                "name": {
+                    "type": "string"
                 },
-                "service_name": {
-                    "type": "string",
-                    "example": "Remote Patient Monitoring"
+                "phone": {
+                    "type": "string"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                "state": {
+                    "type": "string"
+                },
+                "zip": {
+                    "type": "string"
                 }
             }
         },
-        "models.User": {
+        "organization.DeviceHealthRecord": {
             "type": "object",
             "properties": {
-                "avatar_src": {
-                    "type": "string",
-                    "example": "https://cdn.med-kick.com/xxx.jpg"
+                "alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DeviceAlert"
+                    }
                 },
-                "city": {
-                    "type": "string",
-                    "example": "Dallas"
+                "battery_level": {
+                    "type": "integer",
+                    "example": 80
                 },
-                "country": {
+                "device_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "healthy": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "imei": {
                     "type": "string",
-                    "example": "USA"
+                    "example": "123456789"
                 },
-                "created_at": {
+                "last_reading_at": {
                     "type": "string",
                     "example": "2021-01-01T00:00:00Z"
                 },
-                "device": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Device"
-                    }
-                },
-                "dob": {
+                "last_status_at": {
                     "type": "string",
-                    "example": "2000-01-01"
-                },
-                "email": {
-                    "type": "string"
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "first_name": {
+                "name": {
                     "type": "string",
-                    "example": "John"
+                    "example": "Sphygmomanometer"
                 },
-                "id": {
+                "patient_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "insurance_id": {
+                "patient_name": {
                     "type": "string",
-                    "example": "123456789"
+                    "example": "John Doe"
                 },
-                "insurance_provider": {
+                "signal_strength": {
                     "type": "string",
-                    "example": "Aetna"
-                },
-                "interaction": {
+                    "example": "20"
+                }
+            }
+        },
+        "organization.DeviceHealthResponse": {
+            "type": "object",
+            "properties": {
+                "devices": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Interaction"
+                        "$ref": "#/definitions/organization.DeviceHealthRecord"
                     }
                 },
-                "is_deleted": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "last_name": {
-                    "type": "string",
-                    "example": "Doe"
-                },
-                "location": {
-                    "type": "string",
-                    "example": "Dallas, TX"
-                },
-                "organization": {
-                    "$ref": "#/definitions/models.Organization"
+                "limits": {
+                    "$ref": "#/definitions/models.DeviceHealthLimits"
                 },
-                "organization_id": {
-                    "type": "integer",
-                    "example": 1
+                "summary": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "organization.InteractionSettingData": {
+            "type": "object",
+            "required": [
+                "setting_type"
+            ],
+            "properties": {
+                "setting_type": {
+                    "enum": [
+                        "ColorThreshold",
+                        "AutoResolveNormalReadings",
+                        "AlertAckSLAMinutes",
+                        "AlertResolveSLAMinutes",
+                        "DeviceNoReadingDays",
+                        "DeviceLowBatteryPercent",
+                        "DeviceWeakSignal",
+                        "DeviceHeartbeatHours",
+                        "AdherenceStreakBreakDays",
+                        "AdherenceReminders"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.InteractionSettingType"
+                        }
+                    ]
                 },
-                "password": {
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "organization.MFAPolicyData": {
+            "type": "object",
+            "properties": {
+                "roles": {
+                    "description": "Roles whose users must use MFA, empty to not require it",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "org_admin",
+                        "care_manager"
+                    ]
+                }
+            }
+        },
+        "organization.ReadingAdherenceResponse": {
+            "type": "object",
+            "properties": {
+                "month": {
                     "type": "string",
-                    "example": "123456"
+                    "example": "2021-01"
                 },
-                "patientDiagnosis": {
+                "patients": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.PatientDiagnosis"
+                        "$ref": "#/definitions/models.ReadingAdherence"
                     }
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "08123456789"
-                },
-                "provider": {
-                    "type": "string",
-                    "example": "Test Provider"
-                },
-                "role": {
-                    "type": "string",
-                    "example": "admin"
+                "summary": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 },
-                "state": {
-                    "type": "string",
-                    "example": "TX"
+                "target_days": {
+                    "type": "integer",
+                    "example": 16
+                }
+            }
+        },
+        "organization.ResolveTelemetryAlertData": {
+            "type": "object",
+            "properties": {
+                "interaction_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "updated_at": {
+                "note": {
                     "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                    "example": "Patient retook BP, back in range"
                 },
-                "zipcode": {
+                "outcome": {
+                    "enum": [
+                        "CalledPatient",
+                        "MedicationChange",
+                        "SentToER",
+                        "NoActionNeeded",
+                        "Other"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AlertOutcome"
+                        }
+                    ],
+                    "example": "CalledPatient"
+                }
+            }
+        },
+        "organization.TelemetryAlertNoteData": {
+            "type": "object",
+            "required": [
+                "note"
+            ],
+            "properties": {
+                "note": {
                     "type": "string",
-                    "example": "32343"
+                    "example": "Left voicemail for patient"
                 }
             }
         },
-        "models.UserResponse": {
+        "organization.TelemetryAlertResponse": {
             "type": "object",
             "properties": {
-                "avatar_src": {
-                    "type": "string"
-                },
-                "city": {
-                    "type": "string"
+                "acknowledged_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "country": {
-                    "type": "string"
+                "acknowledged_by": {
+                    "type": "string",
+                    "example": "John Doe"
                 },
-                "created_at": {
-                    "type": "string"
+                "alert_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "devices": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.DeviceResponse"
-                    }
+                "assigned_to": {
+                    "type": "string",
+                    "example": "Jane Doe"
                 },
-                "dob": {
-                    "type": "string"
+                "assigned_to_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "email": {
-                    "type": "string"
+                "episode_count": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "first_name": {
-                    "type": "string"
+                "interaction_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "id": {
-                    "type": "integer"
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "insurance_id": {
-                    "type": "string"
+                "is_auto_resolved": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "insurance_provider": {
-                    "type": "string"
+                "last_time": {
+                    "type": "string",
+                    "example": "2021-01-03T00:00:00Z"
                 },
-                "last_name": {
-                    "type": "string"
+                "outcome": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AlertOutcome"
+                        }
+                    ],
+                    "example": "CalledPatient"
                 },
-                "location": {
-                    "type": "string"
+                "patient_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "organization": {
-                    "$ref": "#/definitions/models.Organization"
+                "patient_name": {
+                    "type": "string",
+                    "example": "John Doe"
                 },
-                "patient_diagnosis": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.DignosesResponse"
-                    }
+                "phone_number": {
+                    "type": "string",
+                    "example": "08123456789"
                 },
-                "reading_date": {
+                "resolved_at": {
                     "type": "string",
                     "example": "2021-01-01T00:00:00Z"
                 },
-                "readings": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "role": {
-                    "type": "string"
+                "resolved_by": {
+                    "type": "string",
+                    "example": "John Doe"
                 },
-                "state": {
-                    "type": "string"
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AlertType"
+                        }
+                    ],
+                    "example": "Critical"
                 },
-                "total_duration": {
-                    "description": "Interactions      MainInterActionsResponse ` + "`" + `json:\"interactions,omitempty\"` + "`" + `",
+                "telemetry_id": {
                     "type": "integer",
-                    "example": 30
+                    "example": 1
                 },
-                "updated_at": {
-                    "type": "string"
+                "time": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 },
-                "zipcode": {
-                    "type": "string"
+                "vitals": {
+                    "type": "object",
+                    "additionalProperties": true
                 }
             }
         },
-        "organization.BillingRecordBody": {
+        "organization.TelemetryAlertSLAResponse": {
             "type": "object",
             "properties": {
-                "cpt_codes": {
-                    "type": "string",
-                    "example": "1,2,3"
+                "ack_target_minutes": {
+                    "type": "integer",
+                    "example": 30
                 },
-                "dob": {
-                    "type": "string",
-                    "example": "01/01/2000"
+                "critical": {
+                    "$ref": "#/definitions/organization.TelemetryAlertSLAStats"
                 },
-                "dos": {
+                "end_date": {
                     "type": "string",
-                    "example": "01/01/2021"
+                    "example": "01-31-2021"
                 },
-                "first_name": {
-                    "type": "string",
-                    "example": "John"
+                "overall": {
+                    "$ref": "#/definitions/organization.TelemetryAlertSLAStats"
                 },
-                "icd10": {
-                    "type": "string",
-                    "example": "A00.0"
+                "resolve_target_minutes": {
+                    "type": "integer",
+                    "example": 1440
                 },
-                "last_name": {
+                "start_date": {
                     "type": "string",
-                    "example": "Doe"
+                    "example": "01-01-2021"
                 },
-                "provider": {
-                    "type": "string",
-                    "example": "Dr. John Doe"
+                "warning": {
+                    "$ref": "#/definitions/organization.TelemetryAlertSLAStats"
                 }
             }
         },
-        "organization.BillingReportResponse": {
+        "organization.TelemetryAlertSLAStats": {
             "type": "object",
             "properties": {
-                "end_date": {
-                    "type": "string",
-                    "example": "2006-01-02"
+                "ack_breached": {
+                    "description": "AckBreached counts alerts that were acknowledged late or are still waiting past the target",
+                    "type": "integer",
+                    "example": 2
                 },
-                "records": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/organization.BillingRecordBody"
-                    }
+                "ack_within_target": {
+                    "type": "integer",
+                    "example": 16
                 },
-                "service": {
-                    "type": "string",
-                    "example": "RPM"
+                "acknowledged": {
+                    "type": "integer",
+                    "example": 18
                 },
-                "start_date": {
-                    "type": "string",
-                    "example": "2006-01-02"
-                }
-            }
-        },
-        "organization.CreateRequest": {
-            "type": "object",
-            "required": [
-                "address",
-                "city",
-                "country",
-                "name",
-                "phone",
-                "state",
-                "zip"
-            ],
-            "properties": {
-                "address": {
-                    "type": "string"
+                "auto_resolved": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "address2": {
-                    "type": "string"
+                "avg_ack_minutes": {
+                    "type": "number",
+                    "example": 12.5
                 },
-                "city": {
-                    "type": "string"
+                "avg_resolve_minutes": {
+                    "type": "number",
+                    "example": 240
                 },
-                "country": {
-                    "type": "string"
+                "resolve_breached": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "name": {
-                    "type": "string"
+                "resolve_within_target": {
+                    "type": "integer",
+                    "example": 14
                 },
-                "phone": {
-                    "type": "string"
+                "resolved": {
+                    "type": "integer",
+                    "example": 15
                 },
-                "state": {
-                    "type": "string"
+                "total": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "organization.TelemetryAlertTriageData": {
+            "type": "object",
+            "properties": {
+                "interaction_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "zip": {
-                    "type": "string"
+                "outcome": {
+                    "enum": [
+                        "CalledPatient",
+                        "MedicationChange",
+                        "SentToER",
+                        "NoActionNeeded",
+                        "Other"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AlertOutcome"
+                        }
+                    ],
+                    "example": "MedicationChange"
                 }
             }
         },
-        "organization.InteractionSettingData": {
+        "organization.ThresholdMeasurementData": {
             "type": "object",
             "required": [
-                "setting_type"
+                "measurement_type"
             ],
             "properties": {
-                "setting_type": {
+                "critical_high": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "critical_low": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "measurement_type": {
                     "enum": [
-                        "ColorThreshold"
+                        "Systolic",
+                        "Diastolic",
+                        "Pulse",
+                        "Weight",
+                        "FastingGlucose",
+                        "PostMealGlucose"
                     ],
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.InteractionSettingType"
+                            "$ref": "#/definitions/models.MeasurementType"
                         }
                     ]
                 },
-                "value": {
-                    "type": "integer"
+                "warning_high": {
+                    "type": "integer",
+                    "example": 140
+                },
+                "warning_low": {
+                    "type": "integer",
+                    "example": 100
                 }
             }
         },
-        "organization.TelemetryAlertResponse": {
+        "organization.ThresholdTemplateData": {
             "type": "object",
+            "required": [
+                "device_type",
+                "measurements"
+            ],
             "properties": {
-                "alert_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "patient_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "patient_name": {
-                    "type": "string",
-                    "example": "John Doe"
-                },
-                "phone_number": {
-                    "type": "string",
-                    "example": "08123456789"
-                },
-                "resolved_at": {
-                    "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
-                },
-                "resolved_by": {
-                    "type": "string",
-                    "example": "John Doe"
-                },
-                "status": {
+                "device_type": {
+                    "enum": [
+                        "BloodPressure",
+                        "BloodGlucose",
+                        "WeightScale"
+                    ],
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.AlertType"
+                            "$ref": "#/definitions/models.DeviceType"
                         }
                     ],
-                    "example": "Critical"
-                },
-                "telemetry_id": {
-                    "type": "integer",
-                    "example": 1
+                    "example": "BloodPressure"
                 },
-                "time": {
+                "diagnosis_code": {
+                    "description": "DiagnosisCode is the ICD-10 code the template applies to, empty for the organization default",
                     "type": "string",
-                    "example": "2021-01-01T00:00:00Z"
+                    "maxLength": 10,
+                    "example": "I10"
                 },
-                "vitals": {
-                    "type": "object",
-                    "additionalProperties": true
+                "measurements": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/organization.ThresholdMeasurementData"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Hypertension protocol"
                 }
             }
         },
@@ -5022,6 +11769,49 @@ const docTemplate = `{
                 }
             }
         },
+        "user.AlertTrendRuleData": {
+            "type": "object",
+            "required": [
+                "device_type",
+                "rules"
+            ],
+            "properties": {
+                "device_type": {
+                    "enum": [
+                        "BloodPressure",
+                        "BloodGlucose",
+                        "WeightScale"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceType"
+                        }
+                    ]
+                },
+                "note": {
+                    "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/user.TrendRuleData"
+                    }
+                }
+            }
+        },
+        "user.ClinicalSummaryPeriodData": {
+            "type": "object",
+            "required": [
+                "period"
+            ],
+            "properties": {
+                "period": {
+                    "type": "string",
+                    "example": "2021-01"
+                }
+            }
+        },
         "user.CreateRequest": {
             "type": "object",
             "required": [
@@ -5038,6 +11828,9 @@ const docTemplate = `{
                 "role"
             ],
             "properties": {
+                "address": {
+                    "type": "string"
+                },
                 "city": {
                     "type": "string"
                 },
@@ -5065,6 +11858,10 @@ const docTemplate = `{
                 "location": {
                     "type": "string"
                 },
+                "mrn": {
+                    "type": "string",
+                    "example": "A-10023"
+                },
                 "organization_id": {
                     "type": "integer"
                 },
@@ -5084,6 +11881,10 @@ const docTemplate = `{
                 "state": {
                     "type": "string"
                 },
+                "timezone": {
+                    "type": "string",
+                    "example": "America/Chicago"
+                },
                 "zipcode": {
                     "type": "string"
                 }
@@ -5118,6 +11919,139 @@ const docTemplate = `{
                 }
             }
         },
+        "user.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Successfully logged in"
+                },
+                "mfa_enrollment_required": {
+                    "description": "MFAEnrollmentRequired means the organization requires MFA and the user has to set it up\nbefore using the rest of the API",
+                    "type": "boolean",
+                    "example": false
+                },
+                "mfa_methods": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "totp",
+                        "recovery"
+                    ]
+                },
+                "mfa_required": {
+                    "description": "MFARequired means the login has to be finished with /auth/mfa/verify using one of MFAMethods",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "user.MFACodeData": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Code is from the authenticator app, or one of the recovery codes",
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "user.MFAEmailFallbackData": {
+            "type": "object",
+            "required": [
+                "code",
+                "enabled"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "user.MFAEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                },
+                "uri": {
+                    "description": "URI is the otpauth URI to show as a QR code",
+                    "type": "string",
+                    "example": "otpauth://totp/MedKick:john@example.com?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP\u0026issuer=MedKick"
+                }
+            }
+        },
+        "user.MFARecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "description": "RecoveryCodes are only shown once, each signs in one time",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k3pxpjbs-wy3dpehp"
+                    ]
+                }
+            }
+        },
+        "user.MFAStatusResponse": {
+            "type": "object",
+            "properties": {
+                "email_fallback": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "recovery_codes_remaining": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "required": {
+                    "description": "Required means MFA can't be turned off, platform admins and roles in the organization's policy require it",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "user.MFAVerifyData": {
+            "type": "object",
+            "required": [
+                "code",
+                "method"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "method": {
+                    "description": "Method is the kind of code, from the app, a recovery code or a code sent by email",
+                    "type": "string",
+                    "enum": [
+                        "totp",
+                        "recovery",
+                        "email"
+                    ],
+                    "example": "totp"
+                }
+            }
+        },
         "user.MeasurementData": {
             "type": "object",
             "required": [
@@ -5135,7 +12069,9 @@ const docTemplate = `{
                         "Systolic",
                         "Diastolic",
                         "Pulse",
-                        "Weight"
+                        "Weight",
+                        "FastingGlucose",
+                        "PostMealGlucose"
                     ],
                     "allOf": [
                         {
@@ -5143,6 +12079,11 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "source": {
+                    "description": "Source is only returned, it tells whether the threshold is the patient's own or inherited",
+                    "type": "string",
+                    "example": "Patient"
+                },
                 "warning_high": {
                     "type": "integer"
                 },
@@ -5252,9 +12193,75 @@ const docTemplate = `{
                 }
             }
         },
+        "user.TrendRuleData": {
+            "type": "object",
+            "required": [
+                "measurement_type",
+                "rule_type",
+                "window_hours"
+            ],
+            "properties": {
+                "critical_change": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "direction": {
+                    "enum": [
+                        "Increase",
+                        "Decrease",
+                        "Any"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TrendDirection"
+                        }
+                    ]
+                },
+                "measurement_type": {
+                    "enum": [
+                        "Systolic",
+                        "Diastolic",
+                        "Pulse",
+                        "Weight",
+                        "FastingGlucose",
+                        "PostMealGlucose",
+                        "IrregularHeartBeat"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeasurementType"
+                        }
+                    ]
+                },
+                "rule_type": {
+                    "enum": [
+                        "Delta",
+                        "RollingAverage",
+                        "OccurrenceCount"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TrendRuleType"
+                        }
+                    ]
+                },
+                "warning_change": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "window_hours": {
+                    "type": "integer",
+                    "maximum": 2160,
+                    "example": 24
+                }
+            }
+        },
         "user.UpdateRequest": {
             "type": "object",
             "properties": {
+                "address": {
+                    "type": "string"
+                },
                 "city": {
                     "type": "string"
                 },
@@ -5282,6 +12289,10 @@ const docTemplate = `{
                 "location": {
                     "type": "string"
                 },
+                "mrn": {
+                    "type": "string",
+                    "example": "A-10023"
+                },
                 "organization_id": {
                     "type": "integer"
                 },
@@ -5300,6 +12311,10 @@ const docTemplate = `{
                 "state": {
                     "type": "string"
                 },
+                "timezone": {
+                    "type": "string",
+                    "example": "America/Chicago"
+                },
                 "zipcode": {
                     "type": "string"
                 }
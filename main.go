@@ -5,6 +5,8 @@ import (
 	"MedKick-backend/pkg/database"
 	"MedKick-backend/pkg/echo"
 	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/event"
+	"MedKick-backend/pkg/notification"
 	"MedKick-backend/pkg/s3"
 	"MedKick-backend/pkg/sendgrid"
 	"MedKick-backend/pkg/validator"
@@ -53,6 +55,8 @@ func main() {
 
 	validator.Setup()
 	sendgrid.Setup()
+	notification.Setup()
+	event.Setup()
 	s3.Setup()
 
 	middleware.Setup()
@@ -0,0 +1,150 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// AlertNotificationRule says who an organization notifies about alerts at one escalation
+// tier. Tier 1 is notified when the alert is raised, every next tier is notified when the
+// alert is still unacknowledged EscalateAfterMinutes after the previous tier.
+type AlertNotificationRule struct {
+	ID                   uint                  `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	OrganizationID       uint                  `json:"organization_id" gorm:"index; not null" example:"1"`
+	Organization         *Organization         `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"`
+	Tier                 uint                  `json:"tier" gorm:"not null; default:1" example:"1"`
+	MinAlertType         AlertType             `json:"min_alert_type" gorm:"not null; default:Critical" example:"Critical"`
+	RecipientType        NotificationRecipient `json:"recipient_type" gorm:"not null" example:"CareManager"`
+	RecipientUserID      *uint                 `json:"recipient_user_id,omitempty" example:"1"`
+	RecipientUser        *User                 `json:"recipient_user,omitempty" gorm:"foreignKey:RecipientUserID"`
+	Channel              NotificationChannel   `json:"channel" gorm:"not null" example:"Email"`
+	Target               string                `json:"target,omitempty" gorm:"default:null" example:"https://example.com/hooks/alerts"`
+	EscalateAfterMinutes uint                  `json:"escalate_after_minutes" gorm:"not null; default:15" example:"15"`
+	CreatedAt            time.Time             `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt            time.Time             `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+type NotificationRecipient string
+
+const (
+	// RecipientCareManager notifies every care manager of the organization
+	RecipientCareManager NotificationRecipient = "CareManager"
+	// RecipientProvider notifies every doctor of the organization
+	RecipientProvider NotificationRecipient = "Provider"
+	// RecipientOnCall notifies the user in RecipientUserID, or the raw Target when no user is set
+	RecipientOnCall NotificationRecipient = "OnCall"
+)
+
+type NotificationChannel string
+
+const (
+	ChannelEmail   NotificationChannel = "Email"
+	ChannelSMS     NotificationChannel = "SMS"
+	ChannelWebhook NotificationChannel = "Webhook"
+)
+
+// Matches reports whether the rule applies to an alert of the given type
+func (r AlertNotificationRule) Matches(alertType AlertType) bool {
+	if r.MinAlertType == AlertWarning {
+		return alertType == AlertWarning || alertType == AlertCritical
+	}
+	return alertType == AlertCritical
+}
+
+// AlertNotification is one delivery attempt of an alert to one recipient
+type AlertNotification struct {
+	ID              uint                `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	AlertID         uint                `json:"alert_id" gorm:"index; not null" example:"1"`
+	RuleID          uint                `json:"rule_id" example:"1"`
+	Tier            uint                `json:"tier" example:"1"`
+	Channel         NotificationChannel `json:"channel" example:"Email"`
+	RecipientUserID *uint               `json:"recipient_user_id,omitempty" example:"1"`
+	Recipient       string              `json:"recipient" example:"john@doe.com"`
+	Status          NotificationStatus  `json:"status" example:"Sent"`
+	Error           string              `json:"error,omitempty" gorm:"default:null" example:"webhook responded with status code 500"`
+	CreatedAt       time.Time           `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// AlertNotificationClaim records that a rule was notified about an alert. Tiers are claimed
+// per rule, a rule that starts matching after its tier was reached still fires once.
+type AlertNotificationClaim struct {
+	ID        uint      `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	AlertID   uint      `json:"alert_id" gorm:"index:,unique,composite:alert_rule; not null" example:"1"`
+	RuleID    uint      `json:"rule_id" gorm:"index:,unique,composite:alert_rule; not null" example:"1"`
+	Tier      uint      `json:"tier" example:"1"`
+	CreatedAt time.Time `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+type NotificationStatus string
+
+const (
+	NotificationSent   NotificationStatus = "Sent"
+	NotificationFailed NotificationStatus = "Failed"
+)
+
+func ListAlertNotificationRules(organizationID uint) ([]AlertNotificationRule, error) {
+	var rules []AlertNotificationRule
+	db := database.DB.Where("organization_id = ?", organizationID)
+	if err := db.Order("tier asc").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *AlertNotificationRule) CreateAlertNotificationRule() error {
+	if err := database.DB.Create(&r).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeleteAlertNotificationRule(organizationID, ruleID uint) error {
+	db := database.DB.Where("organization_id = ? AND id = ?", organizationID, ruleID)
+	if err := db.Delete(&AlertNotificationRule{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (n *AlertNotification) CreateAlertNotification() error {
+	if err := database.DB.Create(&n).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func ListAlertNotifications(alertID uint) ([]AlertNotification, error) {
+	var notifications []AlertNotification
+	db := database.DB.Where("alert_id = ?", alertID)
+	if err := db.Order("id asc").Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// ClaimAlertNotificationRule claims the rule's notification of the alert. It only succeeds
+// once per alert and rule, so concurrent workers never notify a rule twice.
+func ClaimAlertNotificationRule(alertID, ruleID, tier uint) (bool, error) {
+	claim := AlertNotificationClaim{
+		AlertID: alertID,
+		RuleID:  ruleID,
+		Tier:    tier,
+	}
+
+	result := database.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&claim)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// BackfillAlertNotificationClaims claims the rules that were notified before claims were
+// kept, so they are not notified again
+func BackfillAlertNotificationClaims() error {
+	return database.DB.Exec(`INSERT IGNORE INTO alert_notification_claims (alert_id, rule_id, tier, created_at)
+		SELECT alert_id, rule_id, MIN(tier), MIN(created_at) FROM alert_notifications GROUP BY alert_id, rule_id`).Error
+}
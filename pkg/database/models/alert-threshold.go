@@ -18,8 +18,10 @@ type AlertThreshold struct {
 	WarningHigh     *uint           `json:"warning_high" gorm:"default:null" example:"120"`
 	CriticalHigh    *uint           `json:"critical_high" gorm:"default:null" example:"140"`
 	Note            string          `json:"note" gorm:"default:null" example:"This is a note"`
-	CreatedAt       time.Time       `json:"created_at" example:"2021-01-01T00:00:00Z"`
-	UpdatedAt       time.Time       `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+	// Source tells where an effective threshold came from, it is not stored
+	Source    string    `json:"source,omitempty" gorm:"-" example:"Patient"`
+	CreatedAt time.Time `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2021-01-01T00:00:00Z"`
 }
 
 type DeviceType string
@@ -37,8 +39,33 @@ const (
 	Diastolic MeasurementType = "Diastolic"
 	Pulse     MeasurementType = "Pulse"
 	Weight    MeasurementType = "Weight"
+	// Blood glucose thresholds are kept in mg/dL and split by meal context
+	FastingGlucose  MeasurementType = "FastingGlucose"
+	PostMealGlucose MeasurementType = "PostMealGlucose"
+	// IrregularHeartBeat is a flag rather than a value, it is only evaluated by trend rules
+	IrregularHeartBeat MeasurementType = "IrregularHeartBeat"
 )
 
+// Validate checks the limits of the threshold are ordered
+func (t AlertThreshold) Validate() error {
+	return ValidateThresholdLimits(t.CriticalLow, t.WarningLow, t.WarningHigh, t.CriticalHigh)
+}
+
+// Classify returns the alert level of value against the threshold
+func (t AlertThreshold) Classify(value uint) AlertType {
+	if (t.CriticalLow != nil && value < *t.CriticalLow) ||
+		(t.CriticalHigh != nil && value > *t.CriticalHigh) {
+		return AlertCritical
+	}
+
+	if (t.WarningLow != nil && value < *t.WarningLow) ||
+		(t.WarningHigh != nil && value > *t.WarningHigh) {
+		return AlertWarning
+	}
+
+	return AlertOk
+}
+
 func ListAlertThresholds(userID []uint) ([]AlertThreshold, error) {
 	var alertThresholds []AlertThreshold
 	if err := database.DB.Where("patient_id in (?)", userID).Find(&alertThresholds).Error; err != nil {
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+type AlertTrendRule struct {
+	ID              uint            `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	PatientID       uint            `json:"patient_id" gorm:"index:,unique,composite:rule; not null" example:"1"`
+	Patient         *User           `json:"patient,omitempty" gorm:"foreignKey:PatientID"`
+	DeviceType      DeviceType      `json:"device_type" gorm:"index:,unique,composite:rule; not null" example:"WeightScale"`
+	MeasurementType MeasurementType `json:"measurement_type" gorm:"index:,unique,composite:rule; not null" example:"Weight"`
+	RuleType        TrendRuleType   `json:"rule_type" gorm:"index:,unique,composite:rule; not null" example:"Delta"`
+	WindowHours     uint            `json:"window_hours" gorm:"index:,unique,composite:rule; not null" example:"24"`
+	Direction       TrendDirection  `json:"direction" gorm:"not null; default:Increase" example:"Increase"`
+	WarningChange   *uint           `json:"warning_change" gorm:"default:null" example:"2"`
+	CriticalChange  *uint           `json:"critical_change" gorm:"default:null" example:"3"`
+	Note            string          `json:"note" gorm:"default:null" example:"CHF weight gain"`
+	CreatedAt       time.Time       `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt       time.Time       `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+type TrendRuleType string
+
+const (
+	// TrendDelta compares the reading against the most extreme reading in the window
+	TrendDelta TrendRuleType = "Delta"
+	// TrendRollingAverage compares the reading against the average of the window
+	TrendRollingAverage TrendRuleType = "RollingAverage"
+	// TrendOccurrenceCount counts the flagged readings in the window, including the current one
+	TrendOccurrenceCount TrendRuleType = "OccurrenceCount"
+)
+
+type TrendDirection string
+
+const (
+	TrendIncrease TrendDirection = "Increase"
+	TrendDecrease TrendDirection = "Decrease"
+	TrendAny      TrendDirection = "Any"
+)
+
+// AlertNotApplicable is what Evaluate returns when the reading can't be judged by the
+// rule, it is neither out of range nor normal and is never stored
+var AlertNotApplicable AlertType
+
+// Window returns the look-back window of the rule
+func (r AlertTrendRule) Window() time.Duration {
+	return time.Duration(r.WindowHours) * time.Hour
+}
+
+// Evaluate compares the current reading against the patient's earlier readings in the
+// rule window. It returns the alert level, the signed change that was measured and the
+// readings the change was measured against. Readings without the rule's measurement and
+// Delta or RollingAverage rules without earlier readings are AlertNotApplicable.
+func (r AlertTrendRule) Evaluate(current DeviceTelemetryData, history []DeviceTelemetryData) (AlertType, float64, []DeviceTelemetryData) {
+	value, ok := current.MeasurementValue(r.MeasurementType)
+	if !ok {
+		return AlertNotApplicable, 0, nil
+	}
+
+	var comparison []DeviceTelemetryData
+	var values []float64
+	for _, h := range history {
+		if v, ok := h.MeasurementValue(r.MeasurementType); ok {
+			comparison = append(comparison, h)
+			values = append(values, float64(v))
+		}
+	}
+
+	if len(values) == 0 && r.RuleType != TrendOccurrenceCount {
+		return AlertNotApplicable, 0, nil
+	}
+
+	var change float64
+
+	switch r.RuleType {
+	case TrendDelta:
+		// Compare against the reading that gives the largest change in the rule direction
+		best := 0
+		for i := range values {
+			if r.directionalChange(float64(value)-values[i]) > r.directionalChange(float64(value)-values[best]) {
+				best = i
+			}
+		}
+		change = float64(value) - values[best]
+		comparison = []DeviceTelemetryData{comparison[best]}
+	case TrendRollingAverage:
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		change = float64(value) - sum/float64(len(values))
+	case TrendOccurrenceCount:
+		change = float64(len(values) + 1)
+	default:
+		return AlertNotApplicable, 0, nil
+	}
+
+	magnitude := change
+	if r.RuleType != TrendOccurrenceCount {
+		magnitude = r.directionalChange(change)
+	}
+
+	if r.CriticalChange != nil && magnitude >= float64(*r.CriticalChange) {
+		return AlertCritical, change, comparison
+	}
+
+	if r.WarningChange != nil && magnitude >= float64(*r.WarningChange) {
+		return AlertWarning, change, comparison
+	}
+
+	return AlertOk, change, comparison
+}
+
+// directionalChange returns how far change moves in the direction the rule watches,
+// changes in the opposite direction count as zero
+func (r AlertTrendRule) directionalChange(change float64) float64 {
+	switch r.Direction {
+	case TrendDecrease:
+		change = -change
+	case TrendAny:
+		if change < 0 {
+			change = -change
+		}
+	}
+
+	if change < 0 {
+		return 0
+	}
+	return change
+}
+
+func ListAlertTrendRules(userID []uint) ([]AlertTrendRule, error) {
+	var alertTrendRules []AlertTrendRule
+	if err := database.DB.Where("patient_id in (?)", userID).Find(&alertTrendRules).Error; err != nil {
+		return nil, err
+	}
+
+	return alertTrendRules, nil
+}
+
+func UpsertAlertTrendRules(alertTrendRules []AlertTrendRule) error {
+	db := database.DB.Model(&AlertTrendRule{})
+	// Conflict with PatientID, DeviceType, MeasurementType, RuleType, WindowHours then update all
+	db = db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "patient_id"}, {Name: "device_type"}, {Name: "measurement_type"}, {Name: "rule_type"}, {Name: "window_hours"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"direction",
+			"warning_change",
+			"critical_change",
+			"note",
+		}),
+	})
+	if err := db.Create(&alertTrendRules).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeleteAlertTrendRule(patientID, ruleID uint) error {
+	db := database.DB.Where("patient_id = ? AND id = ?", patientID, ruleID)
+	if err := db.Delete(&AlertTrendRule{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"testing"
+)
+
+func changeLimit(v uint) *uint {
+	return &v
+}
+
+func weights(values ...uint) []DeviceTelemetryData {
+	readings := make([]DeviceTelemetryData, 0, len(values))
+	for _, v := range values {
+		readings = append(readings, DeviceTelemetryData{Weight: v, WeightStableTime: 1})
+	}
+	return readings
+}
+
+func TestAlertTrendRuleEvaluate(t *testing.T) {
+	delta := AlertTrendRule{
+		MeasurementType: Weight,
+		RuleType:        TrendDelta,
+		Direction:       TrendIncrease,
+		WarningChange:   changeLimit(2),
+		CriticalChange:  changeLimit(3),
+	}
+
+	decrease := delta
+	decrease.Direction = TrendAny
+
+	average := delta
+	average.RuleType = TrendRollingAverage
+
+	occurrences := AlertTrendRule{
+		MeasurementType: IrregularHeartBeat,
+		RuleType:        TrendOccurrenceCount,
+		WarningChange:   changeLimit(2),
+		CriticalChange:  changeLimit(3),
+	}
+
+	fasting := AlertTrendRule{
+		MeasurementType: FastingGlucose,
+		RuleType:        TrendDelta,
+		Direction:       TrendIncrease,
+		WarningChange:   changeLimit(20),
+	}
+
+	irregular := DeviceTelemetryData{IrregularHeartBeat: true}
+
+	tests := []struct {
+		name       string
+		rule       AlertTrendRule
+		current    DeviceTelemetryData
+		history    []DeviceTelemetryData
+		want       AlertType
+		wantChange float64
+	}{
+		{"delta warning", delta, weights(182)[0], weights(180), AlertWarning, 2},
+		{"delta compares to the lowest reading", delta, weights(184)[0], weights(182, 180, 183), AlertCritical, 4},
+		{"delta below the warning change", delta, weights(181)[0], weights(180), AlertOk, 1},
+		{"delta in the other direction", delta, weights(176)[0], weights(180), AlertOk, -4},
+		{"delta in any direction", decrease, weights(176)[0], weights(180), AlertCritical, -4},
+		{"rolling average", average, weights(184)[0], weights(180, 182), AlertCritical, 3},
+		{"rolling average below the warning change", average, weights(182)[0], weights(180, 182), AlertOk, 1},
+		{"occurrences count the current reading", occurrences, irregular, []DeviceTelemetryData{irregular}, AlertWarning, 2},
+		{"occurrences critical", occurrences, irregular, []DeviceTelemetryData{irregular, irregular}, AlertCritical, 3},
+		{"occurrences without history", occurrences, irregular, nil, AlertOk, 1},
+		{"reading without the measurement", delta, DeviceTelemetryData{SystolicBP: 120, DiastolicBP: 80}, weights(180), AlertNotApplicable, 0},
+		{"delta without history", delta, weights(182)[0], nil, AlertNotApplicable, 0},
+		{"history without the measurement", delta, weights(182)[0], []DeviceTelemetryData{{SystolicBP: 120}}, AlertNotApplicable, 0},
+		{"post meal glucose against a fasting rule", fasting, DeviceTelemetryData{BloodGlucose: 180, SampleType: SampleTypeBlood, Meal: MealAfter}, []DeviceTelemetryData{{BloodGlucose: 100, SampleType: SampleTypeBlood, Meal: MealBefore}}, AlertNotApplicable, 0},
+		{"control solution against a glucose rule", fasting, DeviceTelemetryData{BloodGlucose: 180, SampleType: SampleTypeControl, Meal: MealBefore}, []DeviceTelemetryData{{BloodGlucose: 100, SampleType: SampleTypeBlood, Meal: MealBefore}}, AlertNotApplicable, 0},
+		{"fasting glucose rise", fasting, DeviceTelemetryData{BloodGlucose: 130, SampleType: SampleTypeBlood, Meal: MealBefore}, []DeviceTelemetryData{{BloodGlucose: 100, SampleType: SampleTypeBlood, Meal: MealBefore}}, AlertWarning, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, change, _ := tt.rule.Evaluate(tt.current, tt.history)
+			if got != tt.want || change != tt.wantChange {
+				t.Errorf("got %q with change %v, want %q with change %v", got, change, tt.want, tt.wantChange)
+			}
+		})
+	}
+}
+
+func TestAlertTrendRuleEvaluateComparison(t *testing.T) {
+	rule := AlertTrendRule{
+		MeasurementType: Weight,
+		RuleType:        TrendDelta,
+		Direction:       TrendIncrease,
+		WarningChange:   changeLimit(2),
+	}
+
+	history := weights(182, 180, 183)
+	for i := range history {
+		history[i].ID = uint(i + 1)
+	}
+
+	_, _, comparison := rule.Evaluate(weights(184)[0], history)
+	if len(comparison) != 1 || comparison[0].ID != 2 {
+		t.Errorf("got comparison %+v, want only the 180 reading", comparison)
+	}
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// APIKey lets a machine client of an organization call the API as one of the organization's
+// users with a bearer token, limited to its scopes. Only the token's hash is stored, the
+// token is shown once when the key is created.
+type APIKey struct {
+	ID             uint   `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	OrganizationID uint   `json:"organization_id" gorm:"index; not null" example:"1"`
+	Name           string `json:"name" gorm:"not null" example:"EHR integration"`
+	// Prefix is the start of the token, enough to tell keys apart
+	Prefix string `json:"prefix" gorm:"type:varchar(16); not null" example:"mk_3fZq9Lw2"`
+	Hash   string `json:"-" gorm:"type:varchar(64); uniqueIndex; not null"`
+	// Scopes is a comma separated list of APIKeyScopes
+	Scopes      string     `json:"scopes" gorm:"not null" example:"user:read,device:read"`
+	UserID      uint       `json:"user_id" gorm:"not null" example:"1"`
+	User        *User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	CreatedByID uint       `json:"created_by_id" gorm:"not null" example:"1"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" example:"2022-01-01T00:00:00Z"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty" example:"2021-06-01T00:00:00Z"`
+	LastUsedIP  string     `json:"last_used_ip,omitempty" gorm:"default:null" example:"203.0.113.7"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty" example:"2021-07-01T00:00:00Z"`
+	RevokedByID *uint      `json:"revoked_by_id,omitempty" example:"1"`
+	CreatedAt   time.Time  `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt   time.Time  `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// APIKeyScopes are what a key may be granted, an area of the API with read or write access.
+// Write access includes read access.
+var APIKeyScopes = []string{
+	"user:read", "user:write",
+	"organization:read", "organization:write",
+	"device:read", "device:write",
+	"interaction:read", "interaction:write",
+	"careplan:read", "careplan:write",
+}
+
+const (
+	apiKeyTokenPrefix  = "mk_"
+	apiKeyPrefixLength = len(apiKeyTokenPrefix) + 8
+	// lastUsedInterval keeps busy keys from writing on every request
+	lastUsedInterval = time.Minute
+)
+
+// CreateAPIKey stores the key and returns its token
+func (k *APIKey) CreateAPIKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	token := apiKeyTokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	k.Prefix = token[:apiKeyPrefixLength]
+	k.Hash = hashAPIKeyToken(token)
+
+	if err := database.DB.Omit("User").Create(&k).Error; err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// AuthenticateAPIKey returns the key of the token if it is neither revoked nor expired
+func AuthenticateAPIKey(token string) (*APIKey, error) {
+	var key APIKey
+
+	db := database.DB.Where("hash = ?", hashAPIKeyToken(token))
+	db = db.Where("revoked_at IS NULL")
+	db = db.Where("(expires_at IS NULL OR expires_at > ?)", time.Now())
+	if err := db.First(&key).Error; err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// Allows reports whether the key's scopes cover the access to the area
+func (k APIKey) Allows(area string, write bool) bool {
+	for _, scope := range strings.Split(k.Scopes, ",") {
+		if scope == area+":write" || (!write && scope == area+":read") {
+			return true
+		}
+	}
+	return false
+}
+
+// Touch records the key's use
+func (k *APIKey) Touch(ip string) error {
+	now := time.Now()
+	if k.LastUsedAt != nil && now.Sub(*k.LastUsedAt) < lastUsedInterval {
+		return nil
+	}
+
+	k.LastUsedAt = &now
+	k.LastUsedIP = ip
+	return database.DB.Model(&APIKey{}).Where("id = ?", k.ID).UpdateColumns(map[string]interface{}{
+		"last_used_at": now,
+		"last_used_ip": ip,
+	}).Error
+}
+
+func (k *APIKey) GetAPIKey() error {
+	if err := database.DB.Where("id = ?", k.ID).First(&k).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// RevokeAPIKey stops the key from authenticating
+func (k *APIKey) RevokeAPIKey(revokedByID uint) error {
+	now := time.Now()
+	k.RevokedAt = &now
+	k.RevokedByID = &revokedByID
+
+	if err := database.DB.Omit("User").Save(&k).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListAPIKeys returns the organization's keys with the user they act as, newest first
+func ListAPIKeys(organizationID uint) ([]APIKey, error) {
+	var keys []APIKey
+
+	db := database.DB.Preload("User")
+	db = db.Where("organization_id = ?", organizationID)
+	if err := db.Order("id desc").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range keys {
+		if keys[i].User != nil {
+			keys[i].User.SanitizeUser()
+		}
+	}
+
+	return keys, nil
+}
+
+func hashAPIKeyToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,258 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ClinicalSummary is the one page monthly RPM summary of a patient that a provider reviews and
+// signs off. The PDF is kept in the s3 bucket, regenerating a month replaces it.
+type ClinicalSummary struct {
+	ID             uint                  `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	PatientID      uint                  `json:"patient_id" gorm:"index:,unique,composite:patient_period; not null" example:"1"`
+	Patient        *User                 `json:"patient,omitempty" gorm:"foreignKey:PatientID"`
+	OrganizationID uint                  `json:"organization_id" gorm:"index; not null" example:"1"`
+	Period         string                `json:"period" gorm:"type:varchar(7); index:,unique,composite:patient_period; not null" example:"2021-01"`
+	Status         ClinicalSummaryStatus `json:"status" gorm:"not null" example:"Generated"`
+	Error          string                `json:"error,omitempty" gorm:"default:null" example:"failed to upload the report"`
+	FileKey        string                `json:"-" gorm:"default:null"`
+	// The headline numbers of the report, so lists don't need the PDF
+	ReadingDays       int        `json:"reading_days" example:"18"`
+	ManagementMinutes int        `json:"management_minutes" example:"42"`
+	AlertCount        int        `json:"alert_count" example:"3"`
+	InteractionCount  int        `json:"interaction_count" example:"2"`
+	GeneratedByID     *uint      `json:"generated_by_id,omitempty" example:"1"`
+	GeneratedAt       *time.Time `json:"generated_at,omitempty" example:"2021-02-01T06:00:00Z"`
+	SignedByID        *uint      `json:"signed_by_id,omitempty" example:"1"`
+	SignedBy          *User      `json:"signed_by,omitempty" gorm:"foreignKey:SignedByID"`
+	SignedAt          *time.Time `json:"signed_at,omitempty" example:"2021-02-03T14:00:00Z"`
+	CreatedAt         time.Time  `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt         time.Time  `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+type ClinicalSummaryStatus string
+
+const (
+	ClinicalSummaryPending   ClinicalSummaryStatus = "Pending"
+	ClinicalSummaryGenerated ClinicalSummaryStatus = "Generated"
+	ClinicalSummaryFailed    ClinicalSummaryStatus = "Failed"
+)
+
+// ClinicalSummaryMeasurements are the measurements on the report, in order
+var ClinicalSummaryMeasurements = []MeasurementType{Systolic, Diastolic, Pulse, Weight, FastingGlucose, PostMealGlucose}
+
+// ClinicalSummaryData is what a patient's report for a month is drawn from
+type ClinicalSummaryData struct {
+	Patient User
+	Period  string
+	// Start and End are the month's local midnights in the patient's timezone
+	Start        time.Time
+	End          time.Time
+	Stats        map[MeasurementType]*TelemetryStats
+	ReadingDays  int
+	Alerts       []TelemetryAlert
+	Interactions []Interaction
+	// ManagementSeconds is the time of the month's RPM interactions
+	ManagementSeconds uint
+}
+
+// ParsePeriod returns the first local midnight of a YYYY-MM month
+func ParsePeriod(period string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation("2006-01", period, loc)
+}
+
+// GetClinicalSummaryData collects the patient's readings, alerts and interactions of the month
+func GetClinicalSummaryData(patient User, period string) (*ClinicalSummaryData, error) {
+	loc := GetPatientLocation(*patient.ID)
+
+	start, err := ParsePeriod(period, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &ClinicalSummaryData{
+		Patient: patient,
+		Period:  period,
+		Start:   start,
+		End:     start.AddDate(0, 1, 0),
+		Stats:   make(map[MeasurementType]*TelemetryStats, len(ClinicalSummaryMeasurements)),
+	}
+
+	for _, measurementType := range ClinicalSummaryMeasurements {
+		stats, err := GetTelemetryStats(*patient.ID, measurementType, data.Start, data.End, BucketDay)
+		if err != nil {
+			return nil, err
+		}
+		data.Stats[measurementType] = stats
+	}
+
+	if data.ReadingDays, err = countReadingDays(*patient.ID, data.Start, data.End); err != nil {
+		return nil, err
+	}
+
+	if data.Alerts, err = ListPatientTelemetryAlerts(*patient.ID, data.Start, data.End); err != nil {
+		return nil, err
+	}
+
+	if data.Interactions, err = ListPatientInteractions(*patient.ID, data.Start, data.End); err != nil {
+		return nil, err
+	}
+
+	for _, i := range data.Interactions {
+		if i.CostCategory == "RPM" {
+			data.ManagementSeconds += i.Duration
+		}
+	}
+
+	return data, nil
+}
+
+// countReadingDays counts the local days in [start, end) with a countable device uploaded
+// reading of the patient. Like the stats it reads the archive too, a summary of an old
+// month counts the same days it did when the month was in the table.
+func countReadingDays(patientID uint, start, end time.Time) (int, error) {
+	var measuredAt []time.Time
+	db := database.DB.Model(&DeviceTelemetryData{}).
+		Where("user_id = ?", patientID).
+		Where("quality IN (?)", CountableReadingQualities).
+		Where("measured_at >= ? AND measured_at < ?", start, end).
+		Scopes(DeviceUploadedReadings)
+	if err := db.Pluck("measured_at", &measuredAt).Error; err != nil {
+		return 0, err
+	}
+
+	archived, err := ArchivedTelemetry(patientID, start, end, func(d DeviceTelemetryData) bool {
+		return d.IsCountable() && d.IsDeviceUploaded()
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, d := range archived {
+		measuredAt = append(measuredAt, d.MeasuredAt)
+	}
+
+	days := make(map[string]bool)
+	for _, t := range measuredAt {
+		days[t.In(start.Location()).Format("2006-01-02")] = true
+	}
+
+	return len(days), nil
+}
+
+// PrepareClinicalSummary marks the patient's summary of the month pending, creating it if
+// needed. Regenerating clears an earlier error but never a sign-off, signed summaries are
+// left as they are.
+func (s *ClinicalSummary) PrepareClinicalSummary() error {
+	var existing ClinicalSummary
+	err := database.DB.Where("patient_id = ? AND period = ?", s.PatientID, s.Period).First(&existing).Error
+	if err == gorm.ErrRecordNotFound {
+		s.Status = ClinicalSummaryPending
+		return database.DB.Create(&s).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	if existing.SignedAt == nil {
+		existing.Status = ClinicalSummaryPending
+		existing.Error = ""
+		existing.OrganizationID = s.OrganizationID
+		existing.GeneratedByID = s.GeneratedByID
+		if err := database.DB.Save(&existing).Error; err != nil {
+			return err
+		}
+	}
+
+	*s = existing
+	return nil
+}
+
+func (s *ClinicalSummary) GetClinicalSummary() error {
+	if err := database.DB.Preload("SignedBy").Where("id = ?", s.ID).First(&s).Error; err != nil {
+		return err
+	}
+	if s.SignedBy != nil {
+		s.SignedBy.SanitizeUser()
+	}
+	return nil
+}
+
+func (s *ClinicalSummary) UpdateClinicalSummary() error {
+	if err := database.DB.Omit("Patient", "SignedBy").Save(&s).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// SignClinicalSummary records the provider's sign-off of the report
+func (s *ClinicalSummary) SignClinicalSummary(userID uint) error {
+	now := time.Now()
+	s.SignedByID = &userID
+	s.SignedAt = &now
+
+	return s.UpdateClinicalSummary()
+}
+
+// ListPatientClinicalSummaries returns the patient's summaries, latest month first
+func ListPatientClinicalSummaries(patientID uint) ([]ClinicalSummary, error) {
+	var summaries []ClinicalSummary
+
+	db := database.DB.Preload("SignedBy")
+	db = db.Where("patient_id = ?", patientID)
+	if err := db.Order("period desc").Find(&summaries).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range summaries {
+		if summaries[i].SignedBy != nil {
+			summaries[i].SignedBy.SanitizeUser()
+		}
+	}
+
+	return summaries, nil
+}
+
+// ListOrganizationClinicalSummaries returns the summaries of the organization's patients for
+// the month, with the patient
+func ListOrganizationClinicalSummaries(organizationID uint, period string) ([]ClinicalSummary, error) {
+	var summaries []ClinicalSummary
+
+	db := database.DB.Preload("Patient")
+	db = db.Where("organization_id = ? AND period = ?", organizationID, period)
+	if err := db.Order("id asc").Find(&summaries).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range summaries {
+		if summaries[i].Patient != nil {
+			summaries[i].Patient.SanitizeUser()
+		}
+	}
+
+	return summaries, nil
+}
+
+// ListGeneratedClinicalSummaryPatients returns which of the patients already have a generated
+// summary for the month
+func ListGeneratedClinicalSummaryPatients(patientIDs []uint, period string) (map[uint]bool, error) {
+	generated := make(map[uint]bool)
+	if len(patientIDs) == 0 {
+		return generated, nil
+	}
+
+	var ids []uint
+	db := database.DB.Model(&ClinicalSummary{})
+	db = db.Where("patient_id IN (?) AND period = ?", patientIDs, period)
+	db = db.Where("status = ?", ClinicalSummaryGenerated)
+	if err := db.Pluck("patient_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		generated[id] = true
+	}
+
+	return generated, nil
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DataMigration records a one-time change to existing rows that bootstrap has already run
+type DataMigration struct {
+	Name      string    `json:"name" gorm:"primary_key;not null" example:"remove-diagnosis-threshold-copies"`
+	CreatedAt time.Time `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// RunDataMigration runs migrate unless a migration of that name already ran. The migration
+// is recorded in the same transaction, so a failed run is tried again at the next bootstrap.
+func RunDataMigration(name string, migrate func(tx *gorm.DB) error) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&DataMigration{}).Where("name = ?", name).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := migrate(tx); err != nil {
+			return err
+		}
+
+		return tx.Create(&DataMigration{Name: name}).Error
+	})
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"strconv"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// DeviceAlert is raised by the device health job when a device stops reporting or is about
+// to, and is resolved by the job once the condition clears
+type DeviceAlert struct {
+	ID             uint              `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	DeviceID       uint              `json:"device_id" gorm:"index; not null" example:"1"`
+	Device         *Device           `json:"device,omitempty" gorm:"foreignKey:DeviceID"`
+	PatientID      uint              `json:"patient_id" example:"1"`
+	OrganizationID uint              `json:"organization_id" gorm:"index" example:"1"`
+	Type           DeviceAlertType   `json:"type" gorm:"not null" example:"NoReadings"`
+	Data           datatypes.JSONMap `json:"data" swaggertype:"object"`
+	IsActive       bool              `json:"is_active" gorm:"not null; default:true" example:"true"`
+	ResolvedAt     *time.Time        `json:"resolved_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	CreatedAt      time.Time         `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt      time.Time         `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+type DeviceAlertType string
+
+const (
+	DeviceAlertNoReadings  DeviceAlertType = "NoReadings"
+	DeviceAlertLowBattery  DeviceAlertType = "LowBattery"
+	DeviceAlertWeakSignal  DeviceAlertType = "WeakSignal"
+	DeviceAlertNoHeartbeat DeviceAlertType = "NoHeartbeat"
+)
+
+func (a *DeviceAlert) CreateDeviceAlert() error {
+	if err := database.DB.Create(&a).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateDeviceAlertData refreshes the data of an open alert, e.g. the days without readings
+func (a *DeviceAlert) UpdateDeviceAlertData() error {
+	db := database.DB.Model(&DeviceAlert{}).Where("id = ?", a.ID)
+	if err := db.UpdateColumns(map[string]interface{}{
+		"data":       a.Data,
+		"updated_at": time.Now().UTC(),
+	}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *DeviceAlert) ResolveDeviceAlert() error {
+	db := database.DB.Model(&DeviceAlert{}).Where("id = ? AND is_active = ?", a.ID, true)
+	if err := db.UpdateColumns(map[string]interface{}{
+		"is_active":   false,
+		"resolved_at": time.Now().UTC(),
+	}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListActiveDeviceAlerts returns the open alerts of the devices
+func ListActiveDeviceAlerts(deviceIDs []uint) ([]DeviceAlert, error) {
+	var alerts []DeviceAlert
+	db := database.DB.Where("device_id IN (?) AND is_active = ?", deviceIDs, true)
+	if err := db.Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+// DeviceHealthLimits are an organization's limits for the device health checks
+type DeviceHealthLimits struct {
+	NoReadingDays     int64 `json:"no_reading_days" example:"3"`
+	LowBatteryPercent int64 `json:"low_battery_percent" example:"20"`
+	WeakSignal        int64 `json:"weak_signal" example:"10"`
+	HeartbeatHours    int64 `json:"heartbeat_hours" example:"48"`
+}
+
+func GetDeviceHealthLimits(organizationID uint) DeviceHealthLimits {
+	return DeviceHealthLimits{
+		NoReadingDays:     GetInteractionSettingValue(organizationID, DeviceNoReadingDays, DefaultDeviceNoReadingDays),
+		LowBatteryPercent: GetInteractionSettingValue(organizationID, DeviceLowBatteryPercent, DefaultDeviceLowBatteryPercent),
+		WeakSignal:        GetInteractionSettingValue(organizationID, DeviceWeakSignal, DefaultDeviceWeakSignal),
+		HeartbeatHours:    GetInteractionSettingValue(organizationID, DeviceHeartbeatHours, DefaultDeviceHeartbeatHours),
+	}
+}
+
+// HealthIssues checks the device against the limits and returns the data of every failing
+// check keyed by alert type. The silence checks are measured from the start of the current
+// assignment when the device has not reported since, so a newly assigned device isn't
+// flagged for the time it spent in stock.
+func (d Device) HealthIssues(limits DeviceHealthLimits, assignedAt time.Time, now time.Time) map[DeviceAlertType]datatypes.JSONMap {
+	issues := make(map[DeviceAlertType]datatypes.JSONMap)
+
+	lastReading := assignedAt
+	if d.LastReadingAt != nil && d.LastReadingAt.After(assignedAt) {
+		lastReading = *d.LastReadingAt
+	}
+	if limits.NoReadingDays > 0 && now.Sub(lastReading) > time.Duration(limits.NoReadingDays)*24*time.Hour {
+		issues[DeviceAlertNoReadings] = datatypes.JSONMap{
+			"last_reading_at": d.LastReadingAt,
+			"days":            int(now.Sub(lastReading).Hours() / 24),
+		}
+	}
+
+	// A battery level is only known once the device has sent something
+	if d.LastReadingAt != nil && limits.LowBatteryPercent > 0 && int64(d.BatteryLevel) < limits.LowBatteryPercent {
+		issues[DeviceAlertLowBattery] = datatypes.JSONMap{
+			"battery_level": d.BatteryLevel,
+		}
+	}
+
+	if signal, err := strconv.ParseInt(d.SignalStrength, 10, 64); err == nil && limits.WeakSignal > 0 && signal < limits.WeakSignal {
+		issues[DeviceAlertWeakSignal] = datatypes.JSONMap{
+			"signal": signal,
+		}
+	}
+
+	lastStatus := assignedAt
+	if d.LastStatusAt != nil && d.LastStatusAt.After(assignedAt) {
+		lastStatus = *d.LastStatusAt
+	}
+	if limits.HeartbeatHours > 0 && now.Sub(lastStatus) > time.Duration(limits.HeartbeatHours)*time.Hour {
+		issues[DeviceAlertNoHeartbeat] = datatypes.JSONMap{
+			"last_status_at": d.LastStatusAt,
+			"hours":          int(now.Sub(lastStatus).Hours()),
+		}
+	}
+
+	return issues
+}
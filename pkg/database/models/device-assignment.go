@@ -0,0 +1,230 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type DeviceLifecycle string
+
+const (
+	DeviceInStock   DeviceLifecycle = "InStock"
+	DeviceShipped   DeviceLifecycle = "Shipped"
+	DeviceDelivered DeviceLifecycle = "Delivered"
+	DeviceActive    DeviceLifecycle = "Active"
+	DeviceReturned  DeviceLifecycle = "Returned"
+	DeviceLost      DeviceLifecycle = "Lost"
+	DeviceRetired   DeviceLifecycle = "Retired"
+)
+
+// IsAssignable reports whether a device in this state can be handed to a patient
+func (s DeviceLifecycle) IsAssignable() bool {
+	return s != DeviceLost && s != DeviceRetired
+}
+
+// DeviceAssignment records which patient held a device and for how long. An assignment
+// without an end time is the current one.
+type DeviceAssignment struct {
+	ID           uint       `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	DeviceID     uint       `json:"device_id" gorm:"not null;index" example:"1"`
+	UserID       uint       `json:"user_id" gorm:"not null;index" example:"1"`
+	User         User       `json:"user" gorm:"foreignKey:UserID"`
+	StartedAt    time.Time  `json:"started_at" gorm:"not null" example:"2021-01-01T00:00:00Z"`
+	EndedAt      *time.Time `json:"ended_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	AssignedByID *uint      `json:"assigned_by_id,omitempty" example:"1"`
+	CreatedAt    time.Time  `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt    time.Time  `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+func ListDeviceAssignments(deviceID uint) ([]DeviceAssignment, error) {
+	var assignments []DeviceAssignment
+
+	db := database.DB.Model(&DeviceAssignment{}).Preload("User")
+	db = db.Where("device_id = ?", deviceID)
+
+	if err := db.Order("started_at desc").Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+// AssignedPatientAt returns the patient that held the device at the given time. Devices
+// assigned before assignment history was kept fall back to their current patient.
+func (d *Device) AssignedPatientAt(t time.Time) (uint, error) {
+	var assignment DeviceAssignment
+
+	db := database.DB.Model(&DeviceAssignment{}).Where("device_id = ?", d.ID)
+	db = db.Where("started_at <= ?", t)
+	db = db.Where("ended_at IS NULL OR ended_at > ?", t)
+
+	err := db.Order("started_at desc").First(&assignment).Error
+	if err == nil {
+		return assignment.UserID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	var count int64
+	if err := database.DB.Model(&DeviceAssignment{}).Where("device_id = ?", d.ID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return d.UserID, nil
+	}
+
+	// The device has a history but nobody held it at that time
+	return 0, nil
+}
+
+// BackfillDeviceStatus marks the devices that were assigned before devices had a lifecycle
+// status as Active, the status column was added with InStock for every row
+func BackfillDeviceStatus() error {
+	db := database.DB.Model(&Device{}).Where("user_id IS NOT NULL AND status = ?", DeviceInStock)
+	return db.UpdateColumn("status", DeviceActive).Error
+}
+
+// legacyPoolUserID is the user the Mio sync attached new devices to before devices had an
+// unassigned pool
+const legacyPoolUserID = 2
+
+// BackfillDevicePool moves the devices the sync attached to the legacy placeholder user into
+// the unassigned pool. Devices that were assigned through the assignment history are kept,
+// and nothing is moved when the placeholder is a real patient.
+func BackfillDevicePool() error {
+	var placeholder User
+	err := database.DB.Where("id = ?", legacyPoolUserID).First(&placeholder).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if placeholder.Role == "patient" || placeholder.Role == "patientnv" {
+		return nil
+	}
+
+	assigned := database.DB.Model(&DeviceAssignment{}).Select("device_id")
+
+	db := database.DB.Model(&Device{}).Where("user_id = ?", legacyPoolUserID)
+	db = db.Where("id NOT IN (?)", assigned)
+	return db.UpdateColumns(map[string]interface{}{
+		"user_id": nil,
+		"status":  DeviceInStock,
+	}).Error
+}
+
+// ListAssignmentStarts returns when the current assignment of each device started. Devices
+// assigned before assignment history was kept are missing from the result.
+func ListAssignmentStarts(deviceIDs []uint) (map[uint]time.Time, error) {
+	var assignments []DeviceAssignment
+
+	db := database.DB.Model(&DeviceAssignment{}).Select("device_id, started_at")
+	db = db.Where("device_id IN (?) AND ended_at IS NULL", deviceIDs)
+	if err := db.Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	starts := make(map[uint]time.Time, len(assignments))
+	for _, a := range assignments {
+		if a.StartedAt.After(starts[a.DeviceID]) {
+			starts[a.DeviceID] = a.StartedAt
+		}
+	}
+
+	return starts, nil
+}
+
+// closeDeviceAssignment ends the open assignment of a device. A device that was assigned
+// before history was kept gets a record for its current patient so the handover is not lost.
+func closeDeviceAssignment(tx *gorm.DB, device *Device, endedAt time.Time) error {
+	result := tx.Model(&DeviceAssignment{}).
+		Where("device_id = ? AND ended_at IS NULL", device.ID).
+		Update("ended_at", endedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 || device.UserID == 0 {
+		return nil
+	}
+
+	var count int64
+	if err := tx.Model(&DeviceAssignment{}).Where("device_id = ?", device.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	return tx.Create(&DeviceAssignment{
+		DeviceID:  device.ID,
+		UserID:    device.UserID,
+		StartedAt: device.CreatedAt,
+		EndedAt:   &endedAt,
+	}).Error
+}
+
+// AssignPatient ends the current assignment of the device, if any, and starts a new one
+// for the patient
+func (d *Device) AssignPatient(userID uint, assignedByID *uint) error {
+	now := time.Now()
+
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := closeDeviceAssignment(tx, d, now); err != nil {
+			return err
+		}
+
+		assignment := DeviceAssignment{
+			DeviceID:     d.ID,
+			UserID:       userID,
+			StartedAt:    now,
+			AssignedByID: assignedByID,
+		}
+		if err := tx.Create(&assignment).Error; err != nil {
+			return err
+		}
+
+		d.UserID = userID
+		d.Status = DeviceActive
+		return tx.Model(&Device{}).Where("id = ?", d.ID).UpdateColumns(map[string]interface{}{
+			"user_id": userID,
+			"status":  DeviceActive,
+		}).Error
+	})
+}
+
+// UnassignPatient ends the current assignment of the device and moves it to the given state
+func (d *Device) UnassignPatient(status DeviceLifecycle) error {
+	now := time.Now()
+
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := closeDeviceAssignment(tx, d, now); err != nil {
+			return err
+		}
+
+		d.UserID = 0
+		d.Status = status
+		return tx.Model(&Device{}).Where("id = ?", d.ID).UpdateColumns(map[string]interface{}{
+			"user_id": nil,
+			"status":  status,
+		}).Error
+	})
+}
+
+// UpdateStatus moves the device to a new lifecycle state. Devices that leave the patient
+// (returned, lost, retired) end their current assignment.
+func (d *Device) UpdateStatus(status DeviceLifecycle) error {
+	if d.UserID != 0 && (status == DeviceReturned || !status.IsAssignable()) {
+		return d.UnassignPatient(status)
+	}
+
+	if err := database.DB.Model(&Device{}).Where("id = ?", d.ID).Update("status", status).Error; err != nil {
+		return err
+	}
+	d.Status = status
+	return nil
+}
@@ -20,6 +20,24 @@ type DeviceLogData struct {
 	UpdatedAt       time.Time `json:"updated_at" example:"2021-01-01T00:00:00Z"`
 }
 
+// FirmwareVersion is the version the device's firmware goes by, the app version when the
+// device reports one and the MCU version otherwise
+func (d DeviceLogData) FirmwareVersion() string {
+	if d.AppVersion != "" {
+		return d.AppVersion
+	}
+	return d.MCUVersion
+}
+
+// SameVersions reports whether both logs report the same hardware and software versions
+func (d DeviceLogData) SameVersions(other DeviceLogData) bool {
+	return d.HardwareVersion == other.HardwareVersion &&
+		d.MCUVersion == other.MCUVersion &&
+		d.AppVersion == other.AppVersion &&
+		d.ModemVersion == other.ModemVersion &&
+		d.BPMAlgo == other.BPMAlgo
+}
+
 func (d *DeviceLogData) CreateDeviceLogData() error {
 	if err := database.DB.Create(&d).Error; err != nil {
 		return err
@@ -36,15 +54,26 @@ func GetDeviceLogData() ([]DeviceLogData, error) {
 	return deviceLogData, nil
 }
 
+// GetDeviceLogDataByDevice returns the logs of the device, newest first
 func GetDeviceLogDataByDevice(deviceId uint) ([]DeviceLogData, error) {
 	var deviceLogData []DeviceLogData
-	if err := database.DB.Where("device_id = ?", deviceId).Find(&deviceLogData).Error; err != nil {
+	if err := database.DB.Where("device_id = ?", deviceId).Order("id desc").Find(&deviceLogData).Error; err != nil {
 		return nil, err
 	}
 
 	return deviceLogData, nil
 }
 
+// GetLatestDeviceLogData returns the newest log of the device
+func GetLatestDeviceLogData(deviceId uint) (DeviceLogData, error) {
+	var deviceLogData DeviceLogData
+	if err := database.DB.Where("device_id = ?", deviceId).Order("id desc").First(&deviceLogData).Error; err != nil {
+		return DeviceLogData{}, err
+	}
+
+	return deviceLogData, nil
+}
+
 func (d *DeviceLogData) GetDeviceLogData() error {
 	if err := database.DB.Where("id = ?", d.ID).First(&d).Error; err != nil {
 		return err
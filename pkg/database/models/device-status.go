@@ -2,6 +2,7 @@ package models
 
 import (
 	"MedKick-backend/pkg/database"
+	"sort"
 	"time"
 )
 
@@ -11,14 +12,15 @@ type DeviceStatusData struct {
 	Timezone      string    `json:"timezone" gorm:"null" example:"UTC+6"`
 	NetworkOps    string    `json:"network_ops" gorm:"null" example:"T-Mobile;Verizon"`
 	NetworkFormat string    `json:"network_format" gorm:"null" example:"GSM;eMTC;NB-IoT"`
+	Battery       uint      `json:"battery" gorm:"null" example:"90"`
 	Signal        uint      `json:"signal" gorm:"null" example:"100"`
 	Temperature   int       `json:"temperature" gorm:"null" example:"100"`
 	MeasureCount  uint      `json:"measure_count" gorm:"null" example:"100"`
 	AttachTime    time.Time `json:"attach_time" gorm:"null" example:"100"`
 
-	DeviceID  uint      `json:"device_id" gorm:"not null" example:"1"`
+	DeviceID  uint      `json:"device_id" gorm:"index:,composite:device_time; not null" example:"1"`
 	Device    Device    `json:"device" gorm:"foreignKey:DeviceID"`
-	CreatedAt time.Time `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	CreatedAt time.Time `json:"created_at" gorm:"index:,composite:device_time" example:"2021-01-01T00:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" example:"2021-01-01T00:00:00Z"`
 }
 
@@ -47,13 +49,27 @@ func GetDeviceStatusDataByDevice(deviceId uint) ([]DeviceStatusData, error) {
 	return deviceStatusData, nil
 }
 
+// GetDeviceStatusDataByDeviceBetweenDates returns the device's status reports received in
+// [startDate, endDate], oldest first
 func GetDeviceStatusDataByDeviceBetweenDates(deviceId uint, startDate, endDate time.Time) ([]DeviceStatusData, error) {
 	var deviceStatusData []DeviceStatusData
-	if err := database.DB.Where("device_id = ? AND created_at BETWEEN ? AND ?", deviceId, startDate, endDate).Find(&deviceStatusData).Error; err != nil {
+	db := database.DB.Where("device_id = ? AND created_at BETWEEN ? AND ?", deviceId, startDate, endDate)
+	if err := db.Order("created_at asc").Find(&deviceStatusData).Error; err != nil {
 		return nil, err
 	}
 
-	return deviceStatusData, nil
+	archived, err := ArchivedStatus(deviceId, startDate, endDate, func(s DeviceStatusData) bool {
+		return true
+	})
+	if err != nil || len(archived) == 0 {
+		return deviceStatusData, err
+	}
+
+	merged := append(archived, deviceStatusData...)
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].CreatedAt.Before(merged[j].CreatedAt)
+	})
+	return merged, nil
 }
 
 func (d *DeviceStatusData) GetDeviceStatusData() error {
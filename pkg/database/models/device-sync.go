@@ -0,0 +1,126 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"time"
+)
+
+// DeviceModelNames maps Mio model numbers to the name we show for the device
+var DeviceModelNames = map[string]string{
+	"TBM-2092-G": "Sphygmomanometer",
+	"GBS-2104-G": "Weight Scale",
+	"TBM-2282-G": "Blood Glucose Meter",
+}
+
+// DeviceSyncRun is the reconciliation report of one device sync with Mio
+type DeviceSyncRun struct {
+	ID                   uint              `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	Status               DeviceSyncStatus  `json:"status" gorm:"not null" example:"Completed"`
+	Error                string            `json:"error,omitempty" example:"mio: GET /devices responded with status code 503"`
+	UpstreamCount        int               `json:"upstream_count" example:"120"`
+	AddedCount           int               `json:"added_count" example:"2"`
+	UpdatedCount         int               `json:"updated_count" example:"5"`
+	UnchangedCount       int               `json:"unchanged_count" example:"113"`
+	MissingUpstreamCount int               `json:"missing_upstream_count" example:"1"`
+	UnknownModelCount    int               `json:"unknown_model_count" example:"0"`
+	ErrorCount           int               `json:"error_count" example:"0"`
+	Entries              []DeviceSyncEntry `json:"entries,omitempty" gorm:"foreignKey:RunID"`
+	StartedAt            time.Time         `json:"started_at" example:"2021-01-01T00:00:00Z"`
+	FinishedAt           *time.Time        `json:"finished_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	CreatedAt            time.Time         `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt            time.Time         `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// DeviceSyncEntry is one finding of a sync run. Unchanged devices are only counted.
+type DeviceSyncEntry struct {
+	ID          uint             `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	RunID       uint             `json:"run_id" gorm:"index;not null" example:"1"`
+	Result      DeviceSyncResult `json:"result" gorm:"not null" example:"Added"`
+	DeviceID    *uint            `json:"device_id,omitempty" example:"1"`
+	MioDeviceID string           `json:"mio_device_id,omitempty" example:"5f7b1b1b1b1b1b1b1b1b1b1b"`
+	IMEI        string           `json:"imei" example:"123456789"`
+	ModelNumber string           `json:"model_number,omitempty" example:"TBM-2092-G"`
+	Message     string           `json:"message,omitempty" example:"firmware_version 1.0.0 -> 1.0.1"`
+	CreatedAt   time.Time        `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+type DeviceSyncStatus string
+
+const (
+	DeviceSyncRunning             DeviceSyncStatus = "Running"
+	DeviceSyncCompleted           DeviceSyncStatus = "Completed"
+	DeviceSyncCompletedWithErrors DeviceSyncStatus = "CompletedWithErrors"
+	DeviceSyncFailed              DeviceSyncStatus = "Failed"
+)
+
+type DeviceSyncResult string
+
+const (
+	DeviceSyncAdded           DeviceSyncResult = "Added"
+	DeviceSyncUpdated         DeviceSyncResult = "Updated"
+	DeviceSyncMissingUpstream DeviceSyncResult = "MissingUpstream"
+	DeviceSyncUnknownModel    DeviceSyncResult = "UnknownModel"
+	DeviceSyncError           DeviceSyncResult = "Error"
+)
+
+// AddEntry records a finding and counts it
+func (r *DeviceSyncRun) AddEntry(entry DeviceSyncEntry) {
+	switch entry.Result {
+	case DeviceSyncAdded:
+		r.AddedCount++
+	case DeviceSyncUpdated:
+		r.UpdatedCount++
+	case DeviceSyncMissingUpstream:
+		r.MissingUpstreamCount++
+	case DeviceSyncUnknownModel:
+		r.UnknownModelCount++
+	case DeviceSyncError:
+		r.ErrorCount++
+	}
+	r.Entries = append(r.Entries, entry)
+}
+
+func (r *DeviceSyncRun) CreateDeviceSyncRun() error {
+	if err := database.DB.Create(&r).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// FinishDeviceSyncRun stores the counts and findings of the run
+func (r *DeviceSyncRun) FinishDeviceSyncRun() error {
+	now := time.Now()
+	r.FinishedAt = &now
+
+	for i := range r.Entries {
+		r.Entries[i].RunID = r.ID
+	}
+
+	if len(r.Entries) > 0 {
+		if err := database.DB.CreateInBatches(&r.Entries, 100).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := database.DB.Omit("Entries").Save(&r).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *DeviceSyncRun) GetDeviceSyncRun() error {
+	if err := database.DB.Preload("Entries").Where("id = ?", r.ID).First(&r).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListDeviceSyncRuns returns the latest runs without their entries
+func ListDeviceSyncRuns(limit int) ([]DeviceSyncRun, error) {
+	var runs []DeviceSyncRun
+	if err := database.DB.Order("started_at desc").Limit(limit).Find(&runs).Error; err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
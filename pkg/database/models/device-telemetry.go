@@ -2,6 +2,7 @@ package models
 
 import (
 	"MedKick-backend/pkg/database"
+	"math"
 	"time"
 )
 
@@ -25,17 +26,162 @@ type DeviceTelemetryData struct {
 	SampleType   string `json:"sample_type" gorm:"null" example:"1. Blood or Resistance; 2. Quality Control Liquid; 3. Sample is invalid"`
 	Meal         string `json:"meal" gorm:"null" example:"1. Before Meal; 2. After Meal"`
 
-	UserID     uint      `json:"user_id" example:"1"`
+	// Quality is computed at ingest, only Valid and Questionable readings are counted
+	Quality         ReadingQuality `json:"quality" gorm:"not null; default:'Valid'; index" example:"Valid"`
+	QualityFlags    string         `json:"quality_flags,omitempty" gorm:"default:null" example:"HandShaking"`
+	InvalidatedByID *uint          `json:"invalidated_by_id,omitempty" example:"1"`
+	InvalidatedAt   *time.Time     `json:"invalidated_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	InvalidReason   string         `json:"invalid_reason,omitempty" gorm:"default:null" example:"Cuff was on over clothing"`
+
+	// Source tells device uploads from readings entered by hand, EnteredByID is set on the latter
+	Source      ReadingSource `json:"source" gorm:"not null; default:'Device'; index" example:"Device"`
+	EnteredByID *uint         `json:"entered_by_id,omitempty" example:"1"`
+	Note        string        `json:"note,omitempty" gorm:"default:null" example:"Taken at the clinic after a 5 minute rest"`
+	// ImportBatchID is set on historical readings imported from another vendor's export
+	ImportBatchID *uint `json:"import_batch_id,omitempty" gorm:"index" example:"1"`
+
+	// FirmwareVersion is the firmware the device ran when it uploaded the reading
+	FirmwareVersion string `json:"firmware_version,omitempty" gorm:"default:null; index" example:"1.0.0"`
+
+	// patient_time serves reads of a patient's readings over a date range
+	UserID     uint      `json:"user_id" gorm:"index:,composite:patient_time" example:"1"`
 	User       User      `json:"user" gorm:"foreignKey:UserID"`
-	DeviceID   uint      `json:"device_id" gorm:"not null" example:"1"`
+	DeviceID   uint      `json:"device_id" gorm:"default:null" example:"1"`
 	Device     Device    `json:"device" gorm:"foreignKey:DeviceID"`
-	MeasuredAt time.Time `json:"measured_at" example:"2021-01-01T00:00:00Z"`
+	MeasuredAt time.Time `json:"measured_at" gorm:"index:,composite:patient_time; index" example:"2021-01-01T00:00:00Z"`
 	CreatedAt  time.Time `json:"created_at" example:"2021-01-01T00:00:00Z"`
 	UpdatedAt  time.Time `json:"updated_at" example:"2021-01-01T00:00:00Z"`
 }
 
+const (
+	GlucoseUnitMmolL = "mmol/L"
+	GlucoseUnitMgDL  = "mg/dL"
+
+	SampleTypeBlood   = "blood or resistance"
+	SampleTypeControl = "quality control liquid"
+	SampleTypeInvalid = "sample is invalid"
+
+	MealBefore = "before meal"
+	MealAfter  = "after meal"
+
+	// mgPerDLPerMmolL converts glucose from mmol/L to mg/dL
+	mgPerDLPerMmolL = 18.016
+)
+
+// GlucoseMgDL converts a blood glucose value in the given unit to the whole mg/dL it is
+// stored in, mmol/L values carry a decimal that the column can't hold
+func GlucoseMgDL(value float64, unit string) uint {
+	if unit == GlucoseUnitMmolL {
+		value *= mgPerDLPerMmolL
+	}
+	return uint(math.Round(value))
+}
+
+// BackfillTruncatedGlucose flags the readings stored in mmol/L before glucose was stored in
+// mg/dL. Their decimals were cut off by the column, so they are left in mmol/L and marked
+// Questionable for review instead of being converted into values that look exact.
+func BackfillTruncatedGlucose() error {
+	return database.DB.Model(&DeviceTelemetryData{}).
+		Where("unit = ? AND quality = ?", GlucoseUnitMmolL, ReadingValid).
+		UpdateColumns(map[string]interface{}{"quality": ReadingQuestionable, "quality_flags": FlagTruncatedGlucose}).Error
+}
+
+// BloodGlucoseMgDL returns the blood glucose reading normalized to mg/dL, readings are
+// stored in mg/dL but those stored before that can still hold whole mmol/L
+func (d DeviceTelemetryData) BloodGlucoseMgDL() uint {
+	if d.Unit == GlucoseUnitMmolL {
+		return uint(math.Round(float64(d.BloodGlucose) * mgPerDLPerMmolL))
+	}
+	return d.BloodGlucose
+}
+
+// IsGlucoseSample reports whether the glucose reading was taken from a blood sample,
+// control solution and invalid samples must never be used for alerting
+func (d DeviceTelemetryData) IsGlucoseSample() bool {
+	return d.SampleType == SampleTypeBlood
+}
+
+// GlucoseMeasurementType returns the threshold to evaluate the reading against,
+// readings with an unknown meal context are held to the fasting threshold
+func (d DeviceTelemetryData) GlucoseMeasurementType() MeasurementType {
+	if d.Meal == MealAfter {
+		return PostMealGlucose
+	}
+	return FastingGlucose
+}
+
+// MeasurementValue returns the value of the given measurement in the reading and
+// whether the reading carries that measurement at all
+func (d DeviceTelemetryData) MeasurementValue(measurementType MeasurementType) (uint, bool) {
+	var value uint
+
+	switch measurementType {
+	case Systolic:
+		value = d.SystolicBP
+	case Diastolic:
+		value = d.DiastolicBP
+	case Pulse:
+		value = d.Pulse
+	case Weight:
+		value = d.Weight
+	case IrregularHeartBeat:
+		if d.IrregularHeartBeat {
+			value = 1
+		}
+	case FastingGlucose, PostMealGlucose:
+		if !d.IsGlucoseSample() || d.GlucoseMeasurementType() != measurementType {
+			return 0, false
+		}
+		value = d.BloodGlucoseMgDL()
+	}
+
+	return value, value > 0
+}
+
+// measurementColumns maps a measurement to the telemetry column it is stored in
+var measurementColumns = map[MeasurementType]string{
+	Systolic:           "systolic_bp",
+	Diastolic:          "diastolic_bp",
+	Pulse:              "pulse",
+	Weight:             "weight",
+	IrregularHeartBeat: "irregular_heart_beat",
+	FastingGlucose:     "blood_glucose",
+	PostMealGlucose:    "blood_glucose",
+}
+
+// measurementColumnValue is the value of the column measurementColumns maps the measurement to
+func (d DeviceTelemetryData) measurementColumnValue(measurementType MeasurementType) uint {
+	switch measurementType {
+	case Systolic:
+		return d.SystolicBP
+	case Diastolic:
+		return d.DiastolicBP
+	case Pulse:
+		return d.Pulse
+	case Weight:
+		return d.Weight
+	case IrregularHeartBeat:
+		if d.IrregularHeartBeat {
+			return 1
+		}
+	case FastingGlucose, PostMealGlucose:
+		return d.BloodGlucose
+	}
+	return 0
+}
+
 func (d *DeviceTelemetryData) CreateDeviceTelemetryData() error {
-	if err := database.DB.Create(&d).Error; err != nil {
+	if d.Quality == "" {
+		d.ComputeQuality()
+	}
+
+	db := database.DB
+	// Manual readings may have no device, leave device_id NULL for the foreign key
+	if d.DeviceID == 0 {
+		db = db.Omit("DeviceID")
+	}
+
+	if err := db.Create(&d).Error; err != nil {
 		return err
 	}
 	return nil
@@ -59,13 +205,61 @@ func GetDeviceTelemetryDataByDevice(deviceId uint) ([]DeviceTelemetryData, error
 	return deviceTelemetryData, nil
 }
 
+// GetDeviceTelemetryDataByDeviceBetweenDates returns the device's readings for the patient in
+// [startDate, endDate). Callers pass local midnights so days follow the patient's timezone.
 func GetDeviceTelemetryDataByDeviceBetweenDates(deviceId, userID uint, startDate, endDate time.Time) ([]DeviceTelemetryData, error) {
 	var deviceTelemetryData []DeviceTelemetryData
-	if err := database.DB.Preload("Device").Where("device_id = ? AND user_id=? AND date(measured_at) BETWEEN ? AND ?", deviceId, userID, startDate, endDate).Find(&deviceTelemetryData).Error; err != nil {
+	if err := database.DB.Preload("Device").Where("device_id = ? AND user_id=? AND measured_at >= ? AND measured_at < ?", deviceId, userID, startDate, endDate).Find(&deviceTelemetryData).Error; err != nil {
 		return nil, err
 	}
 
-	return deviceTelemetryData, nil
+	archived, err := ArchivedTelemetry(userID, startDate, endDate, func(d DeviceTelemetryData) bool {
+		return d.DeviceID == deviceId
+	})
+	if err != nil || len(archived) == 0 {
+		return deviceTelemetryData, err
+	}
+
+	device := Device{ID: deviceId}
+	if err := device.GetDevice(); err != nil {
+		return nil, err
+	}
+	for i := range archived {
+		archived[i].Device = device
+	}
+
+	return mergeReadings(archived, deviceTelemetryData), nil
+}
+
+// ListPatientMeasurementHistory returns the patient's countable readings carrying the
+// measurement in [startDate, endDate), oldest first
+func ListPatientMeasurementHistory(patientID uint, measurementType MeasurementType, startDate, endDate time.Time) ([]DeviceTelemetryData, error) {
+	var deviceTelemetryData []DeviceTelemetryData
+
+	column, ok := measurementColumns[measurementType]
+	if !ok {
+		return deviceTelemetryData, nil
+	}
+
+	db := database.DB.Model(&DeviceTelemetryData{})
+	db = db.Where("user_id = ?", patientID)
+	db = db.Where(column + " > 0")
+	db = db.Where("quality IN (?)", CountableReadingQualities)
+	db = db.Where("measured_at >= ? AND measured_at < ?", startDate, endDate)
+	db = db.Order("measured_at ASC")
+
+	if err := db.Find(&deviceTelemetryData).Error; err != nil {
+		return nil, err
+	}
+
+	archived, err := ArchivedTelemetry(patientID, startDate, endDate, func(d DeviceTelemetryData) bool {
+		return d.IsCountable() && d.measurementColumnValue(measurementType) > 0
+	})
+	if err != nil || len(archived) == 0 {
+		return deviceTelemetryData, err
+	}
+
+	return mergeReadings(archived, deviceTelemetryData), nil
 }
 
 func GetLatestDeviceTelemetryDataByDevice(deviceId uint) (DeviceTelemetryData, error) {
@@ -84,9 +278,14 @@ func (d *DeviceTelemetryData) GetDeviceTelemetryData() error {
 	return nil
 }
 
-func GetNumberOfTelemetryEntriesThisWeek(deviceId uint) (int64, error) {
+// GetNumberOfTelemetryEntriesThisWeek counts the device's countable readings over the last seven days in
+// loc, today included, starting from local midnight six days ago
+func GetNumberOfTelemetryEntriesThisWeek(deviceId uint, loc *time.Location) (int64, error) {
+	now := time.Now()
+	weekStart := StartOfDay(now, loc).AddDate(0, 0, -6)
+
 	var count int64
-	if err := database.DB.Model(&DeviceTelemetryData{}).Where("device_id = ? AND measured_at BETWEEN ? AND ?", deviceId, time.Now().AddDate(0, 0, -7), time.Now()).Count(&count).Error; err != nil {
+	if err := database.DB.Model(&DeviceTelemetryData{}).Where("device_id = ? AND quality IN (?) AND measured_at >= ? AND measured_at <= ?", deviceId, CountableReadingQualities, weekStart, now).Count(&count).Error; err != nil {
 		return 0, err
 	}
 
@@ -169,6 +368,9 @@ func GetLatestPatientTelemetryData(data []DeviceTelemetryDataForPatient) []Devic
 				weightScaleData[d.PatientID] = d
 			}
 		} else if d.DeviceName == "Blood Glucose Meter" {
+			if !d.IsGlucoseSample() {
+				continue
+			}
 			if _, ok := bloodGlucoseMeterData[d.PatientID]; !ok {
 				d.DeviceType = BloodGlucose
 				bloodGlucoseMeterData[d.PatientID] = d
@@ -197,7 +399,9 @@ func GetPatientStatusFunc(thresholds []AlertThreshold) func(data DeviceTelemetry
 
 	systolicBPThresholdMap := make(map[uint]AlertThreshold)
 	diastolicBPThresholdMap := make(map[uint]AlertThreshold)
+	pulseThresholdMap := make(map[uint]AlertThreshold)
 	weightThresholdMap := make(map[uint]AlertThreshold)
+	glucoseThresholdMap := make(map[uint]map[MeasurementType]AlertThreshold)
 
 	for _, t := range thresholds {
 		if t.DeviceType == BloodPressure {
@@ -205,6 +409,8 @@ func GetPatientStatusFunc(thresholds []AlertThreshold) func(data DeviceTelemetry
 				systolicBPThresholdMap[t.PatientID] = t
 			} else if t.MeasurementType == Diastolic {
 				diastolicBPThresholdMap[t.PatientID] = t
+			} else if t.MeasurementType == Pulse {
+				pulseThresholdMap[t.PatientID] = t
 			}
 		}
 		if t.DeviceType == WeightScale {
@@ -212,11 +418,18 @@ func GetPatientStatusFunc(thresholds []AlertThreshold) func(data DeviceTelemetry
 				weightThresholdMap[t.PatientID] = t
 			}
 		}
+		if t.DeviceType == BloodGlucose {
+			if _, ok := glucoseThresholdMap[t.PatientID]; !ok {
+				glucoseThresholdMap[t.PatientID] = make(map[MeasurementType]AlertThreshold)
+			}
+			glucoseThresholdMap[t.PatientID][t.MeasurementType] = t
+		}
 	}
 
 	return func(data DeviceTelemetryDataForPatient) (isCritical, isWarning bool) {
 		systolicBPThreshold := systolicBPThresholdMap[data.PatientID]
 		diastolicBPThreshold := diastolicBPThresholdMap[data.PatientID]
+		pulseThreshold := pulseThresholdMap[data.PatientID]
 		weightThreshold := weightThresholdMap[data.PatientID]
 
 		if data.DeviceType == BloodPressure {
@@ -233,6 +446,15 @@ func GetPatientStatusFunc(thresholds []AlertThreshold) func(data DeviceTelemetry
 				(diastolicBPThreshold.WarningHigh != nil && data.DiastolicBP > *diastolicBPThreshold.WarningHigh) {
 				return false, true
 			}
+
+			if data.Pulse > 0 {
+				switch pulseThreshold.Classify(data.Pulse) {
+				case AlertCritical:
+					return true, false
+				case AlertWarning:
+					return false, true
+				}
+			}
 		}
 
 		if data.DeviceType == WeightScale {
@@ -247,6 +469,17 @@ func GetPatientStatusFunc(thresholds []AlertThreshold) func(data DeviceTelemetry
 			}
 		}
 
+		if data.DeviceType == BloodGlucose && data.IsGlucoseSample() {
+			glucoseThreshold := glucoseThresholdMap[data.PatientID][data.GlucoseMeasurementType()]
+
+			switch glucoseThreshold.Classify(data.BloodGlucoseMgDL()) {
+			case AlertCritical:
+				return true, false
+			case AlertWarning:
+				return false, true
+			}
+		}
+
 		return false, false
 	}
 }
@@ -259,35 +492,49 @@ func (d DeviceTelemetryData) GetStatusByPatientThreshold(deviceType DeviceType,
 		for _, t := range thresholds {
 			if t.DeviceType == BloodPressure {
 				if t.MeasurementType == Systolic {
-					if (t.CriticalLow != nil && d.SystolicBP < *t.CriticalLow) ||
-						(t.CriticalHigh != nil && d.SystolicBP > *t.CriticalHigh) {
-						alertType = AlertCritical
-					}
-
-					if (t.WarningLow != nil && d.SystolicBP < *t.WarningLow) ||
-						(t.WarningHigh != nil && d.SystolicBP > *t.WarningHigh) {
-						if alertType != AlertCritical {
-							alertType = AlertWarning
-						}
-					}
+					alertType = WorseAlertType(alertType, t.Classify(d.SystolicBP))
 				}
 
 				if t.MeasurementType == Diastolic {
-					if (t.CriticalLow != nil && d.DiastolicBP < *t.CriticalLow) ||
-						(t.CriticalHigh != nil && d.DiastolicBP > *t.CriticalHigh) {
-						alertType = AlertCritical
-					}
-
-					if (t.WarningLow != nil && d.DiastolicBP < *t.WarningLow) ||
-						(t.WarningHigh != nil && d.DiastolicBP > *t.WarningHigh) {
-						if alertType != AlertCritical {
-							alertType = AlertWarning
-						}
-					}
+					alertType = WorseAlertType(alertType, t.Classify(d.DiastolicBP))
 				}
 			}
 		}
 	}
 
+	if deviceType == WeightScale {
+		for _, t := range thresholds {
+			if t.DeviceType == WeightScale && t.MeasurementType == Weight {
+				alertType = WorseAlertType(alertType, t.Classify(d.Weight))
+			}
+		}
+	}
+
+	if deviceType == BloodGlucose && d.IsGlucoseSample() {
+		measurementType := d.GlucoseMeasurementType()
+		for _, t := range thresholds {
+			if t.DeviceType == BloodGlucose && t.MeasurementType == measurementType {
+				alertType = WorseAlertType(alertType, t.Classify(d.BloodGlucoseMgDL()))
+			}
+		}
+	}
+
+	return alertType
+}
+
+// GetStatusByMeasurementThreshold returns the alert level of a single measurement in the reading
+func (d DeviceTelemetryData) GetStatusByMeasurementThreshold(deviceType DeviceType, measurementType MeasurementType, thresholds []AlertThreshold) AlertType {
+	value, ok := d.MeasurementValue(measurementType)
+	if !ok {
+		return AlertOk
+	}
+
+	alertType := AlertOk
+	for _, t := range thresholds {
+		if t.DeviceType == deviceType && t.MeasurementType == measurementType {
+			alertType = WorseAlertType(alertType, t.Classify(value))
+		}
+	}
+
 	return alertType
 }
@@ -2,40 +2,52 @@ package models
 
 import (
 	"MedKick-backend/pkg/database"
+	"strconv"
 	"time"
 )
 
 type Device struct {
-	ID                  uint   `json:"id" gorm:"primary_key;auto_increment" example:"1"`
-	Name                string `json:"name" gorm:"not null" example:"Sphygmomanometer/Weight Scale/Blood Glucose Meter"`
-	ModelNumber         string `json:"model_number" gorm:"not null" example:"123456"`
-	IMEI                string `json:"imei" gorm:"not null" example:"123456789"`
-	SerialNumber        string `json:"serial_number" gorm:"not null" example:"123456789"`
-	BatteryLevel        uint   `json:"battery_level" gorm:"not null" example:"100"`
-	SignalStrength      string `json:"signal_strength" gorm:"not null" example:"100"`
-	FirmwareVersion     string `json:"firmware_version" gorm:"not null" example:"1.0.0"`
-	UserID              uint   `json:"user_id" example:"1"`
-	User                User   `json:"user" gorm:"foreignKey:UserID"`
+	ID                  uint            `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	Name                string          `json:"name" gorm:"not null" example:"Sphygmomanometer/Weight Scale/Blood Glucose Meter"`
+	ModelNumber         string          `json:"model_number" gorm:"not null" example:"123456"`
+	IMEI                string          `json:"imei" gorm:"not null" example:"123456789"`
+	SerialNumber        string          `json:"serial_number" gorm:"not null" example:"123456789"`
+	BatteryLevel        uint            `json:"battery_level" gorm:"not null" example:"100"`
+	SignalStrength      string          `json:"signal_strength" gorm:"not null" example:"100"`
+	NetworkOps          string          `json:"network_ops,omitempty" gorm:"null" example:"T-Mobile"`
+	NetworkFormat       string          `json:"network_format,omitempty" gorm:"null" example:"eMTC"`
+	FirmwareVersion     string          `json:"firmware_version" gorm:"not null" example:"1.0.0"`
+	Status              DeviceLifecycle `json:"status" gorm:"not null;default:'InStock'" example:"Active"`
+	UserID              uint            `json:"user_id" example:"1"`
+	User                User            `json:"user" gorm:"foreignKey:UserID"`
 	DeviceTelemetryData []DeviceTelemetryData
-	CreatedAt           time.Time `json:"created_at" example:"2021-01-01T00:00:00Z"`
-	UpdatedAt           time.Time `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+	LastReadingAt       *time.Time `json:"last_reading_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	LastStatusAt        *time.Time `json:"last_status_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	CreatedAt           time.Time  `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt           time.Time  `json:"updated_at" example:"2021-01-01T00:00:00Z"`
 }
 
 type DeviceDTO struct {
-	ID              uint      `json:"id" gorm:"primary_key;auto_increment" example:"1"`
-	Name            string    `json:"name" gorm:"not null" example:"Sphygmomanometer/Weight Scale/Blood Glucose Meter"`
-	ModelNumber     string    `json:"model_number" gorm:"not null" example:"123456"`
-	IMEI            string    `json:"imei" gorm:"not null" example:"123456789"`
-	SerialNumber    string    `json:"serial_number" gorm:"not null" example:"123456789"`
-	BatteryLevel    uint      `json:"battery_level" gorm:"not null" example:"100"`
-	SignalStrength  string    `json:"signal_strength" gorm:"not null" example:"100"`
-	FirmwareVersion string    `json:"firmware_version" gorm:"not null" example:"1.0.0"`
-	CreatedAt       time.Time `json:"created_at" example:"2021-01-01T00:00:00Z"`
-	UpdatedAt       time.Time `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+	ID              uint            `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	Name            string          `json:"name" gorm:"not null" example:"Sphygmomanometer/Weight Scale/Blood Glucose Meter"`
+	ModelNumber     string          `json:"model_number" gorm:"not null" example:"123456"`
+	IMEI            string          `json:"imei" gorm:"not null" example:"123456789"`
+	SerialNumber    string          `json:"serial_number" gorm:"not null" example:"123456789"`
+	BatteryLevel    uint            `json:"battery_level" gorm:"not null" example:"100"`
+	SignalStrength  string          `json:"signal_strength" gorm:"not null" example:"100"`
+	FirmwareVersion string          `json:"firmware_version" gorm:"not null" example:"1.0.0"`
+	Status          DeviceLifecycle `json:"status" example:"InStock"`
+	CreatedAt       time.Time       `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt       time.Time       `json:"updated_at" example:"2021-01-01T00:00:00Z"`
 }
 
 func (d *Device) CreateDevice() error {
-	if err := database.DB.Create(&d).Error; err != nil {
+	db := database.DB
+	// Devices in the unassigned pool have no user, user_id must stay NULL for the foreign key
+	if d.UserID == 0 {
+		db = db.Omit("UserID")
+	}
+	if err := db.Create(&d).Error; err != nil {
 		return err
 	}
 	return nil
@@ -84,19 +96,36 @@ func (d *Device) GetDevice() error {
 }
 
 func (d *Device) UpdateDevice() error {
-	if err := database.DB.Save(&d).Error; err != nil {
+	db := database.DB
+	// Same as CreateDevice, pool devices keep user_id NULL and have no user to save
+	if d.UserID == 0 {
+		db = db.Omit("UserID", "User")
+	}
+	if err := db.Save(&d).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (d *Device) DeleteDevice() error {
-	if err := database.DB.Model(&Device{}).Where("id = ?", d.ID).UpdateColumn("user_id", nil).Error; err != nil {
+// UpdateDeviceInfo saves the details that come from Mio without touching the assignment,
+// status or readings of the device
+func (d *Device) UpdateDeviceInfo() error {
+	if err := database.DB.Model(&Device{}).Where("id = ?", d.ID).Updates(map[string]interface{}{
+		"name":             d.Name,
+		"model_number":     d.ModelNumber,
+		"serial_number":    d.SerialNumber,
+		"firmware_version": d.FirmwareVersion,
+	}).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
+// DeleteDevice takes the device back from its patient, keeping the assignment history
+func (d *Device) DeleteDevice() error {
+	return d.UnassignPatient(DeviceReturned)
+}
+
 func (d *Device) UpdateBattery(batteryLevel uint) error {
 	if err := database.DB.Model(&Device{}).Where("id = ?", d.ID).Update("battery_level", batteryLevel).Error; err != nil {
 		return err
@@ -104,19 +133,78 @@ func (d *Device) UpdateBattery(batteryLevel uint) error {
 	return nil
 }
 
-func (d *Device) GetAvailableDevices() ([]DeviceDTO, error) {
-	var devices []DeviceDTO
+// RecordReading keeps the time of the newest reading and the signal it was sent with
+func (d *Device) RecordReading(measuredAt time.Time, signal uint) error {
+	db := database.DB.Model(&Device{}).Where("id = ?", d.ID)
+	db = db.Where("last_reading_at IS NULL OR last_reading_at < ?", measuredAt)
 
-	if err := database.DB.Table("devices").Where("user_id IS NULL").Find(&devices).Error; err != nil {
-		return nil, err
+	columns := map[string]interface{}{
+		"last_reading_at": measuredAt,
+	}
+	if signal > 0 {
+		columns["signal_strength"] = strconv.FormatUint(uint64(signal), 10)
 	}
 
-	return devices, nil
+	if err := db.UpdateColumns(columns).Error; err != nil {
+		return err
+	}
+	return nil
 }
 
-func (d *Device) AssignDeviceToUser(deviceId uint, userId uint) error {
-	if err := database.DB.Model(&Device{}).Where("id = ?", deviceId).Update("user_id", userId).Error; err != nil {
+// RecordStatus keeps the time of the last status heartbeat and the current battery, signal
+// and network the device reported with it
+func (d *Device) RecordStatus(status DeviceStatusData) error {
+	columns := map[string]interface{}{
+		"last_status_at": status.CreatedAt,
+	}
+	if status.Battery > 0 {
+		columns["battery_level"] = status.Battery
+	}
+	if status.Signal > 0 {
+		columns["signal_strength"] = strconv.FormatUint(uint64(status.Signal), 10)
+	}
+	if status.NetworkOps != "" {
+		columns["network_ops"] = status.NetworkOps
+	}
+	if status.NetworkFormat != "" {
+		columns["network_format"] = status.NetworkFormat
+	}
+
+	if err := database.DB.Model(&Device{}).Where("id = ?", d.ID).UpdateColumns(columns).Error; err != nil {
 		return err
 	}
 	return nil
 }
+
+// RecordFirmware keeps the firmware version the device last reported
+func (d *Device) RecordFirmware(version string) error {
+	if version == "" || version == d.FirmwareVersion {
+		return nil
+	}
+
+	if err := database.DB.Model(&Device{}).Where("id = ?", d.ID).UpdateColumn("firmware_version", version).Error; err != nil {
+		return err
+	}
+	d.FirmwareVersion = version
+	return nil
+}
+
+// ListAssignedDevices returns the devices that are assigned to a patient
+func ListAssignedDevices() ([]Device, error) {
+	var devices []Device
+	if err := database.DB.Preload("User").Where("user_id IS NOT NULL AND user_id <> 0").Find(&devices).Error; err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+func (d *Device) GetAvailableDevices() ([]DeviceDTO, error) {
+	var devices []DeviceDTO
+
+	if err := database.DB.Table("devices").Where("user_id IS NULL").Where("status NOT IN ?", []DeviceLifecycle{DeviceLost, DeviceRetired}).Find(&devices).Error; err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
@@ -25,6 +25,13 @@ func ListDiagnosisCodes() ([]Diagnosis, error) {
 	return codes, nil
 }
 
+func (d *Diagnosis) GetDiagnosisByCode() error {
+	if err := database.DB.Where("code = ?", d.Code).First(&d).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 type PatientDiagnosis struct {
 	UserID      uint      `json:"user_id" gorm:"primaryKey"`
 	Patient     User      `json:"patient" gorm:"foreignKey:UserID"`
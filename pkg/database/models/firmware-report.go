@@ -0,0 +1,134 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"math"
+	"sort"
+	"time"
+)
+
+// FirmwareVersionReport is how many devices of a model run a firmware version and the
+// quality of the readings uploaded with it. Readings uploaded before the firmware was
+// recorded on them have an empty version.
+type FirmwareVersionReport struct {
+	ModelNumber     string `json:"model_number" example:"TBM-2092-G"`
+	DeviceName      string `json:"device_name" example:"Sphygmomanometer"`
+	FirmwareVersion string `json:"firmware_version" example:"1.0.0"`
+	// Devices excludes lost and retired devices, ActiveDevices are with a patient
+	Devices       int `json:"devices" example:"120"`
+	ActiveDevices int `json:"active_devices" example:"98"`
+	Readings      int `json:"readings" example:"2400"`
+	Valid         int `json:"valid" example:"2300"`
+	Questionable  int `json:"questionable" example:"80"`
+	Control       int `json:"control" example:"5"`
+	Invalid       int `json:"invalid" example:"15"`
+	// QuestionableRate and InvalidRate are percentages of the readings
+	QuestionableRate float64 `json:"questionable_rate" example:"3.3"`
+	InvalidRate      float64 `json:"invalid_rate" example:"0.6"`
+}
+
+// GetFirmwareReport reports the deployed firmware versions per device model with the quality
+// of the device uploaded readings measured since the given time. An organization ID of 0
+// reports the whole fleet, otherwise only devices and readings of its patients.
+func GetFirmwareReport(organizationID uint, since time.Time) ([]FirmwareVersionReport, error) {
+	var deviceRows []struct {
+		ModelNumber     string
+		FirmwareVersion string
+		Devices         int
+		ActiveDevices   int
+	}
+
+	db := database.DB.Model(&Device{})
+	db = db.Select("devices.model_number, devices.firmware_version, COUNT(*) AS devices, SUM(CASE WHEN devices.status = ? THEN 1 ELSE 0 END) AS active_devices", DeviceActive)
+	db = db.Where("devices.status NOT IN ?", []DeviceLifecycle{DeviceLost, DeviceRetired})
+	if organizationID != 0 {
+		db = db.Joins("JOIN users ON users.id = devices.user_id")
+		db = db.Where("users.organization_id = ?", organizationID)
+	}
+	db = db.Group("devices.model_number, devices.firmware_version")
+
+	if err := db.Scan(&deviceRows).Error; err != nil {
+		return nil, err
+	}
+
+	var readingRows []struct {
+		ModelNumber     string
+		FirmwareVersion string
+		Quality         ReadingQuality
+		Readings        int
+	}
+
+	db = database.DB.Model(&DeviceTelemetryData{})
+	db = db.Select("devices.model_number, COALESCE(device_telemetry_data.firmware_version, '') AS firmware_version, device_telemetry_data.quality, COUNT(*) AS readings")
+	db = db.Joins("JOIN devices ON devices.id = device_telemetry_data.device_id")
+	db = db.Scopes(DeviceUploadedReadings)
+	db = db.Where("device_telemetry_data.measured_at >= ?", since)
+	if organizationID != 0 {
+		db = db.Joins("JOIN users ON users.id = device_telemetry_data.user_id")
+		db = db.Where("users.organization_id = ?", organizationID)
+	}
+	db = db.Group("devices.model_number, device_telemetry_data.firmware_version, device_telemetry_data.quality")
+
+	if err := db.Scan(&readingRows).Error; err != nil {
+		return nil, err
+	}
+
+	reports := make(map[[2]string]*FirmwareVersionReport)
+	get := func(modelNumber, version string) *FirmwareVersionReport {
+		key := [2]string{modelNumber, version}
+		if r, ok := reports[key]; ok {
+			return r
+		}
+		r := &FirmwareVersionReport{
+			ModelNumber:     modelNumber,
+			DeviceName:      DeviceModelNames[modelNumber],
+			FirmwareVersion: version,
+		}
+		reports[key] = r
+		return r
+	}
+
+	for _, row := range deviceRows {
+		r := get(row.ModelNumber, row.FirmwareVersion)
+		r.Devices += row.Devices
+		r.ActiveDevices += row.ActiveDevices
+	}
+
+	for _, row := range readingRows {
+		r := get(row.ModelNumber, row.FirmwareVersion)
+		r.Readings += row.Readings
+		switch row.Quality {
+		case ReadingValid:
+			r.Valid += row.Readings
+		case ReadingQuestionable:
+			r.Questionable += row.Readings
+		case ReadingControl:
+			r.Control += row.Readings
+		case ReadingInvalid:
+			r.Invalid += row.Readings
+		}
+	}
+
+	report := make([]FirmwareVersionReport, 0, len(reports))
+	for _, r := range reports {
+		if r.Readings > 0 {
+			r.QuestionableRate = percentage(r.Questionable, r.Readings)
+			r.InvalidRate = percentage(r.Invalid, r.Readings)
+		}
+		report = append(report, *r)
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].ModelNumber != report[j].ModelNumber {
+			return report[i].ModelNumber < report[j].ModelNumber
+		}
+		return report[i].FirmwareVersion > report[j].FirmwareVersion
+	})
+
+	return report, nil
+}
+
+// percentage is part of total in percent, rounded to one decimal
+func percentage(part, total int) float64 {
+	return math.Round(float64(part)/float64(total)*1000) / 10
+}
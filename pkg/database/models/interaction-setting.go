@@ -20,8 +20,62 @@ type InteractionSettingType string
 
 const (
 	ColorThreshold InteractionSettingType = "ColorThreshold"
+	// AutoResolveNormalReadings is the number of consecutive normal readings that auto resolve
+	// an open alert, 0 turns auto resolution off
+	AutoResolveNormalReadings InteractionSettingType = "AutoResolveNormalReadings"
+	// AlertAckSLAMinutes and AlertResolveSLAMinutes are the alert triage targets of the SLA report
+	AlertAckSLAMinutes     InteractionSettingType = "AlertAckSLAMinutes"
+	AlertResolveSLAMinutes InteractionSettingType = "AlertResolveSLAMinutes"
+	// Device health limits, the signal is compared in the unit the device reports it
+	DeviceNoReadingDays     InteractionSettingType = "DeviceNoReadingDays"
+	DeviceLowBatteryPercent InteractionSettingType = "DeviceLowBatteryPercent"
+	DeviceWeakSignal        InteractionSettingType = "DeviceWeakSignal"
+	DeviceHeartbeatHours    InteractionSettingType = "DeviceHeartbeatHours"
+	// AdherenceStreakBreakDays is how many days without a reading break a patient's streak,
+	// AdherenceReminders turns patient reading reminders on (1) or off (0)
+	AdherenceStreakBreakDays InteractionSettingType = "AdherenceStreakBreakDays"
+	AdherenceReminders       InteractionSettingType = "AdherenceReminders"
 )
 
+// DefaultAutoResolveNormalReadings is used when the organization has not configured it,
+// alerts are only auto resolved for organizations that opt in
+const DefaultAutoResolveNormalReadings = 0
+
+// Default alert triage targets, used when the organization has not configured them
+const (
+	DefaultAlertAckSLAMinutes     = 30
+	DefaultAlertResolveSLAMinutes = 24 * 60
+)
+
+// Default device health limits, used when the organization has not configured them
+const (
+	DefaultDeviceNoReadingDays     = 3
+	DefaultDeviceLowBatteryPercent = 20
+	DefaultDeviceWeakSignal        = 10
+	DefaultDeviceHeartbeatHours    = 48
+)
+
+// Default reading adherence settings, used when the organization has not configured them
+const (
+	DefaultAdherenceStreakBreakDays = 3
+	DefaultAdherenceReminders       = 0
+)
+
+// GetInteractionSettingValue returns the organization's value for the setting, or the
+// fallback when it is not configured
+func GetInteractionSettingValue(organizationID uint, settingType InteractionSettingType, fallback int64) int64 {
+	setting := InteractionSetting{
+		OrganizationID: organizationID,
+		Type:           settingType,
+	}
+
+	if err := setting.GetInteractionSetting(); err != nil {
+		return fallback
+	}
+
+	return setting.Value
+}
+
 func (i *InteractionSetting) UpsertInteractionSetting() error {
 	db := database.DB.Model(&InteractionSetting{})
 	db = db.Clauses(clause.OnConflict{
@@ -62,6 +62,25 @@ func GetInteractionsByUserBetweenDates(id uint, startDate time.Time, endDate tim
 	return interactions, nil
 }
 
+// ListPatientInteractions returns the patient's sessions in [start, end) with who held them,
+// oldest first
+func ListPatientInteractions(patientID uint, start, end time.Time) ([]Interaction, error) {
+	var interactions []Interaction
+	db := database.DB.Preload("Doctor")
+	db = db.Where("user_id = ?", patientID)
+	db = db.Where("session_date >= ? AND session_date < ?", start, end)
+
+	if err := db.Order("session_date asc").Find(&interactions).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range interactions {
+		interactions[i].Doctor.SanitizeUser()
+	}
+
+	return interactions, nil
+}
+
 func (i *Interaction) GetInteraction() error {
 	if err := database.DB.Where("id = ?", i.ID).First(&i).Error; err != nil {
 		return err
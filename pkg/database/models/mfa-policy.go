@@ -0,0 +1,67 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// MFAPolicy is the roles of an organization whose users must use multi-factor authentication
+type MFAPolicy struct {
+	ID             uint `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	OrganizationID uint `json:"organization_id" gorm:"uniqueIndex; not null" example:"1"`
+	// Roles is a comma separated list of user roles
+	Roles       string    `json:"roles" gorm:"not null" example:"org_admin,care_manager"`
+	UpdatedByID *uint     `json:"updated_by_id,omitempty" example:"1"`
+	CreatedAt   time.Time `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+func (p *MFAPolicy) UpsertMFAPolicy() error {
+	db := database.DB.Model(&MFAPolicy{})
+	db = db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "organization_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"roles",
+			"updated_by_id",
+			"updated_at",
+		}),
+	})
+
+	return db.Create(&p).Error
+}
+
+func (p *MFAPolicy) GetMFAPolicy() error {
+	if err := database.DB.Where("organization_id = ?", p.OrganizationID).First(&p).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsMFARequired reports whether the user's organization requires MFA for the user's role.
+// Platform admins have no organization and always require it.
+func IsMFARequired(u User) bool {
+	if u.Role == "admin" {
+		return true
+	}
+
+	if u.OrganizationID == nil {
+		return false
+	}
+
+	policy := MFAPolicy{
+		OrganizationID: *u.OrganizationID,
+	}
+	if err := policy.GetMFAPolicy(); err != nil {
+		return false
+	}
+
+	for _, role := range strings.Split(policy.Roles, ",") {
+		if role == u.Role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,262 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimezone is used for patients without a timezone of their own or from their devices
+const DefaultTimezone = "America/New_York"
+
+// deviceOffsetPattern matches the offsets devices report, e.g. "UTC+6", "GMT-05:30", "+0800"
+var deviceOffsetPattern = regexp.MustCompile(`^(?:UTC|GMT)?\s*([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+// deviceZones are matched against the offsets devices report, in order. Devices report the
+// offset in effect, which moves with daylight saving time, so a fixed offset would put the
+// days of the other half of the year an hour off. Phoenix comes last, its offset is shared
+// with Denver in winter and Los Angeles in summer.
+var deviceZones = []string{
+	"America/New_York",
+	"America/Chicago",
+	"America/Denver",
+	"America/Los_Angeles",
+	"America/Anchorage",
+	"Pacific/Honolulu",
+	"America/Phoenix",
+}
+
+// DefaultLocation returns the location of DefaultTimezone
+func DefaultLocation() *time.Location {
+	loc, err := time.LoadLocation(DefaultTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ParseTimezone reads an IANA timezone name or a UTC offset as sent by the devices
+func ParseTimezone(tz string) (*time.Location, bool) {
+	tz = strings.TrimSpace(tz)
+	if tz == "" {
+		return nil, false
+	}
+
+	if strings.EqualFold(tz, "UTC") || strings.EqualFold(tz, "GMT") {
+		return time.UTC, true
+	}
+
+	if offset, ok := parseOffset(tz); ok {
+		return time.FixedZone(tz, offset), true
+	}
+
+	// Offsets are handled above, anything else must be a zone name like America/Chicago
+	if !strings.Contains(tz, "/") {
+		return nil, false
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, false
+	}
+	return loc, true
+}
+
+// parseOffset reads a UTC offset in seconds
+func parseOffset(tz string) (int, bool) {
+	m := deviceOffsetPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(tz)))
+	if m == nil {
+		return 0, false
+	}
+
+	hours, _ := strconv.Atoi(m[2])
+	minutes := 0
+	if m[3] != "" {
+		minutes, _ = strconv.Atoi(m[3])
+	}
+	if hours > 14 || minutes > 59 {
+		return 0, false
+	}
+
+	offset := hours*3600 + minutes*60
+	if m[1] == "-" {
+		offset = -offset
+	}
+	return offset, true
+}
+
+// deviceLocation reads a timezone a device reported at the given time. An offset becomes the
+// first of deviceZones that had it then, offsets none of them had stay fixed.
+func deviceLocation(tz string, reportedAt time.Time) (*time.Location, bool) {
+	offset, ok := parseOffset(tz)
+	if !ok {
+		return ParseTimezone(tz)
+	}
+
+	for _, name := range deviceZones {
+		zone, err := time.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		if _, zoneOffset := reportedAt.In(zone).Zone(); zoneOffset == offset {
+			return zone, true
+		}
+	}
+
+	return time.FixedZone(tz, offset), true
+}
+
+// GetPatientLocations returns the location each patient's days are counted in: the timezone
+// stored on the patient, else the one last reported by their devices, else DefaultTimezone
+func GetPatientLocations(patientIDs []uint) (map[uint]*time.Location, error) {
+	locations := make(map[uint]*time.Location, len(patientIDs))
+	if len(patientIDs) == 0 {
+		return locations, nil
+	}
+
+	var users []struct {
+		ID       uint
+		Timezone string
+	}
+	if err := database.DB.Model(&User{}).Select("id, timezone").Where("id IN (?)", patientIDs).Scan(&users).Error; err != nil {
+		return nil, err
+	}
+
+	for _, u := range users {
+		if loc, ok := ParseTimezone(u.Timezone); ok {
+			locations[u.ID] = loc
+		}
+	}
+
+	var missing []uint
+	for _, id := range patientIDs {
+		if _, ok := locations[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		// Only the latest report of each device counts, older ones are not read
+		latest := database.DB.Model(&DeviceStatusData{}).
+			Select("MAX(id)").
+			Where("device_id IN (SELECT id FROM devices WHERE user_id IN (?))", missing).
+			Group("device_id")
+
+		var statuses []struct {
+			UserID    uint
+			Timezone  string
+			CreatedAt time.Time
+		}
+		db := database.DB.Model(&DeviceStatusData{}).
+			Select("devices.user_id, device_status_data.timezone, device_status_data.created_at").
+			Joins("JOIN devices ON devices.id = device_status_data.device_id").
+			Where("device_status_data.id IN (?)", latest).
+			Where("devices.user_id IN (?)", missing).
+			Where("device_status_data.timezone <> ''").
+			Order("device_status_data.created_at DESC")
+		if err := db.Scan(&statuses).Error; err != nil {
+			return nil, err
+		}
+
+		for _, s := range statuses {
+			if _, ok := locations[s.UserID]; ok {
+				continue
+			}
+			if loc, ok := deviceLocation(s.Timezone, s.CreatedAt); ok {
+				locations[s.UserID] = loc
+			}
+		}
+	}
+
+	defaultLocation := DefaultLocation()
+	for _, id := range patientIDs {
+		if _, ok := locations[id]; !ok {
+			locations[id] = defaultLocation
+		}
+	}
+
+	return locations, nil
+}
+
+// GetPatientLocation returns the location the patient's days are counted in
+func GetPatientLocation(patientID uint) *time.Location {
+	locations, err := GetPatientLocations([]uint{patientID})
+	if err != nil {
+		return DefaultLocation()
+	}
+	return locations[patientID]
+}
+
+// StartOfDay returns local midnight of the day t falls on in loc
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// CountPatientReadingDays counts, per patient, the distinct local days with at least one
+// countable reading in the patient's calendar month that contains now. The month is taken in
+// each patient's own timezone, so it is the same month as the patient's today. Manual
+// readings don't count, this is the 99454 day count.
+func CountPatientReadingDays(patientIDs []uint, now time.Time) (map[uint]int, error) {
+	counts := make(map[uint]int, len(patientIDs))
+	if len(patientIDs) == 0 {
+		return counts, nil
+	}
+
+	locations, err := GetPatientLocations(patientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch from the earliest month start to the latest month end of all the timezones
+	monthStarts := make(map[uint]time.Time, len(patientIDs))
+	var from, to time.Time
+	for _, id := range patientIDs {
+		local := now.In(locations[id])
+		start := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, local.Location())
+		end := start.AddDate(0, 1, 0)
+		monthStarts[id] = start
+
+		if from.IsZero() || start.Before(from) {
+			from = start
+		}
+		if end.After(to) {
+			to = end
+		}
+	}
+
+	var readings []struct {
+		UserID     uint
+		MeasuredAt time.Time
+	}
+	db := database.DB.Model(&DeviceTelemetryData{}).
+		Select("user_id, measured_at").
+		Where("user_id IN (?)", patientIDs).
+		Where("quality IN (?)", CountableReadingQualities).
+		Where("measured_at >= ? AND measured_at < ?", from, to).
+		Scopes(DeviceUploadedReadings)
+	if err := db.Scan(&readings).Error; err != nil {
+		return nil, err
+	}
+
+	days := make(map[uint]map[string]bool, len(patientIDs))
+	for _, r := range readings {
+		start := monthStarts[r.UserID]
+		if r.MeasuredAt.Before(start) || !r.MeasuredAt.Before(start.AddDate(0, 1, 0)) {
+			continue
+		}
+
+		local := r.MeasuredAt.In(locations[r.UserID])
+		if days[r.UserID] == nil {
+			days[r.UserID] = make(map[string]bool)
+		}
+		days[r.UserID][local.Format("2006-01-02")] = true
+	}
+
+	for id, d := range days {
+		counts[id] = len(d)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestDeviceLocation(t *testing.T) {
+	winter := time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2024, time.July, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		tz         string
+		reportedAt time.Time
+		want       string
+		wantOffset int
+	}{
+		{"eastern standard time", "UTC-5", winter, "America/New_York", -5 * 3600},
+		{"eastern daylight time", "UTC-4", summer, "America/New_York", -4 * 3600},
+		{"central daylight time", "UTC-5", summer, "America/Chicago", -5 * 3600},
+		{"mountain standard time", "GMT-07:00", winter, "America/Denver", -7 * 3600},
+		{"pacific daylight time", "-0700", summer, "America/Los_Angeles", -7 * 3600},
+		{"alaska standard time", "UTC-9", winter, "America/Anchorage", -9 * 3600},
+		{"hawaii", "UTC-10", summer, "Pacific/Honolulu", -10 * 3600},
+		{"offset outside the US stays fixed", "UTC+8", winter, "UTC+8", 8 * 3600},
+		{"half hour offset stays fixed", "UTC+05:30", summer, "UTC+05:30", 5*3600 + 30*60},
+		{"zone name", "America/Chicago", summer, "America/Chicago", -5 * 3600},
+		{"utc", "UTC", winter, "UTC", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, ok := deviceLocation(tt.tz, tt.reportedAt)
+			if !ok {
+				t.Fatalf("%q was not read", tt.tz)
+			}
+			if loc.String() != tt.want {
+				t.Errorf("got %s, want %s", loc, tt.want)
+			}
+			if _, offset := tt.reportedAt.In(loc).Zone(); offset != tt.wantOffset {
+				t.Errorf("got offset %d at the report time, want %d", offset, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestDeviceLocationFollowsDaylightSaving(t *testing.T) {
+	// Reported in winter, the days of the summer must still be counted in local time
+	loc, ok := deviceLocation("UTC-6", time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC))
+	if !ok {
+		t.Fatal("offset was not read")
+	}
+
+	local := time.Date(2024, time.July, 15, 4, 30, 0, 0, time.UTC).In(loc)
+	if local.Day() != 14 || local.Hour() != 23 {
+		t.Errorf("got %s, want 23:30 on July 14th in central daylight time", local)
+	}
+}
+
+func TestDeviceLocationRejectsUnknownTimezones(t *testing.T) {
+	for _, tz := range []string{"", "garbage", "UTC+15", "GMT-05:75", "Mars/Olympus"} {
+		if loc, ok := deviceLocation(tz, time.Now()); ok {
+			t.Errorf("%q was read as %s, want it rejected", tz, loc)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ReadingDaysTarget is the number of reading days in a month that 99454 bills for
+const ReadingDaysTarget = 16
+
+// atRiskSlackDays is how many spare days a patient may have left before they are at risk
+const atRiskSlackDays = 3
+
+type AdherenceStatus string
+
+const (
+	// AdherenceMet patients already have the target reading days this month
+	AdherenceMet AdherenceStatus = "Met"
+	// AdherenceOnTrack patients can still comfortably reach the target
+	AdherenceOnTrack AdherenceStatus = "OnTrack"
+	// AdherenceAtRisk patients can still reach the target but have little slack left or broke their streak
+	AdherenceAtRisk AdherenceStatus = "AtRisk"
+	// AdherenceMissed patients can no longer reach the target this month
+	AdherenceMissed AdherenceStatus = "Missed"
+)
+
+// ReadingAdherence is a patient's progress towards the monthly reading days target, with
+// days counted in the patient's timezone
+type ReadingAdherence struct {
+	PatientID            uint            `json:"patient_id" example:"1"`
+	PatientName          string          `json:"patient_name" example:"John Doe"`
+	Phone                string          `json:"phone" example:"+1 555 555 5555"`
+	OrganizationID       uint            `json:"organization_id" example:"1"`
+	Timezone             string          `json:"timezone" example:"America/New_York"`
+	Month                string          `json:"month" example:"2021-01"`
+	ReadingDays          int             `json:"reading_days" example:"9"`
+	TargetDays           int             `json:"target_days" example:"16"`
+	DaysRemaining        int             `json:"days_remaining" example:"10"`
+	DaysNeeded           int             `json:"days_needed" example:"7"`
+	Achievable           bool            `json:"achievable" example:"true"`
+	LastReadingAt        *time.Time      `json:"last_reading_at" example:"2021-01-20T08:00:00Z"`
+	DaysSinceLastReading *int            `json:"days_since_last_reading" example:"4"`
+	StreakBroken         bool            `json:"streak_broken" example:"true"`
+	Status               AdherenceStatus `json:"status" example:"AtRisk"`
+	// Priority is the position on the outreach list, 1 is the most urgent. Patients that
+	// need no outreach have 0.
+	Priority int `json:"priority" example:"1"`
+}
+
+// slack is how many of the remaining days the patient can still skip
+func (a ReadingAdherence) slack() int {
+	return a.DaysRemaining - a.DaysNeeded
+}
+
+// NeedsOutreach reports whether the care team should reach out to the patient
+func (a ReadingAdherence) NeedsOutreach() bool {
+	return a.Status == AdherenceAtRisk || a.Status == AdherenceMissed
+}
+
+// GetReadingAdherence computes the reading adherence of the organization's enrolled RPM
+// patients for the month of now. Patients needing outreach are numbered by priority: at
+// risk patients first, those with the least slack and longest silence ahead, then the
+// patients that already missed the target.
+func GetReadingAdherence(organizationID uint, now time.Time) ([]ReadingAdherence, error) {
+	patients, err := ListEnrolledPatients("RPM", organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	adherence, err := computeReadingAdherence(patients, now)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(adherence, func(i, j int) bool {
+		a, b := adherence[i], adherence[j]
+		if rank(a.Status) != rank(b.Status) {
+			return rank(a.Status) < rank(b.Status)
+		}
+		if a.slack() != b.slack() {
+			return a.slack() < b.slack()
+		}
+		return daysSince(a) > daysSince(b)
+	})
+
+	priority := 1
+	for i := range adherence {
+		if adherence[i].NeedsOutreach() {
+			adherence[i].Priority = priority
+			priority++
+		}
+	}
+
+	return adherence, nil
+}
+
+// GetPatientReadingAdherence computes the reading adherence of one patient for the month of now
+func GetPatientReadingAdherence(patient User, now time.Time) (ReadingAdherence, error) {
+	adherence, err := computeReadingAdherence([]User{patient}, now)
+	if err != nil {
+		return ReadingAdherence{}, err
+	}
+
+	return adherence[0], nil
+}
+
+func computeReadingAdherence(patients []User, now time.Time) ([]ReadingAdherence, error) {
+	adherence := make([]ReadingAdherence, 0, len(patients))
+	if len(patients) == 0 {
+		return adherence, nil
+	}
+
+	patientIDs := make([]uint, 0, len(patients))
+	for _, p := range patients {
+		patientIDs = append(patientIDs, *p.ID)
+	}
+
+	locations, err := GetPatientLocations(patientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	readingDays, err := CountPatientReadingDays(patientIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	lastReadings, err := getLastReadingTimes(patientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	streakDaysByOrg := make(map[uint]int)
+
+	for _, p := range patients {
+		patientID := *p.ID
+		loc := locations[patientID]
+
+		organizationID := uint(0)
+		if p.OrganizationID != nil {
+			organizationID = *p.OrganizationID
+		}
+
+		streakDays, ok := streakDaysByOrg[organizationID]
+		if !ok {
+			streakDays = int(GetInteractionSettingValue(organizationID, AdherenceStreakBreakDays, DefaultAdherenceStreakBreakDays))
+			streakDaysByOrg[organizationID] = streakDays
+		}
+
+		today := StartOfDay(now, loc)
+		monthEnd := time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, loc)
+
+		a := ReadingAdherence{
+			PatientID:      patientID,
+			PatientName:    fmt.Sprintf("%s %s", p.FirstName, p.LastName),
+			Phone:          p.Phone,
+			OrganizationID: organizationID,
+			Timezone:       loc.String(),
+			Month:          today.Format("2006-01"),
+			ReadingDays:    readingDays[patientID],
+			TargetDays:     ReadingDaysTarget,
+			// Today counts as remaining until the patient has read on it
+			DaysRemaining: int(monthEnd.Sub(today).Hours()/24 + 0.5),
+			StreakBroken:  true,
+		}
+
+		if last, ok := lastReadings[patientID]; ok {
+			lastReadingAt := last
+			days := int(today.Sub(StartOfDay(last, loc)).Hours()/24 + 0.5)
+			a.LastReadingAt = &lastReadingAt
+			a.DaysSinceLastReading = &days
+			a.StreakBroken = days >= streakDays
+			if days == 0 {
+				a.DaysRemaining--
+			}
+		}
+
+		if a.ReadingDays < a.TargetDays {
+			a.DaysNeeded = a.TargetDays - a.ReadingDays
+		}
+		a.Achievable = a.DaysNeeded <= a.DaysRemaining
+
+		switch {
+		case a.DaysNeeded == 0:
+			a.Status = AdherenceMet
+		case !a.Achievable:
+			a.Status = AdherenceMissed
+		case a.StreakBroken || a.slack() <= atRiskSlackDays:
+			a.Status = AdherenceAtRisk
+		default:
+			a.Status = AdherenceOnTrack
+		}
+
+		adherence = append(adherence, a)
+	}
+
+	return adherence, nil
+}
+
+// getLastReadingTimes returns when each patient last took a countable reading
+func getLastReadingTimes(patientIDs []uint) (map[uint]time.Time, error) {
+	var rows []struct {
+		UserID        uint
+		LastReadingAt time.Time
+	}
+
+	db := database.DB.Model(&DeviceTelemetryData{})
+	db = db.Select("user_id, MAX(measured_at) AS last_reading_at")
+	db = db.Where("user_id IN (?)", patientIDs)
+	db = db.Where("quality IN (?)", CountableReadingQualities)
+	db = db.Group("user_id")
+
+	if err := db.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	lastReadings := make(map[uint]time.Time, len(rows))
+	for _, r := range rows {
+		lastReadings[r.UserID] = r.LastReadingAt
+	}
+
+	return lastReadings, nil
+}
+
+func rank(status AdherenceStatus) int {
+	switch status {
+	case AdherenceAtRisk:
+		return 0
+	case AdherenceMissed:
+		return 1
+	case AdherenceOnTrack:
+		return 2
+	}
+	return 3
+}
+
+// daysSince is the days since the last reading, patients that never read sort first
+func daysSince(a ReadingAdherence) int {
+	if a.DaysSinceLastReading == nil {
+		return 1 << 30
+	}
+	return *a.DaysSinceLastReading
+}
+
+// AdherenceReminder is one reading reminder sent to a patient
+type AdherenceReminder struct {
+	ID             uint                `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	PatientID      uint                `json:"patient_id" gorm:"index; not null" example:"1"`
+	OrganizationID uint                `json:"organization_id" gorm:"index; not null" example:"1"`
+	Channel        NotificationChannel `json:"channel" example:"SMS"`
+	Recipient      string              `json:"recipient" example:"+1 555 555 5555"`
+	Reason         AdherenceStatus     `json:"reason" example:"AtRisk"`
+	ReadingDays    int                 `json:"reading_days" example:"9"`
+	DaysNeeded     int                 `json:"days_needed" example:"7"`
+	Status         NotificationStatus  `json:"status" example:"Sent"`
+	Error          string              `json:"error,omitempty" gorm:"default:null" example:"twilio responded with status code 400"`
+	CreatedAt      time.Time           `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+func (r *AdherenceReminder) CreateAdherenceReminder() error {
+	if err := database.DB.Create(&r).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListAdherenceReminders returns the reminders sent to the patient, newest first
+func ListAdherenceReminders(patientID uint) ([]AdherenceReminder, error) {
+	var reminders []AdherenceReminder
+	db := database.DB.Where("patient_id = ?", patientID)
+	if err := db.Order("id desc").Find(&reminders).Error; err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// ListRemindedPatientsSince returns the patients that were sent a reminder since the given time
+func ListRemindedPatientsSince(patientIDs []uint, since time.Time) (map[uint]bool, error) {
+	reminded := make(map[uint]bool)
+	if len(patientIDs) == 0 {
+		return reminded, nil
+	}
+
+	var ids []uint
+	db := database.DB.Model(&AdherenceReminder{})
+	db = db.Where("patient_id IN (?)", patientIDs)
+	db = db.Where("status = ?", NotificationSent)
+	db = db.Where("created_at >= ?", since)
+
+	if err := db.Distinct().Pluck("patient_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		reminded[id] = true
+	}
+
+	return reminded, nil
+}
@@ -0,0 +1,190 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"strings"
+	"time"
+)
+
+type ReadingQuality string
+
+const (
+	// ReadingValid readings count everywhere
+	ReadingValid ReadingQuality = "Valid"
+	// ReadingQuestionable readings were taken in less than ideal conditions, like a shaking
+	// hand or a scale that didn't settle. They still count but are flagged for review.
+	ReadingQuestionable ReadingQuality = "Questionable"
+	// ReadingControl readings were taken with quality control liquid to check the meter
+	ReadingControl ReadingQuality = "Control"
+	// ReadingInvalid readings were rejected by the device or marked invalid by a clinician
+	ReadingInvalid ReadingQuality = "Invalid"
+)
+
+// CountableReadingQualities are the qualities of readings that count towards billing,
+// alerts and aggregates. Control and invalid readings are kept but never counted.
+var CountableReadingQualities = []ReadingQuality{ReadingValid, ReadingQuestionable}
+
+// Quality flags explain why a reading is not Valid
+const (
+	FlagHandShaking    = "HandShaking"
+	FlagUnstableWeight = "UnstableWeight"
+	FlagControlLiquid  = "ControlLiquid"
+	FlagInvalidSample  = "InvalidSample"
+	// FlagTruncatedGlucose readings were stored in whole mmol/L and lost their decimals
+	FlagTruncatedGlucose = "TruncatedGlucose"
+)
+
+// IsCountable reports whether the reading counts towards billing, alerts and aggregates
+func (d DeviceTelemetryData) IsCountable() bool {
+	return d.Quality == ReadingValid || d.Quality == ReadingQuestionable
+}
+
+// ComputeQuality derives the quality of a new reading from what the device reported
+func (d *DeviceTelemetryData) ComputeQuality() {
+	var flags []string
+	quality := ReadingValid
+
+	if d.HandShaking {
+		flags = append(flags, FlagHandShaking)
+		quality = ReadingQuestionable
+	}
+
+	if d.Weight > 0 && d.WeightStableTime == 0 {
+		flags = append(flags, FlagUnstableWeight)
+		quality = ReadingQuestionable
+	}
+
+	if d.BloodGlucose > 0 {
+		switch d.SampleType {
+		case SampleTypeControl:
+			flags = append(flags, FlagControlLiquid)
+			quality = ReadingControl
+		case SampleTypeBlood:
+		default:
+			flags = append(flags, FlagInvalidSample)
+			quality = ReadingInvalid
+		}
+	}
+
+	d.Quality = quality
+	d.QualityFlags = strings.Join(flags, ",")
+}
+
+// InvalidateReading marks the reading invalid. The raw values are kept.
+func (d *DeviceTelemetryData) InvalidateReading(invalidatedByID *uint, reason string) error {
+	now := time.Now().UTC()
+	d.Quality = ReadingInvalid
+	d.InvalidatedByID = invalidatedByID
+	d.InvalidatedAt = &now
+	d.InvalidReason = reason
+
+	db := database.DB.Model(&DeviceTelemetryData{})
+	db = db.Where("id = ?", d.ID)
+
+	if err := db.UpdateColumns(map[string]interface{}{
+		"quality":           d.Quality,
+		"invalidated_by_id": d.InvalidatedByID,
+		"invalidated_at":    d.InvalidatedAt,
+		"invalid_reason":    d.InvalidReason,
+	}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RestoreReading undoes a clinician's invalidation, the quality is derived from the
+// device data again
+func (d *DeviceTelemetryData) RestoreReading() error {
+	d.ComputeQuality()
+	d.InvalidatedByID = nil
+	d.InvalidatedAt = nil
+	d.InvalidReason = ""
+
+	db := database.DB.Model(&DeviceTelemetryData{})
+	db = db.Where("id = ?", d.ID)
+
+	if err := db.UpdateColumns(map[string]interface{}{
+		"quality":           d.Quality,
+		"invalidated_by_id": nil,
+		"invalidated_at":    nil,
+		"invalid_reason":    "",
+	}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ResolveReadingAlerts resolves the open alerts raised by the reading alone and returns them,
+// alerts that folded in other readings stay open for the care team
+func ResolveReadingAlerts(telemetryID uint, resolvedByID *uint) ([]TelemetryAlert, error) {
+	var alerts []TelemetryAlert
+
+	db := database.DB.Model(&TelemetryAlert{})
+	db = db.Where("telemetry_id = ?", telemetryID)
+	db = db.Where("episode_count = ?", 1)
+	db = db.Where("is_active = ?", true)
+
+	if err := db.Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	if len(alerts) == 0 {
+		return alerts, nil
+	}
+
+	now := time.Now().UTC()
+	ids := make([]uint, 0, len(alerts))
+	for i := range alerts {
+		ids = append(ids, alerts[i].ID)
+		alerts[i].IsActive = false
+		alerts[i].ResolvedByID = resolvedByID
+		alerts[i].ResolvedAt = &now
+		alerts[i].Outcome = OutcomeNoActionNeeded
+	}
+
+	db = database.DB.Model(&TelemetryAlert{})
+	db = db.Where("id IN (?)", ids)
+	db = db.Where("is_active = ?", true)
+
+	if err := db.UpdateColumns(map[string]interface{}{
+		"is_active":      false,
+		"resolved_by_id": resolvedByID,
+		"resolved_at":    now,
+		"outcome":        OutcomeNoActionNeeded,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+// BackfillReadingQuality sets the quality of readings stored before it was tracked
+func BackfillReadingQuality() error {
+	db := database.DB.Model(&DeviceTelemetryData{})
+	if err := db.Where("quality = ? AND blood_glucose > 0 AND sample_type = ?", ReadingValid, SampleTypeControl).
+		UpdateColumns(map[string]interface{}{"quality": ReadingControl, "quality_flags": FlagControlLiquid}).Error; err != nil {
+		return err
+	}
+
+	db = database.DB.Model(&DeviceTelemetryData{})
+	if err := db.Where("quality = ? AND blood_glucose > 0 AND sample_type <> ?", ReadingValid, SampleTypeBlood).
+		UpdateColumns(map[string]interface{}{"quality": ReadingInvalid, "quality_flags": FlagInvalidSample}).Error; err != nil {
+		return err
+	}
+
+	db = database.DB.Model(&DeviceTelemetryData{})
+	if err := db.Where("quality = ? AND hand_shaking = ?", ReadingValid, true).
+		UpdateColumns(map[string]interface{}{"quality": ReadingQuestionable, "quality_flags": FlagHandShaking}).Error; err != nil {
+		return err
+	}
+
+	db = database.DB.Model(&DeviceTelemetryData{})
+	if err := db.Where("quality = ? AND weight > 0 AND weight_stable_time = 0", ReadingValid).
+		UpdateColumns(map[string]interface{}{"quality": ReadingQuestionable, "quality_flags": FlagUnstableWeight}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
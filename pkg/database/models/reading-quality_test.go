@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestComputeQuality(t *testing.T) {
+	tests := []struct {
+		name      string
+		reading   DeviceTelemetryData
+		want      ReadingQuality
+		wantFlags string
+	}{
+		{"blood pressure", DeviceTelemetryData{SystolicBP: 120, DiastolicBP: 80, Pulse: 70}, ReadingValid, ""},
+		{"shaking hand", DeviceTelemetryData{SystolicBP: 120, DiastolicBP: 80, HandShaking: true}, ReadingQuestionable, FlagHandShaking},
+		{"settled weight", DeviceTelemetryData{Weight: 180, WeightStableTime: 3}, ReadingValid, ""},
+		{"unsettled weight", DeviceTelemetryData{Weight: 180}, ReadingQuestionable, FlagUnstableWeight},
+		{"blood sample", DeviceTelemetryData{BloodGlucose: 110, SampleType: SampleTypeBlood}, ReadingValid, ""},
+		{"control liquid", DeviceTelemetryData{BloodGlucose: 110, SampleType: SampleTypeControl}, ReadingControl, FlagControlLiquid},
+		{"invalid sample", DeviceTelemetryData{BloodGlucose: 110, SampleType: SampleTypeInvalid}, ReadingInvalid, FlagInvalidSample},
+		{"unknown sample", DeviceTelemetryData{BloodGlucose: 110}, ReadingInvalid, FlagInvalidSample},
+		{"invalid sample outranks a shaking hand", DeviceTelemetryData{BloodGlucose: 110, SampleType: SampleTypeInvalid, HandShaking: true}, ReadingInvalid, FlagHandShaking + "," + FlagInvalidSample},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading := tt.reading
+			reading.ComputeQuality()
+			if reading.Quality != tt.want || reading.QualityFlags != tt.wantFlags {
+				t.Errorf("got %s with flags %q, want %s with flags %q", reading.Quality, reading.QualityFlags, tt.want, tt.wantFlags)
+			}
+			if reading.IsCountable() != (tt.want == ReadingValid || tt.want == ReadingQuestionable) {
+				t.Errorf("got countable %v for a %s reading", reading.IsCountable(), reading.Quality)
+			}
+		})
+	}
+}
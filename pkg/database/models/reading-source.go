@@ -0,0 +1,35 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+type ReadingSource string
+
+const (
+	// SourceDevice readings were uploaded by a device, or read off a device and entered by hand
+	SourceDevice ReadingSource = "Device"
+	// SourcePatientReported readings were called in or entered by the patient
+	SourcePatientReported ReadingSource = "PatientReported"
+	// SourceClinicMeasured readings were taken by staff with clinic equipment
+	SourceClinicMeasured ReadingSource = "ClinicMeasured"
+	// SourceImported readings were imported from another vendor's export
+	SourceImported ReadingSource = "Imported"
+)
+
+// IsManual reports whether the reading was entered by hand instead of uploaded by a device
+func (d DeviceTelemetryData) IsManual() bool {
+	return d.EnteredByID != nil
+}
+
+// IsDeviceUploaded is DeviceUploadedReadings for a single reading
+func (d DeviceTelemetryData) IsDeviceUploaded() bool {
+	return d.EnteredByID == nil && d.ImportBatchID == nil
+}
+
+// DeviceUploadedReadings limits a telemetry query to readings uploaded by a device, the only
+// ones device supply billing like 99453 and 99454 may count. Imported readings were billed
+// by the previous vendor.
+func DeviceUploadedReadings(db *gorm.DB) *gorm.DB {
+	return db.Where("device_telemetry_data.entered_by_id IS NULL AND device_telemetry_data.import_batch_id IS NULL")
+}
@@ -107,3 +107,31 @@ func UpsertPatientServices(services []PatientService) error {
 
 	return nil
 }
+
+// ListEnrolledPatients returns the patients with an open enrollment in the enabled service,
+// limited to the organization unless organizationID is 0
+func ListEnrolledPatients(serviceCode string, organizationID uint) ([]User, error) {
+	var patients []User
+
+	db := database.DB.Model(&User{})
+	db = db.Joins("JOIN patient_services ON patient_services.patient_id = users.id")
+	db = db.Joins("JOIN services ON services.id = patient_services.service_id")
+	db = db.Where("services.is_enabled = ?", true)
+	db = db.Where("services.code = ?", serviceCode)
+	db = db.Where("patient_services.ended_at IS NULL")
+	db = db.Where("users.is_deleted = ?", false)
+
+	if organizationID != 0 {
+		db = db.Where("users.organization_id = ?", organizationID)
+	}
+
+	if err := db.Distinct("users.*").Find(&patients).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range patients {
+		patients[i].SanitizeUser()
+	}
+
+	return patients, nil
+}
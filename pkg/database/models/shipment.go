@@ -0,0 +1,148 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Shipment is a device order placed with Mio for a patient. The address is copied from the
+// patient when the order is placed so later profile edits don't rewrite where it went.
+type Shipment struct {
+	ID             uint           `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	OrganizationID uint           `json:"organization_id" gorm:"index;not null" example:"1"`
+	PatientID      uint           `json:"patient_id" gorm:"index;not null" example:"1"`
+	Patient        *User          `json:"patient,omitempty" gorm:"foreignKey:PatientID"`
+	OrderedByID    *uint          `json:"ordered_by_id,omitempty" example:"1"`
+	MioShipmentID  string         `json:"mio_shipment_id,omitempty" gorm:"index" example:"shp_1"`
+	Status         ShipmentStatus `json:"status" gorm:"not null" example:"Ordered"`
+	Error          string         `json:"error,omitempty" example:"API responded with status code 422: Address is incomplete"`
+	Carrier        string         `json:"carrier,omitempty" example:"USPS"`
+	TrackingNumber string         `json:"tracking_number,omitempty" example:"9400100000000000000000"`
+	AddressLine    string         `json:"address_line" example:"123 Main St"`
+	City           string         `json:"city" example:"Dallas"`
+	State          string         `json:"state" example:"TX"`
+	ZipCode        string         `json:"zipcode" example:"75001"`
+	Country        string         `json:"country" example:"USA"`
+	Phone          string         `json:"phone" example:"08123456789"`
+	Items          []ShipmentItem `json:"items" gorm:"foreignKey:ShipmentID"`
+	ShippedAt      *time.Time     `json:"shipped_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	CreatedAt      time.Time      `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt      time.Time      `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// ShipmentItem is one line of a shipment order. Once Mio packs the order, each line is
+// split into one item per device so the device can be linked to the patient on delivery.
+type ShipmentItem struct {
+	ID          uint    `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	ShipmentID  uint    `json:"shipment_id" gorm:"index;not null" example:"1"`
+	ModelNumber string  `json:"model_number" gorm:"not null" example:"TBM-2092-G"`
+	Count       uint    `json:"count" gorm:"not null" example:"1"`
+	IMEI        string  `json:"imei,omitempty" example:"123456789"`
+	DeviceID    *uint   `json:"device_id,omitempty" example:"1"`
+	Device      *Device `json:"device,omitempty" gorm:"foreignKey:DeviceID"`
+}
+
+type ShipmentStatus string
+
+const (
+	ShipmentPending   ShipmentStatus = "Pending"
+	ShipmentOrdered   ShipmentStatus = "Ordered"
+	ShipmentShipped   ShipmentStatus = "Shipped"
+	ShipmentDelivered ShipmentStatus = "Delivered"
+	ShipmentCancelled ShipmentStatus = "Cancelled"
+	ShipmentFailed    ShipmentStatus = "Failed"
+)
+
+// IsOpen reports whether the shipment is still on its way
+func (s ShipmentStatus) IsOpen() bool {
+	return s == ShipmentOrdered || s == ShipmentShipped
+}
+
+func (s *Shipment) CreateShipment() error {
+	if err := database.DB.Create(&s).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Shipment) UpdateShipment() error {
+	if err := database.DB.Omit("Items", "Patient").Save(&s).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Shipment) GetShipment() error {
+	db := database.DB.Preload("Items.Device").Preload("Patient")
+	if err := db.Where("id = ?", s.ID).First(&s).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReplaceShipmentItems swaps the ordered lines for the devices Mio actually packed
+func (s *Shipment) ReplaceShipmentItems(items []ShipmentItem) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("shipment_id = ?", s.ID).Delete(&ShipmentItem{}).Error; err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].ID = 0
+			items[i].ShipmentID = s.ID
+		}
+		if len(items) > 0 {
+			if err := tx.Create(&items).Error; err != nil {
+				return err
+			}
+		}
+		s.Items = items
+		return nil
+	})
+}
+
+// LinkShipmentItemDevice records which local device was packed into the item
+func (i *ShipmentItem) LinkShipmentItemDevice(deviceID uint) error {
+	if err := database.DB.Model(&ShipmentItem{}).Where("id = ?", i.ID).Update("device_id", deviceID).Error; err != nil {
+		return err
+	}
+	i.DeviceID = &deviceID
+	return nil
+}
+
+func ListShipments(organizationID uint, patientID uint, status string) ([]Shipment, error) {
+	var shipments []Shipment
+
+	db := database.DB.Model(&Shipment{}).Preload("Items")
+	if organizationID != 0 {
+		db = db.Where("organization_id = ?", organizationID)
+	}
+	if patientID != 0 {
+		db = db.Where("patient_id = ?", patientID)
+	}
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	if err := db.Order("created_at desc").Find(&shipments).Error; err != nil {
+		return nil, err
+	}
+
+	return shipments, nil
+}
+
+// ListOpenShipments returns the shipments Mio has accepted that haven't arrived yet
+func ListOpenShipments() ([]Shipment, error) {
+	var shipments []Shipment
+
+	db := database.DB.Model(&Shipment{}).Preload("Items")
+	db = db.Where("status IN ?", []ShipmentStatus{ShipmentOrdered, ShipmentShipped})
+
+	if err := db.Find(&shipments).Error; err != nil {
+		return nil, err
+	}
+
+	return shipments, nil
+}
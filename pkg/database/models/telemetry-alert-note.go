@@ -0,0 +1,38 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"time"
+)
+
+type TelemetryAlertNote struct {
+	ID        uint      `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	AlertID   uint      `json:"alert_id" gorm:"index; not null" example:"1"`
+	AuthorID  uint      `json:"author_id" gorm:"not null" example:"1"`
+	Author    *User     `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+	Note      string    `json:"note" gorm:"type:text; not null" example:"Called patient, BP retaken at 135/85"`
+	CreatedAt time.Time `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+func (n *TelemetryAlertNote) CreateTelemetryAlertNote() error {
+	if err := database.DB.Create(&n).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func ListTelemetryAlertNotes(alertID uint) ([]TelemetryAlertNote, error) {
+	var notes []TelemetryAlertNote
+	db := database.DB.Where("alert_id = ?", alertID).Preload("Author")
+	if err := db.Order("id asc").Find(&notes).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range notes {
+		if notes[i].Author != nil {
+			notes[i].Author.SanitizeUser()
+		}
+	}
+
+	return notes, nil
+}
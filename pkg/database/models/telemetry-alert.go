@@ -2,30 +2,53 @@ package models
 
 import (
 	"MedKick-backend/pkg/database"
+	"errors"
 	"time"
 
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TelemetryAlert struct {
-	ID             uint                 `json:"id" gorm:"primary_key;auto_increment" example:"1"`
-	OrganizationID uint                 `json:"organization_id" gorm:"not null" example:"1"`
-	Organization   *Organization        `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"`
-	DeviceType     DeviceType           `json:"device_type" example:"BloodPressure"`
-	DeviceID       uint                 `json:"device_id" example:"1"`
-	Device         *Device              `json:"device,omitempty" gorm:"foreignKey:DeviceID"`
-	TelemetryID    uint                 `json:"telemetry_id" example:"1"`
-	Telemetry      *DeviceTelemetryData `json:"telemetry,omitempty" gorm:"foreignKey:TelemetryID"`
-	PatientID      uint                 `json:"patient_id" example:"1"`
-	Patient        *User                `json:"patient,omitempty" gorm:"foreignKey:PatientID"`
-	AlertType      AlertType            `json:"alert_type" example:"WarningHigh"`
-	Data           datatypes.JSONMap    `json:"data" example:"{\"value\": 120}"`
-	IsActive       bool                 `json:"is_active" example:"true"`
-	ResolvedByID   *uint                `json:"resolved_by_id,omitempty" example:"1"`
-	ResolvedBy     *User                `json:"resolved_by,omitempty" gorm:"foreignKey:ResolvedByID"`
-	MeasuredAt     time.Time            `json:"measured_at" example:"2021-01-01T00:00:00Z"`
-	IsAutoResolved bool                 `json:"is_auto_resolved,omitempty" example:"true"`
-	ResolvedAt     *time.Time           `json:"resolved_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	ID              uint                 `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	OrganizationID  uint                 `json:"organization_id" gorm:"not null" example:"1"`
+	Organization    *Organization        `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"`
+	DeviceType      DeviceType           `json:"device_type" example:"BloodPressure"`
+	MeasurementType MeasurementType      `json:"measurement_type,omitempty" example:"FastingGlucose"`
+	DeviceID        uint                 `json:"device_id" example:"1"`
+	Device          *Device              `json:"device,omitempty" gorm:"foreignKey:DeviceID"`
+	TelemetryID     uint                 `json:"telemetry_id" example:"1"`
+	Telemetry       *DeviceTelemetryData `json:"telemetry,omitempty" gorm:"foreignKey:TelemetryID"`
+	PatientID       uint                 `json:"patient_id" example:"1"`
+	Patient         *User                `json:"patient,omitempty" gorm:"foreignKey:PatientID"`
+	AlertType       AlertType            `json:"alert_type" example:"WarningHigh"`
+	TrendRuleID     *uint                `json:"trend_rule_id,omitempty" example:"1"`
+	Data            datatypes.JSONMap    `json:"data" example:"{\"value\": 120}"`
+	IsActive        bool                 `json:"is_active" example:"true"`
+	ResolvedByID    *uint                `json:"resolved_by_id,omitempty" example:"1"`
+	ResolvedBy      *User                `json:"resolved_by,omitempty" gorm:"foreignKey:ResolvedByID"`
+	MeasuredAt      time.Time            `json:"measured_at" example:"2021-01-01T00:00:00Z"`
+	IsAutoResolved  bool                 `json:"is_auto_resolved,omitempty" example:"true"`
+	ResolvedAt      *time.Time           `json:"resolved_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	// An alert is one episode of a condition, repeated out of range readings are folded into it
+	EpisodeCount       uint         `json:"episode_count" gorm:"not null; default:1" example:"3"`
+	LastTelemetryID    *uint        `json:"last_telemetry_id,omitempty" example:"3"`
+	LastMeasuredAt     *time.Time   `json:"last_measured_at,omitempty" example:"2021-01-03T00:00:00Z"`
+	NormalReadingCount uint         `json:"normal_reading_count" gorm:"not null; default:0" example:"0"`
+	AcknowledgedByID   *uint        `json:"acknowledged_by_id,omitempty" example:"1"`
+	AcknowledgedBy     *User        `json:"acknowledged_by,omitempty" gorm:"foreignKey:AcknowledgedByID"`
+	AcknowledgedAt     *time.Time   `json:"acknowledged_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	AssignedToID       *uint        `json:"assigned_to_id,omitempty" example:"1"`
+	AssignedTo         *User        `json:"assigned_to,omitempty" gorm:"foreignKey:AssignedToID"`
+	AssignedAt         *time.Time   `json:"assigned_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	Outcome            AlertOutcome `json:"outcome,omitempty" gorm:"default:null" example:"CalledPatient"`
+	InteractionID      *uint        `json:"interaction_id,omitempty" example:"1"`
+	Interaction        *Interaction `json:"interaction,omitempty" gorm:"foreignKey:InteractionID"`
+	// NotifiedTier is the last escalation tier that was notified, 0 when nobody was notified yet
+	NotifiedTier   uint                `json:"notified_tier" gorm:"not null; default:0" example:"1"`
+	LastNotifiedAt *time.Time          `json:"last_notified_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	Notifications  []AlertNotification `json:"notifications,omitempty" gorm:"foreignKey:AlertID"`
 
 	CreatedAt time.Time `json:"created_at" example:"2021-01-01T00:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" example:"2021-01-01T00:00:00Z"`
@@ -39,6 +62,35 @@ const (
 	AlertOk       AlertType = "Ok"
 )
 
+type AlertOutcome string
+
+const (
+	OutcomeCalledPatient    AlertOutcome = "CalledPatient"
+	OutcomeMedicationChange AlertOutcome = "MedicationChange"
+	OutcomeSentToER         AlertOutcome = "SentToER"
+	OutcomeNoActionNeeded   AlertOutcome = "NoActionNeeded"
+	OutcomeOther            AlertOutcome = "Other"
+)
+
+// TelemetryAlertFilter narrows the alerts listed for an organization
+type TelemetryAlertFilter struct {
+	IsActive       bool
+	IsAutoResolved bool
+	IsAcknowledged *bool
+	AssignedToID   *uint
+}
+
+// WorseAlertType returns the more severe of the two alert types
+func WorseAlertType(a, b AlertType) AlertType {
+	if a == AlertCritical || b == AlertCritical {
+		return AlertCritical
+	}
+	if a == AlertWarning || b == AlertWarning {
+		return AlertWarning
+	}
+	return AlertOk
+}
+
 func (t *TelemetryAlert) GetTelemetryAlert() error {
 	db := database.DB.Model(&TelemetryAlert{})
 
@@ -64,24 +116,282 @@ func (t *TelemetryAlert) GetTelemetryAlert() error {
 	return nil
 }
 
-func (t *TelemetryAlert) InsertTelemetryAlert() error {
-	db := database.DB.Model(&TelemetryAlert{})
+// GetTelemetryAlertByID gets the alert regardless of its state
+func (t *TelemetryAlert) GetTelemetryAlertByID() error {
+	if err := database.DB.Where("id = ?", t.ID).First(&t).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// BackfillAlertMeasurementType sets an empty measurement type on alerts raised before it
+// was recorded, blood pressure alerts are stored with an empty one
+func BackfillAlertMeasurementType() error {
+	return database.DB.Model(&TelemetryAlert{}).
+		Where("measurement_type IS NULL").
+		Update("measurement_type", "").Error
+}
+
+// AlertEpisodeChange is what recording a reading did to its alert episode
+type AlertEpisodeChange int
+
+const (
+	EpisodeUnchanged AlertEpisodeChange = iota
+	EpisodeOpened
+	EpisodeUpdated
+	EpisodeResolved
+)
+
+// RecordTelemetryAlert applies one evaluated condition to its alert episode and returns
+// the episode with what happened to it. An out of range reading opens an episode or is
+// folded into the open one, a normal reading taken after the episode counts towards auto
+// resolving it once requiredNormal is set. The patient row is locked while the episode is
+// looked up, so readings arriving at the same time can't open the same episode twice.
+func RecordTelemetryAlert(alert TelemetryAlert, requiredNormal uint) (TelemetryAlert, AlertEpisodeChange, error) {
+	episode := TelemetryAlert{
+		PatientID:       alert.PatientID,
+		DeviceType:      alert.DeviceType,
+		MeasurementType: alert.MeasurementType,
+		TrendRuleID:     alert.TrendRuleID,
+	}
+	change := EpisodeUnchanged
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		if err := tx.Model(&User{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", alert.PatientID).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+
+		err := episode.getOpenTelemetryAlert(tx)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		hasOpen := err == nil
+
+		if alert.AlertType == AlertOk {
+			// Readings synced late must not resolve an episode that started after them
+			if !hasOpen || requiredNormal == 0 || alert.MeasuredAt.Before(episode.LatestMeasuredAt()) {
+				return nil
+			}
+			if err := episode.addNormalReading(tx, requiredNormal); err != nil {
+				return err
+			}
+			if !episode.IsActive {
+				change = EpisodeResolved
+			}
+			return nil
+		}
+
+		if hasOpen {
+			if err := episode.addEpisodeReading(tx, alert); err != nil {
+				return err
+			}
+			change = EpisodeUpdated
+			return nil
+		}
+
+		episode = alert
+		episode.ID = 0
+		if err := episode.insertTelemetryAlert(tx); err != nil {
+			return err
+		}
+		change = EpisodeOpened
+		return nil
+	})
+	if err != nil {
+		return TelemetryAlert{}, EpisodeUnchanged, err
+	}
+
+	return episode, change, nil
+}
+
+// getOpenTelemetryAlert finds the open episode for the same patient, device type,
+// measurement and trend rule as t
+func (t *TelemetryAlert) getOpenTelemetryAlert(tx *gorm.DB) error {
+	db := tx.Model(&TelemetryAlert{})
+	db = db.Where("patient_id = ?", t.PatientID)
+	db = db.Where("device_type = ?", t.DeviceType)
+	if t.MeasurementType != "" {
+		db = db.Where("measurement_type = ?", t.MeasurementType)
+	} else {
+		// Alerts raised before measurement types were recorded hold NULL
+		db = db.Where("(measurement_type = '' OR measurement_type IS NULL)")
+	}
+
+	if t.TrendRuleID != nil {
+		db = db.Where("trend_rule_id = ?", *t.TrendRuleID)
+	} else {
+		db = db.Where("trend_rule_id IS NULL")
+	}
+
+	db = db.Where("is_active = ?", true)
+	db = db.Where("is_auto_resolved = ?", false)
+
+	if err := db.Order("id desc").First(&t).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// LatestMeasuredAt returns when the last reading of the episode was taken
+func (t TelemetryAlert) LatestMeasuredAt() time.Time {
+	if t.LastMeasuredAt != nil {
+		return *t.LastMeasuredAt
+	}
+	return t.MeasuredAt
+}
+
+// addEpisodeReading folds another out of range reading into the open episode. The episode
+// keeps the worst alert type seen and the data of the latest reading.
+func (t *TelemetryAlert) addEpisodeReading(tx *gorm.DB, reading TelemetryAlert) error {
+	t.EpisodeCount++
+	t.AlertType = WorseAlertType(t.AlertType, reading.AlertType)
+	t.Data = reading.Data
+	t.LastTelemetryID = &reading.TelemetryID
+	t.LastMeasuredAt = &reading.MeasuredAt
+	t.NormalReadingCount = 0
+
+	db := tx.Model(&TelemetryAlert{})
+	db = db.Where("id = ?", t.ID)
+
+	if err := db.UpdateColumns(map[string]interface{}{
+		"episode_count":        t.EpisodeCount,
+		"alert_type":           t.AlertType,
+		"data":                 t.Data,
+		"last_telemetry_id":    t.LastTelemetryID,
+		"last_measured_at":     t.LastMeasuredAt,
+		"normal_reading_count": t.NormalReadingCount,
+		"updated_at":           time.Now().UTC(),
+	}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// addNormalReading counts an in range reading against the open episode and auto resolves
+// it once the required number of consecutive normal readings is reached
+func (t *TelemetryAlert) addNormalReading(tx *gorm.DB, required uint) error {
+	t.NormalReadingCount++
+
+	columns := map[string]interface{}{
+		"normal_reading_count": t.NormalReadingCount,
+		"updated_at":           time.Now().UTC(),
+	}
+
+	if t.NormalReadingCount >= required {
+		now := time.Now().UTC()
+		t.IsActive = false
+		t.IsAutoResolved = true
+		t.ResolvedAt = &now
+
+		columns["is_active"] = t.IsActive
+		columns["is_auto_resolved"] = t.IsAutoResolved
+		columns["resolved_at"] = t.ResolvedAt
+	}
+
+	db := tx.Model(&TelemetryAlert{})
+	db = db.Where("id = ?", t.ID)
+	db = db.Where("is_active = ?", true)
+
+	if err := db.UpdateColumns(columns).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *TelemetryAlert) insertTelemetryAlert(tx *gorm.DB) error {
+	db := tx.Model(&TelemetryAlert{})
+	// Alerts on manual readings may have no device
+	if t.DeviceID == 0 {
+		db = db.Omit("DeviceID")
+	}
 	if err := db.Create(&t).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
+// ResolveTelemetryAlert closes the alert by hand. An alert that was never acknowledged
+// counts as acknowledged when it is resolved.
 func (t *TelemetryAlert) ResolveTelemetryAlert() error {
+	now := time.Now().UTC()
+	columns := map[string]interface{}{
+		"is_active":      false,
+		"resolved_by_id": t.ResolvedByID,
+		"resolved_at":    now,
+	}
+
+	if t.AcknowledgedAt == nil {
+		columns["acknowledged_by_id"] = t.ResolvedByID
+		columns["acknowledged_at"] = now
+	}
+
+	if t.Outcome != "" {
+		columns["outcome"] = t.Outcome
+	}
+
+	if t.InteractionID != nil {
+		columns["interaction_id"] = t.InteractionID
+	}
+
 	db := database.DB.Model(&TelemetryAlert{})
 	db = db.Where("id = ?", t.ID)
 	db = db.Where("is_active = ?", true)
 	db = db.Where("is_auto_resolved = ?", false)
 
+	if err := db.UpdateColumns(columns).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AcknowledgeTelemetryAlert marks the alert as seen, which stops its escalation
+func (t *TelemetryAlert) AcknowledgeTelemetryAlert() error {
+	now := time.Now().UTC()
+	db := database.DB.Model(&TelemetryAlert{})
+	db = db.Where("id = ?", t.ID)
+	db = db.Where("acknowledged_at IS NULL")
+
 	if err := db.UpdateColumns(map[string]interface{}{
-		"is_active":      false,
-		"resolved_by_id": t.ResolvedByID,
-		"resolved_at":    time.Now().UTC(),
+		"acknowledged_by_id": t.AcknowledgedByID,
+		"acknowledged_at":    now,
+	}).Error; err != nil {
+		return err
+	}
+
+	t.AcknowledgedAt = &now
+	return nil
+}
+
+func (t *TelemetryAlert) AssignTelemetryAlert() error {
+	now := time.Now().UTC()
+	db := database.DB.Model(&TelemetryAlert{})
+	db = db.Where("id = ?", t.ID)
+
+	if err := db.UpdateColumns(map[string]interface{}{
+		"assigned_to_id": t.AssignedToID,
+		"assigned_at":    now,
+	}).Error; err != nil {
+		return err
+	}
+
+	t.AssignedAt = &now
+	return nil
+}
+
+// UpdateTelemetryAlertTriage saves the outcome and linked interaction of the alert
+func (t *TelemetryAlert) UpdateTelemetryAlertTriage() error {
+	db := database.DB.Model(&TelemetryAlert{})
+	db = db.Where("id = ?", t.ID)
+
+	if err := db.UpdateColumns(map[string]interface{}{
+		"outcome":        t.Outcome,
+		"interaction_id": t.InteractionID,
 	}).Error; err != nil {
 		return err
 	}
@@ -89,29 +399,47 @@ func (t *TelemetryAlert) ResolveTelemetryAlert() error {
 	return nil
 }
 
-func ListTelemetryAlerts(org uint, isActive bool, pagination PageReq, sort SortReq) ([]TelemetryAlert, error) {
+func (f TelemetryAlertFilter) scope(db *gorm.DB) *gorm.DB {
+	db = db.Where("is_active = ?", f.IsActive)
+	db = db.Where("is_auto_resolved = ?", f.IsAutoResolved)
+
+	if f.IsAcknowledged != nil {
+		if *f.IsAcknowledged {
+			db = db.Where("acknowledged_at IS NOT NULL")
+		} else {
+			db = db.Where("acknowledged_at IS NULL")
+		}
+	}
+
+	if f.AssignedToID != nil {
+		db = db.Where("assigned_to_id = ?", *f.AssignedToID)
+	}
+
+	return db
+}
+
+func ListTelemetryAlerts(org uint, filter TelemetryAlertFilter, pagination PageReq, sort SortReq) ([]TelemetryAlert, error) {
 	var telemetryAlerts []TelemetryAlert
 	db := database.DB.Model(&TelemetryAlert{})
 	db = db.Where("organization_id = ?", org)
-	db = db.Where("is_active = ?", isActive)
-	db = db.Where("is_auto_resolved = ?", false)
+	db = db.Scopes(filter.scope)
 
 	db.Scopes(pagination.Paginate())
 	db.Scopes(sort.Sort())
 
-	if err := db.Preload("Patient").Preload("ResolvedBy").Find(&telemetryAlerts).Error; err != nil {
+	db = db.Preload("Patient").Preload("ResolvedBy").Preload("AcknowledgedBy").Preload("AssignedTo")
+	if err := db.Find(&telemetryAlerts).Error; err != nil {
 		return nil, err
 	}
 
 	return telemetryAlerts, nil
 }
 
-func CountTelemetryAlerts(org uint, isActive bool) (int64, error) {
+func CountTelemetryAlerts(org uint, filter TelemetryAlertFilter) (int64, error) {
 	var count int64
 	db := database.DB.Model(&TelemetryAlert{})
 	db = db.Where("organization_id = ?", org)
-	db = db.Where("is_active = ?", isActive)
-	db = db.Where("is_auto_resolved = ?", false)
+	db = db.Scopes(filter.scope)
 
 	if err := db.Count(&count).Error; err != nil {
 		return 0, err
@@ -119,3 +447,94 @@ func CountTelemetryAlerts(org uint, isActive bool) (int64, error) {
 
 	return count, nil
 }
+
+// ListTelemetryAlertsByDateRange returns the alerts of the organization raised in [start, end)
+func ListTelemetryAlertsByDateRange(org uint, start, end time.Time) ([]TelemetryAlert, error) {
+	var telemetryAlerts []TelemetryAlert
+	db := database.DB.Model(&TelemetryAlert{})
+	db = db.Where("organization_id = ?", org)
+	db = db.Where("created_at >= ? AND created_at < ?", start, end)
+
+	if err := db.Find(&telemetryAlerts).Error; err != nil {
+		return nil, err
+	}
+
+	return telemetryAlerts, nil
+}
+
+// ListAlertsPendingNotification returns the open alerts that have not been notified to
+// their first tier yet, or whose last tier was notified before the given time
+func ListAlertsPendingNotification(notifiedBefore time.Time) ([]TelemetryAlert, error) {
+	var telemetryAlerts []TelemetryAlert
+	db := database.DB.Model(&TelemetryAlert{})
+	db = db.Where("is_active = ?", true)
+	db = db.Where("is_auto_resolved = ?", false)
+	db = db.Where("alert_type IN (?)", []AlertType{AlertWarning, AlertCritical})
+	db = db.Where("acknowledged_at IS NULL")
+	db = db.Where("notified_tier = 0 OR last_notified_at < ?", notifiedBefore)
+
+	if err := db.Preload("Patient").Find(&telemetryAlerts).Error; err != nil {
+		return nil, err
+	}
+
+	return telemetryAlerts, nil
+}
+
+// ClaimNotificationTier moves the alert to the next tier. It only succeeds for the caller
+// that saw the alert at its current tier, so a tier is never notified twice.
+func (t *TelemetryAlert) ClaimNotificationTier(tier uint) (bool, error) {
+	now := time.Now().UTC()
+	db := database.DB.Model(&TelemetryAlert{})
+	db = db.Where("id = ?", t.ID)
+	db = db.Where("notified_tier = ?", t.NotifiedTier)
+	db = db.Where("is_active = ?", true)
+	db = db.Where("acknowledged_at IS NULL")
+
+	resp := db.UpdateColumns(map[string]interface{}{
+		"notified_tier":    tier,
+		"last_notified_at": now,
+	})
+	if resp.Error != nil {
+		return false, resp.Error
+	}
+
+	if resp.RowsAffected == 0 {
+		return false, nil
+	}
+
+	t.NotifiedTier = tier
+	t.LastNotifiedAt = &now
+	return true, nil
+}
+
+// ListPatientIDsWithActiveTrendAlerts returns the patients that have an active alert of the
+// given type raised by a trend rule, such as repeated irregular heartbeats or weight gain
+func ListPatientIDsWithActiveTrendAlerts(patientIDs []uint, alertType AlertType) ([]uint, error) {
+	var ids []uint
+	db := database.DB.Model(&TelemetryAlert{})
+	db = db.Where("patient_id IN (?)", patientIDs)
+	db = db.Where("trend_rule_id IS NOT NULL")
+	db = db.Where("alert_type = ?", alertType)
+	db = db.Where("is_active = ?", true)
+	db = db.Distinct("patient_id")
+
+	if err := db.Pluck("patient_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// ListPatientTelemetryAlerts returns the patient's alerts raised in [start, end), oldest first
+func ListPatientTelemetryAlerts(patientID uint, start, end time.Time) ([]TelemetryAlert, error) {
+	var telemetryAlerts []TelemetryAlert
+	db := database.DB.Model(&TelemetryAlert{})
+	db = db.Where("patient_id = ?", patientID)
+	db = db.Where("created_at >= ? AND created_at < ?", start, end)
+
+	if err := db.Order("created_at asc").Find(&telemetryAlerts).Error; err != nil {
+		return nil, err
+	}
+
+	return telemetryAlerts, nil
+}
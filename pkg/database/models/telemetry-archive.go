@@ -0,0 +1,250 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"MedKick-backend/pkg/s3"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ArchiveSource is a table whose rows are moved to the archive once they leave its hot window
+type ArchiveSource string
+
+const (
+	ArchiveTelemetry ArchiveSource = "device_telemetry_data"
+	ArchiveStatus    ArchiveSource = "device_status_data"
+)
+
+// Hot windows in days, set with TELEMETRY_HOT_DAYS and STATUS_HOT_DAYS. The minimums keep
+// what billing, adherence and device health look at in the tables.
+const (
+	DefaultTelemetryHotDays = 395
+	minTelemetryHotDays     = 62
+	DefaultStatusHotDays    = 90
+	minStatusHotDays        = 7
+
+	// ImportRevertDays keeps imported readings in the table while their import can be reverted
+	ImportRevertDays = 30
+)
+
+// TelemetryArchive is one gzip compressed JSON lines file in the s3 bucket holding rows moved
+// out of a table, all of one owner and measured in the same UTC month
+type TelemetryArchive struct {
+	ID     uint          `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	Source ArchiveSource `json:"source" gorm:"index:,composite:archive_owner_range; not null" example:"device_telemetry_data"`
+	// OwnerID is the patient of archived readings or the device of archived status reports,
+	// 0 for files written before archives were split by owner
+	OwnerID   uint      `json:"owner_id" gorm:"index:,composite:archive_owner_range; not null; default:0" example:"1"`
+	Period    string    `json:"period" gorm:"type:varchar(7); not null" example:"2021-01"`
+	ObjectKey string    `json:"object_key" gorm:"not null" example:"archive/device_telemetry_data/2021-01/1/1-1612137600000000000.jsonl.gz"`
+	RowCount  int       `json:"row_count" example:"5000"`
+	FirstAt   time.Time `json:"first_at" example:"2021-01-01T00:00:00Z"`
+	LastAt    time.Time `json:"last_at" gorm:"index:,composite:archive_owner_range" example:"2021-01-31T23:59:59Z"`
+	CreatedAt time.Time `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// HotWindowDays is how many days of rows the source keeps in its table
+func HotWindowDays(source ArchiveSource) int {
+	env, fallback, minimum := "TELEMETRY_HOT_DAYS", DefaultTelemetryHotDays, minTelemetryHotDays
+	if source == ArchiveStatus {
+		env, fallback, minimum = "STATUS_HOT_DAYS", DefaultStatusHotDays, minStatusHotDays
+	}
+
+	days, err := strconv.Atoi(os.Getenv(env))
+	if err != nil || days <= 0 {
+		return fallback
+	}
+	if days < minimum {
+		return minimum
+	}
+	return days
+}
+
+// ListArchivableTelemetry returns the oldest readings measured before the given time that
+// may leave the table. Readings an alert points at stay, and so do readings of imports
+// created after importsBefore, which can still be reverted.
+func ListArchivableTelemetry(before, importsBefore time.Time, limit int) ([]DeviceTelemetryData, error) {
+	var readings []DeviceTelemetryData
+
+	db := database.DB.Model(&DeviceTelemetryData{})
+	db = db.Where("measured_at < ?", before)
+	db = db.Where("id NOT IN (SELECT telemetry_id FROM telemetry_alerts WHERE telemetry_id IS NOT NULL)")
+	db = db.Where("id NOT IN (SELECT last_telemetry_id FROM telemetry_alerts WHERE last_telemetry_id IS NOT NULL)")
+	db = db.Where("(import_batch_id IS NULL OR import_batch_id IN (SELECT id FROM telemetry_imports WHERE created_at < ?))", importsBefore)
+	db = db.Order("measured_at asc, id asc").Limit(limit)
+
+	if err := db.Find(&readings).Error; err != nil {
+		return nil, err
+	}
+
+	return readings, nil
+}
+
+// ListArchivableStatus returns the oldest status reports received before the given time.
+// The latest report of every device stays, it is the device's timezone fallback.
+func ListArchivableStatus(before time.Time, limit int) ([]DeviceStatusData, error) {
+	var statuses []DeviceStatusData
+
+	db := database.DB.Model(&DeviceStatusData{})
+	db = db.Where("created_at < ?", before)
+	db = db.Where("id NOT IN (SELECT id FROM (SELECT MAX(id) AS id FROM device_status_data GROUP BY device_id) AS latest)")
+	db = db.Order("created_at asc, id asc").Limit(limit)
+
+	if err := db.Find(&statuses).Error; err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+// SaveTelemetryArchive records an uploaded archive of readings, folds the readings into the
+// daily summaries and deletes them from the table, all or nothing
+func SaveTelemetryArchive(archive *TelemetryArchive, readings []DeviceTelemetryData, locations map[uint]*time.Location) error {
+	ids := make([]uint, 0, len(readings))
+	for _, r := range readings {
+		ids = append(ids, r.ID)
+	}
+
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&archive).Error; err != nil {
+			return err
+		}
+
+		if err := mergeTelemetrySummaries(tx, dailySummaries(readings, locations)); err != nil {
+			return err
+		}
+
+		return tx.Where("id IN (?)", ids).Delete(&DeviceTelemetryData{}).Error
+	})
+}
+
+// SaveStatusArchive records an uploaded archive of status reports and deletes them from the table
+func SaveStatusArchive(archive *TelemetryArchive, statuses []DeviceStatusData) error {
+	ids := make([]uint, 0, len(statuses))
+	for _, s := range statuses {
+		ids = append(ids, s.ID)
+	}
+
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&archive).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id IN (?)", ids).Delete(&DeviceStatusData{}).Error
+	})
+}
+
+// ListTelemetryArchives returns the archive files of the source, newest first
+func ListTelemetryArchives(source ArchiveSource, limit int) ([]TelemetryArchive, error) {
+	var archives []TelemetryArchive
+	db := database.DB.Where("source = ?", source)
+	if err := db.Order("id desc").Limit(limit).Find(&archives).Error; err != nil {
+		return nil, err
+	}
+
+	return archives, nil
+}
+
+// ArchivedTelemetry returns the patient's archived readings measured in [start, end) that match
+func ArchivedTelemetry(patientID uint, start, end time.Time, match func(DeviceTelemetryData) bool) ([]DeviceTelemetryData, error) {
+	var readings []DeviceTelemetryData
+
+	err := scanArchives(ArchiveTelemetry, patientID, start, end, func(line []byte) error {
+		var d DeviceTelemetryData
+		if err := json.Unmarshal(line, &d); err != nil {
+			return err
+		}
+		if d.UserID == patientID && !d.MeasuredAt.Before(start) && d.MeasuredAt.Before(end) && match(d) {
+			readings = append(readings, d)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return readings, nil
+}
+
+// ArchivedStatus returns the device's archived status reports received in [start, end] that match
+func ArchivedStatus(deviceID uint, start, end time.Time, match func(DeviceStatusData) bool) ([]DeviceStatusData, error) {
+	var statuses []DeviceStatusData
+
+	err := scanArchives(ArchiveStatus, deviceID, start, end.Add(time.Nanosecond), func(line []byte) error {
+		var s DeviceStatusData
+		if err := json.Unmarshal(line, &s); err != nil {
+			return err
+		}
+		if s.DeviceID == deviceID && !s.CreatedAt.Before(start) && !s.CreatedAt.After(end) && match(s) {
+			statuses = append(statuses, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+// mergeReadings joins archived and hot readings in measurement order. An import can put
+// readings older than archived ones back into the table.
+func mergeReadings(archived, hot []DeviceTelemetryData) []DeviceTelemetryData {
+	merged := append(archived, hot...)
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].MeasuredAt.Before(merged[j].MeasuredAt)
+	})
+	return merged
+}
+
+// scanArchives feeds every row of the owner's archive files overlapping [start, end) to fn,
+// oldest file first. Most reads stay in the hot window and find no files.
+func scanArchives(source ArchiveSource, ownerID uint, start, end time.Time, fn func(line []byte) error) error {
+	var archives []TelemetryArchive
+
+	db := database.DB.Where("source = ?", source)
+	db = db.Where("owner_id IN (?)", []uint{ownerID, 0})
+	db = db.Where("last_at >= ? AND first_at < ?", start, end)
+	if err := db.Order("first_at asc").Find(&archives).Error; err != nil {
+		return err
+	}
+
+	for _, archive := range archives {
+		if err := scanArchive(archive.ObjectKey, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanArchive(key string, fn func(line []byte) error) error {
+	file, err := s3.DownloadFile(key)
+	if err != nil {
+		return err
+	}
+	defer file.Body.Close()
+
+	gz, err := gzip.NewReader(file.Body)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if err := fn(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,333 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// TelemetryImport is one batch of historical readings imported from another vendor's export.
+// Every reading it writes carries its ID, so the whole batch can be reverted.
+type TelemetryImport struct {
+	ID             uint                   `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	OrganizationID uint                   `json:"organization_id" gorm:"index; not null" example:"1"`
+	UploadedByID   uint                   `json:"uploaded_by_id" gorm:"not null" example:"1"`
+	FileName       string                 `json:"file_name" example:"readings.csv"`
+	Format         ImportFormat           `json:"format" gorm:"not null" example:"CSV"`
+	PatientMatch   PatientMatch           `json:"patient_match" gorm:"not null" example:"MRN"`
+	ColumnMap      datatypes.JSONMap      `json:"column_map,omitempty" swaggertype:"object"`
+	Status         ImportStatus           `json:"status" gorm:"not null; index" example:"Completed"`
+	Error          string                 `json:"error,omitempty" gorm:"default:null" example:"file has no rows"`
+	TotalRows      int                    `json:"total_rows" example:"120"`
+	ImportedCount  int                    `json:"imported_count" example:"112"`
+	DuplicateCount int                    `json:"duplicate_count" example:"5"`
+	ErrorCount     int                    `json:"error_count" example:"3"`
+	RevertedCount  int                    `json:"reverted_count,omitempty" example:"0"`
+	Errors         []TelemetryImportError `json:"-" gorm:"foreignKey:ImportID"`
+	StartedAt      time.Time              `json:"started_at" example:"2021-01-01T00:00:00Z"`
+	FinishedAt     *time.Time             `json:"finished_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	RevertedByID   *uint                  `json:"reverted_by_id,omitempty" example:"1"`
+	RevertedAt     *time.Time             `json:"reverted_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	CreatedAt      time.Time              `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt      time.Time              `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// TelemetryImportError is one row of a batch that could not be imported
+type TelemetryImportError struct {
+	ID        uint      `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	ImportID  uint      `json:"import_id" gorm:"index; not null" example:"1"`
+	Line      int       `json:"line" example:"14"`
+	Column    string    `json:"column,omitempty" example:"weight_unit"`
+	Message   string    `json:"message" example:"unknown weight unit stone"`
+	Raw       string    `json:"raw,omitempty" gorm:"type:text" example:"A-10023,2021-01-01 08:00,,,,12,stone"`
+	CreatedAt time.Time `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+type ImportFormat string
+
+const (
+	ImportCSV  ImportFormat = "CSV"
+	ImportJSON ImportFormat = "JSON"
+)
+
+// PatientMatch is how the rows of an import are matched to the organization's patients
+type PatientMatch string
+
+const (
+	MatchMRN     PatientMatch = "MRN"
+	MatchNameDOB PatientMatch = "NameDOB"
+	MatchEmail   PatientMatch = "Email"
+)
+
+type ImportStatus string
+
+const (
+	ImportRunning             ImportStatus = "Running"
+	ImportCompleted           ImportStatus = "Completed"
+	ImportCompletedWithErrors ImportStatus = "CompletedWithErrors"
+	ImportFailed              ImportStatus = "Failed"
+	ImportReverted            ImportStatus = "Reverted"
+)
+
+// kgToLb converts kilograms to the pounds weights are stored in
+const kgToLb = 2.20462
+
+// AddError records a row that could not be imported and counts it
+func (t *TelemetryImport) AddError(entry TelemetryImportError) {
+	t.ErrorCount++
+	t.Errors = append(t.Errors, entry)
+}
+
+func (t *TelemetryImport) CreateTelemetryImport() error {
+	if err := database.DB.Omit("Errors").Create(&t).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// FinishTelemetryImport stores the counts and the error report of the batch
+func (t *TelemetryImport) FinishTelemetryImport() error {
+	now := time.Now()
+	t.FinishedAt = &now
+
+	for i := range t.Errors {
+		t.Errors[i].ImportID = t.ID
+	}
+
+	if len(t.Errors) > 0 {
+		if err := database.DB.CreateInBatches(&t.Errors, 100).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := database.DB.Omit("Errors").Save(&t).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// FailStaleTelemetryImports marks batches still running that started before the given time
+// as failed and returns how many there were
+func FailStaleTelemetryImports(startedBefore time.Time) (int64, error) {
+	db := database.DB.Model(&TelemetryImport{})
+	db = db.Where("status = ? AND started_at < ?", ImportRunning, startedBefore)
+	result := db.Updates(map[string]interface{}{
+		"status":      ImportFailed,
+		"error":       "import stopped before finishing",
+		"finished_at": time.Now(),
+	})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (t *TelemetryImport) GetTelemetryImport() error {
+	if err := database.DB.Where("id = ?", t.ID).First(&t).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListTelemetryImports returns the organization's latest import batches
+func ListTelemetryImports(organizationID uint, limit int) ([]TelemetryImport, error) {
+	var imports []TelemetryImport
+	db := database.DB.Where("organization_id = ?", organizationID)
+	if err := db.Order("id desc").Limit(limit).Find(&imports).Error; err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+// ListTelemetryImportErrors returns the error report of a batch in file order
+func ListTelemetryImportErrors(importID uint) ([]TelemetryImportError, error) {
+	var importErrors []TelemetryImportError
+	db := database.DB.Where("import_id = ?", importID)
+	if err := db.Order("line asc, id asc").Find(&importErrors).Error; err != nil {
+		return nil, err
+	}
+
+	return importErrors, nil
+}
+
+// Revertable reports whether the batch is still in its revert window. Older readings may
+// already be in the archive, where a revert can't reach them.
+func (t TelemetryImport) Revertable() bool {
+	return t.CreatedAt.After(time.Now().AddDate(0, 0, -ImportRevertDays))
+}
+
+// RevertTelemetryImport deletes every reading the batch wrote and marks it reverted
+func (t *TelemetryImport) RevertTelemetryImport(revertedByID *uint) error {
+	if t.Status == ImportRunning {
+		return errors.New("import is still running")
+	}
+	if t.Status == ImportReverted {
+		return errors.New("import was already reverted")
+	}
+	if !t.Revertable() {
+		return errors.New("import is past its revert window")
+	}
+
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("import_batch_id = ?", t.ID).Delete(&DeviceTelemetryData{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		now := time.Now()
+		t.Status = ImportReverted
+		t.RevertedCount = int(result.RowsAffected)
+		t.RevertedByID = revertedByID
+		t.RevertedAt = &now
+
+		return tx.Omit("Errors").Save(&t).Error
+	})
+}
+
+// ListImportablePatients returns the organization's patients rows can be matched to
+func ListImportablePatients(organizationID uint) ([]User, error) {
+	var patients []User
+	db := database.DB.Where("organization_id = ?", organizationID)
+	db = db.Where("role = ?", "patient")
+	db = db.Where("is_deleted = ?", false)
+	if err := db.Find(&patients).Error; err != nil {
+		return nil, err
+	}
+
+	return patients, nil
+}
+
+// HasDuplicateReading reports whether the patient already has a reading with the same values
+// taken in the same minute. Vendors often drop the seconds, so the minute is compared.
+func HasDuplicateReading(d DeviceTelemetryData) (bool, error) {
+	minute := d.MeasuredAt.Truncate(time.Minute)
+
+	db := database.DB.Model(&DeviceTelemetryData{})
+	db = db.Where("user_id = ?", d.UserID)
+	db = db.Where("measured_at >= ? AND measured_at < ?", minute, minute.Add(time.Minute))
+
+	switch {
+	case d.SystolicBP > 0:
+		db = db.Where("systolic_bp = ? AND diastolic_bp = ?", d.SystolicBP, d.DiastolicBP)
+	case d.Weight > 0:
+		db = db.Where("weight = ?", d.Weight)
+	case d.BloodGlucose > 0:
+		db = db.Where("blood_glucose = ?", d.BloodGlucose)
+	}
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// ParseImportedWeight parses a weight in the given unit and returns it in pounds, an empty
+// unit is taken as pounds
+func ParseImportedWeight(value, unit string) (uint, error) {
+	weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || weight <= 0 {
+		return 0, errors.New("weight must be a positive number")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "", "lb", "lbs", "pound", "pounds":
+	case "kg", "kgs", "kilogram", "kilograms":
+		weight *= kgToLb
+	default:
+		return 0, errors.New("unknown weight unit " + unit + ", must be lb or kg")
+	}
+
+	if weight > 1000 {
+		return 0, errors.New("weight is out of range")
+	}
+
+	return uint(math.Round(weight)), nil
+}
+
+// ParseImportedGlucose parses a blood glucose value in the given unit and returns it in
+// mg/dL, an empty unit is taken as mg/dL
+func ParseImportedGlucose(value, unit string) (uint, error) {
+	glucose, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || glucose <= 0 {
+		return 0, errors.New("blood glucose must be a positive number")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "", "mg/dl", "mgdl":
+	case "mmol/l", "mmol":
+		glucose *= mgPerDLPerMmolL
+	default:
+		return 0, errors.New("unknown glucose unit " + unit + ", must be mg/dL or mmol/L")
+	}
+
+	if glucose > 1000 {
+		return 0, errors.New("blood glucose is out of range")
+	}
+
+	return uint(math.Round(glucose)), nil
+}
+
+// importTimeLayouts are tried in order, layouts without an offset are read in the patient's timezone
+var importTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+	"01/02/2006 3:04:05 PM",
+	"01/02/2006 3:04 PM",
+	"1/2/2006 15:04",
+	"1/2/2006 3:04 PM",
+}
+
+// ParseImportedTime parses the time a reading was taken, Unix seconds are accepted as well
+func ParseImportedTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, errors.New("measured_at is required")
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+
+	for _, layout := range importTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, errors.New("unrecognized time " + value)
+}
+
+// importDateLayouts are the date of birth formats rows are matched with
+var importDateLayouts = []string{
+	"2006-01-02",
+	"01/02/2006",
+	"1/2/2006",
+	"01-02-2006",
+	"2006/01/02",
+}
+
+// NormalizeDate returns a date of birth as YYYY-MM-DD, or an empty string if it is not a date
+func NormalizeDate(value string) string {
+	value = strings.TrimSpace(value)
+	for _, layout := range importDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("2006-01-02")
+		}
+	}
+	return ""
+}
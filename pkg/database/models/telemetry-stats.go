@@ -0,0 +1,299 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+type TelemetryBucketSize string
+
+const (
+	BucketDay   TelemetryBucketSize = "day"
+	BucketWeek  TelemetryBucketSize = "week"
+	BucketMonth TelemetryBucketSize = "month"
+)
+
+// Morning and evening windows for blood pressure, in the patient's local time
+const (
+	morningStartHour = 4
+	morningEndHour   = 12
+	eveningStartHour = 16
+	eveningEndHour   = 24
+)
+
+// MeasurementStats summarizes the readings of one measurement. The value fields are null
+// when there are no readings.
+type MeasurementStats struct {
+	Count       int          `json:"count" example:"14"`
+	ReadingDays int          `json:"reading_days" example:"7"`
+	Min         *uint        `json:"min" example:"112"`
+	Max         *uint        `json:"max" example:"148"`
+	Mean        *float64     `json:"mean" example:"127.5"`
+	Median      *float64     `json:"median" example:"125"`
+	TimeInRange *TimeInRange `json:"time_in_range,omitempty"`
+}
+
+// TimeInRange is the share of readings, in percent, that fell in each band of the
+// patient's alert threshold
+type TimeInRange struct {
+	CriticalLow  float64 `json:"critical_low" example:"0"`
+	WarningLow   float64 `json:"warning_low" example:"0"`
+	InRange      float64 `json:"in_range" example:"85.7"`
+	WarningHigh  float64 `json:"warning_high" example:"14.3"`
+	CriticalHigh float64 `json:"critical_high" example:"0"`
+}
+
+type TelemetryStatsBucket struct {
+	Start time.Time `json:"start" example:"2021-01-01T00:00:00-05:00"`
+	End   time.Time `json:"end" example:"2021-01-02T00:00:00-05:00"`
+	MeasurementStats
+}
+
+// BloodPressureTimeOfDay compares morning (04:00-12:00) and evening (16:00-24:00) blood pressure
+type BloodPressureTimeOfDay struct {
+	Morning BloodPressureAverage `json:"morning"`
+	Evening BloodPressureAverage `json:"evening"`
+}
+
+type BloodPressureAverage struct {
+	Count     int      `json:"count" example:"7"`
+	Systolic  *float64 `json:"systolic" example:"131.2"`
+	Diastolic *float64 `json:"diastolic" example:"84.1"`
+}
+
+type TelemetryStats struct {
+	PatientID       uint                    `json:"patient_id" example:"1"`
+	MeasurementType MeasurementType         `json:"measurement_type" example:"Systolic"`
+	BucketSize      TelemetryBucketSize     `json:"bucket_size" example:"day"`
+	Timezone        string                  `json:"timezone" example:"America/New_York"`
+	Start           time.Time               `json:"start" example:"2021-01-01T00:00:00-05:00"`
+	End             time.Time               `json:"end" example:"2021-01-31T00:00:00-05:00"`
+	Threshold       *AlertThreshold         `json:"threshold,omitempty"`
+	Overall         MeasurementStats        `json:"overall"`
+	Buckets         []TelemetryStatsBucket  `json:"buckets"`
+	BloodPressure   *BloodPressureTimeOfDay `json:"blood_pressure,omitempty"`
+}
+
+// statsReading is one value of the measurement and when it was taken
+type statsReading struct {
+	value      uint
+	measuredAt time.Time
+}
+
+// BucketStart returns the start of the bucket t falls in. Weeks start on Monday.
+func (b TelemetryBucketSize) BucketStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	switch b {
+	case BucketWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case BucketMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+
+	return day
+}
+
+// Next returns the start of the bucket after the one starting at start
+func (b TelemetryBucketSize) Next(start time.Time) time.Time {
+	switch b {
+	case BucketWeek:
+		return start.AddDate(0, 0, 7)
+	case BucketMonth:
+		return start.AddDate(0, 1, 0)
+	}
+
+	return start.AddDate(0, 0, 1)
+}
+
+// GetTelemetryStats aggregates the patient's readings of the measurement in [start, end)
+// into buckets, with time in range against the patient's effective alert threshold.
+// Buckets and reading days follow the location of start.
+func GetTelemetryStats(patientID uint, measurementType MeasurementType, start, end time.Time, bucketSize TelemetryBucketSize) (*TelemetryStats, error) {
+	loc := start.Location()
+
+	history, err := ListPatientMeasurementHistory(patientID, measurementType, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	thresholds, err := ListEffectiveAlertThresholds([]uint{patientID})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &TelemetryStats{
+		PatientID:       patientID,
+		MeasurementType: measurementType,
+		BucketSize:      bucketSize,
+		Timezone:        loc.String(),
+		Start:           start,
+		End:             end,
+		Buckets:         []TelemetryStatsBucket{},
+	}
+
+	for i := range thresholds {
+		if thresholds[i].MeasurementType == measurementType {
+			stats.Threshold = &thresholds[i]
+			break
+		}
+	}
+
+	readings := make([]statsReading, 0, len(history))
+	for _, d := range history {
+		value, ok := d.MeasurementValue(measurementType)
+		if !ok {
+			continue
+		}
+		readings = append(readings, statsReading{value: value, measuredAt: d.MeasuredAt.In(loc)})
+	}
+
+	stats.Overall = summarizeReadings(readings, stats.Threshold)
+
+	i := 0
+	for bucketStart := bucketSize.BucketStart(start); bucketStart.Before(end); bucketStart = bucketSize.Next(bucketStart) {
+		bucketEnd := bucketSize.Next(bucketStart)
+
+		j := i
+		for j < len(readings) && readings[j].measuredAt.Before(bucketEnd) {
+			j++
+		}
+
+		// The first and last bucket are cut to the requested range
+		bucket := TelemetryStatsBucket{
+			Start: bucketStart,
+			End:   bucketEnd,
+		}
+		if bucket.Start.Before(start) {
+			bucket.Start = start
+		}
+		if bucket.End.After(end) {
+			bucket.End = end
+		}
+		bucket.MeasurementStats = summarizeReadings(readings[i:j], stats.Threshold)
+
+		stats.Buckets = append(stats.Buckets, bucket)
+		i = j
+	}
+
+	if measurementType == Systolic || measurementType == Diastolic {
+		stats.BloodPressure = bloodPressureTimeOfDay(history, loc)
+	}
+
+	return stats, nil
+}
+
+func summarizeReadings(readings []statsReading, threshold *AlertThreshold) MeasurementStats {
+	stats := MeasurementStats{
+		Count: len(readings),
+	}
+	if len(readings) == 0 {
+		return stats
+	}
+
+	values := make([]uint, 0, len(readings))
+	days := make(map[string]bool)
+	var sum float64
+	var tir TimeInRange
+
+	for _, r := range readings {
+		values = append(values, r.value)
+		days[r.measuredAt.Format("2006-01-02")] = true
+		sum += float64(r.value)
+
+		if threshold != nil {
+			switch {
+			case threshold.CriticalLow != nil && r.value < *threshold.CriticalLow:
+				tir.CriticalLow++
+			case threshold.CriticalHigh != nil && r.value > *threshold.CriticalHigh:
+				tir.CriticalHigh++
+			case threshold.WarningLow != nil && r.value < *threshold.WarningLow:
+				tir.WarningLow++
+			case threshold.WarningHigh != nil && r.value > *threshold.WarningHigh:
+				tir.WarningHigh++
+			default:
+				tir.InRange++
+			}
+		}
+	}
+
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	n := len(values)
+	mean := roundStat(sum / float64(n))
+	median := float64(values[n/2])
+	if n%2 == 0 {
+		median = (float64(values[n/2-1]) + float64(values[n/2])) / 2
+	}
+
+	stats.ReadingDays = len(days)
+	stats.Min = &values[0]
+	stats.Max = &values[n-1]
+	stats.Mean = &mean
+	stats.Median = &median
+
+	if threshold != nil {
+		percent := func(count float64) float64 {
+			return roundStat(count * 100 / float64(n))
+		}
+		stats.TimeInRange = &TimeInRange{
+			CriticalLow:  percent(tir.CriticalLow),
+			WarningLow:   percent(tir.WarningLow),
+			InRange:      percent(tir.InRange),
+			WarningHigh:  percent(tir.WarningHigh),
+			CriticalHigh: percent(tir.CriticalHigh),
+		}
+	}
+
+	return stats
+}
+
+func bloodPressureTimeOfDay(history []DeviceTelemetryData, loc *time.Location) *BloodPressureTimeOfDay {
+	var morning, evening [3]float64
+
+	for _, d := range history {
+		if d.SystolicBP == 0 || d.DiastolicBP == 0 {
+			continue
+		}
+
+		hour := d.MeasuredAt.In(loc).Hour()
+		var window *[3]float64
+		switch {
+		case hour >= morningStartHour && hour < morningEndHour:
+			window = &morning
+		case hour >= eveningStartHour && hour < eveningEndHour:
+			window = &evening
+		default:
+			continue
+		}
+
+		window[0]++
+		window[1] += float64(d.SystolicBP)
+		window[2] += float64(d.DiastolicBP)
+	}
+
+	average := func(window [3]float64) BloodPressureAverage {
+		avg := BloodPressureAverage{
+			Count: int(window[0]),
+		}
+		if window[0] > 0 {
+			systolic := roundStat(window[1] / window[0])
+			diastolic := roundStat(window[2] / window[0])
+			avg.Systolic = &systolic
+			avg.Diastolic = &diastolic
+		}
+		return avg
+	}
+
+	return &BloodPressureTimeOfDay{
+		Morning: average(morning),
+		Evening: average(evening),
+	}
+}
+
+// roundStat rounds a statistic to one decimal
+func roundStat(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,274 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TelemetryDailySummary aggregates a patient's readings of one device type on one day in the
+// patient's timezone. Summaries are written when readings are archived, so long range trends
+// don't need the archive files. Min, max and sum cover countable readings only.
+type TelemetryDailySummary struct {
+	ID           uint       `json:"id" gorm:"primary_key;auto_increment"`
+	PatientID    uint       `json:"patient_id" gorm:"index:,unique,composite:summary_day; not null"`
+	DeviceType   DeviceType `json:"device_type" gorm:"index:,unique,composite:summary_day; not null"`
+	Day          string     `json:"day" gorm:"type:varchar(10); index:,unique,composite:summary_day; not null"`
+	Readings     int        `json:"readings"`
+	Countable    int        `json:"countable"`
+	SystolicMin  uint       `json:"systolic_min"`
+	SystolicMax  uint       `json:"systolic_max"`
+	SystolicSum  uint       `json:"systolic_sum"`
+	DiastolicMin uint       `json:"diastolic_min"`
+	DiastolicMax uint       `json:"diastolic_max"`
+	DiastolicSum uint       `json:"diastolic_sum"`
+	PulseCount   int        `json:"pulse_count"`
+	PulseMin     uint       `json:"pulse_min"`
+	PulseMax     uint       `json:"pulse_max"`
+	PulseSum     uint       `json:"pulse_sum"`
+	WeightMin    uint       `json:"weight_min"`
+	WeightMax    uint       `json:"weight_max"`
+	WeightSum    uint       `json:"weight_sum"`
+	GlucoseMin   uint       `json:"glucose_min"`
+	GlucoseMax   uint       `json:"glucose_max"`
+	GlucoseSum   uint       `json:"glucose_sum"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// DailySummary is a day of a patient's readings of one device type
+type DailySummary struct {
+	Day        string        `json:"day" example:"2021-01-01"`
+	DeviceType DeviceType    `json:"device_type" example:"BloodPressure"`
+	Readings   int           `json:"readings" example:"3"`
+	Countable  int           `json:"countable" example:"2"`
+	Systolic   *SummaryRange `json:"systolic,omitempty"`
+	Diastolic  *SummaryRange `json:"diastolic,omitempty"`
+	Pulse      *SummaryRange `json:"pulse,omitempty"`
+	Weight     *SummaryRange `json:"weight,omitempty"`
+	Glucose    *SummaryRange `json:"glucose,omitempty"`
+}
+
+type SummaryRange struct {
+	Min  uint    `json:"min" example:"118"`
+	Max  uint    `json:"max" example:"131"`
+	Mean float64 `json:"mean" example:"124.5"`
+}
+
+// ReadingDeviceType returns the type of device that takes the reading's measurements
+func (d DeviceTelemetryData) ReadingDeviceType() DeviceType {
+	switch {
+	case d.SystolicBP > 0 || d.DiastolicBP > 0:
+		return BloodPressure
+	case d.Weight > 0:
+		return WeightScale
+	case d.BloodGlucose > 0:
+		return BloodGlucose
+	}
+	return ""
+}
+
+// Add counts the reading into the summary
+func (s *TelemetryDailySummary) Add(d DeviceTelemetryData) {
+	s.Readings++
+	if !d.IsCountable() {
+		return
+	}
+	s.Countable++
+
+	addRange(&s.SystolicMin, &s.SystolicMax, &s.SystolicSum, s.Countable, d.SystolicBP)
+	addRange(&s.DiastolicMin, &s.DiastolicMax, &s.DiastolicSum, s.Countable, d.DiastolicBP)
+	addRange(&s.WeightMin, &s.WeightMax, &s.WeightSum, s.Countable, d.Weight)
+	addRange(&s.GlucoseMin, &s.GlucoseMax, &s.GlucoseSum, s.Countable, d.BloodGlucoseMgDL())
+	if d.Pulse > 0 {
+		s.PulseCount++
+		addRange(&s.PulseMin, &s.PulseMax, &s.PulseSum, s.PulseCount, d.Pulse)
+	}
+}
+
+// Merge folds another summary of the same patient, device type and day into this one
+func (s *TelemetryDailySummary) Merge(other TelemetryDailySummary) {
+	s.Readings += other.Readings
+
+	if other.Countable > 0 {
+		mergeRange(&s.SystolicMin, &s.SystolicMax, &s.SystolicSum, s.Countable, other.SystolicMin, other.SystolicMax, other.SystolicSum)
+		mergeRange(&s.DiastolicMin, &s.DiastolicMax, &s.DiastolicSum, s.Countable, other.DiastolicMin, other.DiastolicMax, other.DiastolicSum)
+		mergeRange(&s.WeightMin, &s.WeightMax, &s.WeightSum, s.Countable, other.WeightMin, other.WeightMax, other.WeightSum)
+		mergeRange(&s.GlucoseMin, &s.GlucoseMax, &s.GlucoseSum, s.Countable, other.GlucoseMin, other.GlucoseMax, other.GlucoseSum)
+		s.Countable += other.Countable
+	}
+
+	if other.PulseCount > 0 {
+		mergeRange(&s.PulseMin, &s.PulseMax, &s.PulseSum, s.PulseCount, other.PulseMin, other.PulseMax, other.PulseSum)
+		s.PulseCount += other.PulseCount
+	}
+}
+
+// Summary returns the day with the means of its measurements
+func (s TelemetryDailySummary) Summary() DailySummary {
+	summary := DailySummary{
+		Day:        s.Day,
+		DeviceType: s.DeviceType,
+		Readings:   s.Readings,
+		Countable:  s.Countable,
+	}
+
+	if s.Countable == 0 {
+		return summary
+	}
+
+	switch s.DeviceType {
+	case BloodPressure:
+		summary.Systolic = summaryRange(s.SystolicMin, s.SystolicMax, s.SystolicSum, s.Countable)
+		summary.Diastolic = summaryRange(s.DiastolicMin, s.DiastolicMax, s.DiastolicSum, s.Countable)
+		if s.PulseCount > 0 {
+			summary.Pulse = summaryRange(s.PulseMin, s.PulseMax, s.PulseSum, s.PulseCount)
+		}
+	case WeightScale:
+		summary.Weight = summaryRange(s.WeightMin, s.WeightMax, s.WeightSum, s.Countable)
+	case BloodGlucose:
+		summary.Glucose = summaryRange(s.GlucoseMin, s.GlucoseMax, s.GlucoseSum, s.Countable)
+	}
+
+	return summary
+}
+
+// addRange counts value as the n-th value of a range
+func addRange(min, max, sum *uint, n int, value uint) {
+	if n == 1 || value < *min {
+		*min = value
+	}
+	if value > *max {
+		*max = value
+	}
+	*sum += value
+}
+
+// mergeRange folds another range into one holding n values
+func mergeRange(min, max, sum *uint, n int, otherMin, otherMax, otherSum uint) {
+	if n == 0 || otherMin < *min {
+		*min = otherMin
+	}
+	if otherMax > *max {
+		*max = otherMax
+	}
+	*sum += otherSum
+}
+
+func summaryRange(min, max, sum uint, n int) *SummaryRange {
+	return &SummaryRange{
+		Min:  min,
+		Max:  max,
+		Mean: math.Round(float64(sum)/float64(n)*10) / 10,
+	}
+}
+
+type summaryKey struct {
+	patientID  uint
+	deviceType DeviceType
+	day        string
+}
+
+// dailySummaries groups readings into daily summaries by patient, device type and the
+// patient's local day. Readings without a patient are left out.
+func dailySummaries(readings []DeviceTelemetryData, locations map[uint]*time.Location) map[summaryKey]*TelemetryDailySummary {
+	summaries := make(map[summaryKey]*TelemetryDailySummary)
+
+	for _, d := range readings {
+		deviceType := d.ReadingDeviceType()
+		if d.UserID == 0 || deviceType == "" {
+			continue
+		}
+
+		loc, ok := locations[d.UserID]
+		if !ok {
+			loc = DefaultLocation()
+		}
+
+		key := summaryKey{d.UserID, deviceType, d.MeasuredAt.In(loc).Format("2006-01-02")}
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &TelemetryDailySummary{
+				PatientID:  key.patientID,
+				DeviceType: key.deviceType,
+				Day:        key.day,
+			}
+			summaries[key] = summary
+		}
+		summary.Add(d)
+	}
+
+	return summaries
+}
+
+// mergeTelemetrySummaries folds the summaries into the stored ones
+func mergeTelemetrySummaries(tx *gorm.DB, summaries map[summaryKey]*TelemetryDailySummary) error {
+	for _, summary := range summaries {
+		var stored TelemetryDailySummary
+		err := tx.Where("patient_id = ? AND device_type = ? AND day = ?", summary.PatientID, summary.DeviceType, summary.Day).First(&stored).Error
+		if err == gorm.ErrRecordNotFound {
+			if err := tx.Create(summary).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		stored.Merge(*summary)
+		if err := tx.Save(&stored).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetDailyTelemetrySummaries returns the patient's daily summaries for the local days in
+// [start, end), combining the stored summaries of archived readings with the readings still
+// in the table
+func GetDailyTelemetrySummaries(patientID uint, start, end time.Time, loc *time.Location) ([]DailySummary, error) {
+	var stored []TelemetryDailySummary
+
+	db := database.DB.Where("patient_id = ?", patientID)
+	db = db.Where("day >= ? AND day < ?", start.In(loc).Format("2006-01-02"), end.In(loc).Format("2006-01-02"))
+	if err := db.Find(&stored).Error; err != nil {
+		return nil, err
+	}
+
+	var readings []DeviceTelemetryData
+	db = database.DB.Where("user_id = ?", patientID)
+	db = db.Where("measured_at >= ? AND measured_at < ?", start, end)
+	if err := db.Find(&readings).Error; err != nil {
+		return nil, err
+	}
+
+	summaries := dailySummaries(readings, map[uint]*time.Location{patientID: loc})
+	for _, s := range stored {
+		key := summaryKey{s.PatientID, s.DeviceType, s.Day}
+		if summary, ok := summaries[key]; ok {
+			summary.Merge(s)
+			continue
+		}
+		s := s
+		summaries[key] = &s
+	}
+
+	result := make([]DailySummary, 0, len(summaries))
+	for _, s := range summaries {
+		result = append(result, s.Summary())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Day != result[j].Day {
+			return result[i].Day < result[j].Day
+		}
+		return result[i].DeviceType < result[j].DeviceType
+	})
+
+	return result, nil
+}
@@ -0,0 +1,236 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ThresholdTemplate is an organization level alert threshold. Templates without a diagnosis
+// code are the organization defaults, templates with a code apply to patients with that
+// ICD-10 diagnosis. Patient thresholds always take precedence over templates.
+type ThresholdTemplate struct {
+	ID              uint            `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	OrganizationID  uint            `json:"organization_id" gorm:"index:,unique,composite:template; not null" example:"1"`
+	DiagnosisCode   string          `json:"diagnosis_code" gorm:"type:varchar(10); index:,unique,composite:template; not null; default:''" example:"I10"`
+	DeviceType      DeviceType      `json:"device_type" gorm:"index:,unique,composite:template; not null" example:"BloodPressure"`
+	MeasurementType MeasurementType `json:"measurement_type" gorm:"index:,unique,composite:template; not null" example:"Systolic"`
+	CriticalLow     *uint           `json:"critical_low" gorm:"default:null" example:"90"`
+	WarningLow      *uint           `json:"warning_low" gorm:"default:null" example:"100"`
+	WarningHigh     *uint           `json:"warning_high" gorm:"default:null" example:"140"`
+	CriticalHigh    *uint           `json:"critical_high" gorm:"default:null" example:"180"`
+	Note            string          `json:"note" gorm:"default:null" example:"Hypertension protocol"`
+	CreatedAt       time.Time       `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt       time.Time       `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// Threshold sources returned with effective thresholds
+const (
+	ThresholdSourcePatient      = "Patient"
+	ThresholdSourceDiagnosis    = "Diagnosis"
+	ThresholdSourceOrganization = "Organization"
+)
+
+// ValidateThresholdLimits checks the limits of a threshold are ordered
+// critical low <= warning low <= warning high <= critical high
+func ValidateThresholdLimits(criticalLow, warningLow, warningHigh, criticalHigh *uint) error {
+	if criticalLow != nil && criticalHigh != nil && *criticalLow > *criticalHigh {
+		return errors.New("critical low must be less than critical high")
+	}
+
+	if warningLow != nil && warningHigh != nil && *warningLow > *warningHigh {
+		return errors.New("warning low must be less than warning high")
+	}
+
+	if criticalLow != nil && warningLow != nil && *criticalLow > *warningLow {
+		return errors.New("critical low must be less than warning low")
+	}
+
+	if criticalHigh != nil && warningHigh != nil && *criticalHigh < *warningHigh {
+		return errors.New("critical high must be greater than warning high")
+	}
+
+	return nil
+}
+
+func (t ThresholdTemplate) toAlertThreshold(patientID uint) AlertThreshold {
+	source := ThresholdSourceOrganization
+	if t.DiagnosisCode != "" {
+		source = ThresholdSourceDiagnosis + ":" + t.DiagnosisCode
+	}
+
+	return AlertThreshold{
+		PatientID:       patientID,
+		DeviceType:      t.DeviceType,
+		MeasurementType: t.MeasurementType,
+		CriticalLow:     t.CriticalLow,
+		WarningLow:      t.WarningLow,
+		WarningHigh:     t.WarningHigh,
+		CriticalHigh:    t.CriticalHigh,
+		Note:            t.Note,
+		Source:          source,
+	}
+}
+
+func ListThresholdTemplates(organizationIDs []uint) ([]ThresholdTemplate, error) {
+	var templates []ThresholdTemplate
+	db := database.DB.Where("organization_id IN (?)", organizationIDs)
+	if err := db.Order("diagnosis_code asc").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func UpsertThresholdTemplates(templates []ThresholdTemplate) error {
+	db := database.DB.Model(&ThresholdTemplate{})
+	// Conflict with OrganizationID, DiagnosisCode, DeviceType, MeasurementType then update all
+	db = db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "organization_id"}, {Name: "diagnosis_code"}, {Name: "device_type"}, {Name: "measurement_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"critical_low",
+			"warning_low",
+			"warning_high",
+			"critical_high",
+			"note",
+		}),
+	})
+	if err := db.Create(&templates).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeleteThresholdTemplate(organizationID, templateID uint) error {
+	db := database.DB.Where("organization_id = ? AND id = ?", organizationID, templateID)
+	if err := db.Delete(&ThresholdTemplate{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+type thresholdKey struct {
+	deviceType      DeviceType
+	measurementType MeasurementType
+}
+
+// ListEffectiveAlertThresholds returns the thresholds that apply to each patient. A patient's
+// own threshold wins, then the template of one of their diagnoses (lowest code first),
+// then the organization default.
+func ListEffectiveAlertThresholds(patientIDs []uint) ([]AlertThreshold, error) {
+	thresholds, err := ListAlertThresholds(patientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var patients []struct {
+		ID             uint
+		OrganizationID *uint
+	}
+	if err := database.DB.Model(&User{}).Select("id, organization_id").Where("id IN (?)", patientIDs).Scan(&patients).Error; err != nil {
+		return nil, err
+	}
+
+	var orgIDs []uint
+	patientOrg := make(map[uint]uint)
+	for _, p := range patients {
+		if p.OrganizationID != nil {
+			patientOrg[p.ID] = *p.OrganizationID
+			orgIDs = append(orgIDs, *p.OrganizationID)
+		}
+	}
+
+	if len(orgIDs) == 0 {
+		return thresholds, nil
+	}
+
+	templates, err := ListThresholdTemplates(orgIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templates) == 0 {
+		return thresholds, nil
+	}
+
+	diagnosisCodes, err := ListPatientDiagnosesCodeByPatientIDs(patientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	have := make(map[uint]map[thresholdKey]struct{})
+	for i := range thresholds {
+		thresholds[i].Source = ThresholdSourcePatient
+		if have[thresholds[i].PatientID] == nil {
+			have[thresholds[i].PatientID] = make(map[thresholdKey]struct{})
+		}
+		have[thresholds[i].PatientID][thresholdKey{thresholds[i].DeviceType, thresholds[i].MeasurementType}] = struct{}{}
+	}
+
+	for patientID, orgID := range patientOrg {
+		codes := strings.Split(diagnosisCodes[patientID], ",")
+		sort.Strings(codes)
+
+		for _, t := range templatesFor(templates, orgID, codes) {
+			key := thresholdKey{t.DeviceType, t.MeasurementType}
+			if _, ok := have[patientID][key]; ok {
+				continue
+			}
+			if have[patientID] == nil {
+				have[patientID] = make(map[thresholdKey]struct{})
+			}
+			have[patientID][key] = struct{}{}
+			thresholds = append(thresholds, t.toAlertThreshold(patientID))
+		}
+	}
+
+	return thresholds, nil
+}
+
+// templatesFor orders the organization's templates that apply to the diagnosis codes by
+// precedence, diagnosis templates first and the organization defaults last
+func templatesFor(templates []ThresholdTemplate, orgID uint, codes []string) []ThresholdTemplate {
+	var res []ThresholdTemplate
+	for _, code := range codes {
+		if code == "" {
+			continue
+		}
+		for _, t := range templates {
+			if t.OrganizationID == orgID && t.DiagnosisCode == code {
+				res = append(res, t)
+			}
+		}
+	}
+
+	for _, t := range templates {
+		if t.OrganizationID == orgID && t.DiagnosisCode == "" {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
+
+// RemoveDiagnosisThresholdCopies deletes patient thresholds that are unchanged copies of a
+// diagnosis template of the patient. Diagnoses used to copy their templates onto the
+// patient, the copies kept later template changes from reaching the patient. Only rows
+// written right after the diagnosis was added and never edited since are taken as copies,
+// it runs once so thresholds a clinician sets later are never removed.
+func RemoveDiagnosisThresholdCopies(tx *gorm.DB) error {
+	return tx.Exec(`DELETE a FROM alert_thresholds a
+		JOIN users u ON u.id = a.patient_id
+		JOIN patient_diagnoses pd ON pd.user_id = a.patient_id
+		JOIN diagnoses d ON d.id = pd.diagnosis_id
+		JOIN threshold_templates t ON t.organization_id = u.organization_id AND t.diagnosis_code = d.code
+			AND t.device_type = a.device_type AND t.measurement_type = a.measurement_type
+		WHERE a.critical_low <=> t.critical_low AND a.warning_low <=> t.warning_low
+			AND a.warning_high <=> t.warning_high AND a.critical_high <=> t.critical_high
+			AND COALESCE(a.note, '') = COALESCE(t.note, '')
+			AND a.created_at BETWEEN pd.created_at AND pd.created_at + INTERVAL 1 MINUTE
+			AND a.updated_at = a.created_at`).Error
+}
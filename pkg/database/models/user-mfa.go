@@ -0,0 +1,330 @@
+package models
+
+import (
+	"MedKick-backend/pkg/database"
+	"MedKick-backend/pkg/totp"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserMFA is a user's authenticator app. MFA is on once the user confirmed the secret with a
+// code from the app.
+type UserMFA struct {
+	ID          uint       `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	UserID      uint       `json:"user_id" gorm:"uniqueIndex; not null" example:"1"`
+	Secret      string     `json:"-" gorm:"type:varchar(64); not null"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	// EmailFallback lets the user sign in with a code sent to their email instead of the app
+	EmailFallback bool `json:"email_fallback" gorm:"default:false" example:"false"`
+	// LastStep is the TOTP period of the last accepted code, so a code can't be used twice
+	LastStep int64 `json:"-" gorm:"default:0"`
+	// Attempts is how many codes were tried since the last accepted one, over all logins
+	Attempts      uint       `json:"-" gorm:"default:0"`
+	LastAttemptAt *time.Time `json:"-"`
+	CreatedAt     time.Time  `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt     time.Time  `json:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// MFARecoveryCode is a one time code that signs the user in when the app is unavailable.
+// Only the code's hash is stored.
+type MFARecoveryCode struct {
+	ID        uint       `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	UserID    uint       `json:"user_id" gorm:"index; not null" example:"1"`
+	Hash      string     `json:"-" gorm:"type:varchar(64); not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	CreatedAt time.Time  `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// MFAChallenge is a login whose password was checked and that waits for the second factor
+type MFAChallenge struct {
+	ID              uint       `json:"id" gorm:"primary_key;auto_increment" example:"1"`
+	UserID          uint       `json:"user_id" gorm:"index; not null" example:"1"`
+	Attempts        uint       `json:"attempts" gorm:"default:0" example:"0"`
+	EmailCodeHash   string     `json:"-" gorm:"type:varchar(64); default:null"`
+	EmailCodeSentAt *time.Time `json:"email_code_sent_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	ExpiresAt       time.Time  `json:"expires_at" gorm:"index; not null" example:"2021-01-01T00:10:00Z"`
+	CreatedAt       time.Time  `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+const (
+	// MFAChallengeTTL is how long the second factor can be entered after the password
+	MFAChallengeTTL = 10 * time.Minute
+	// MFAMaxAttempts wrong codes end the challenge, the user has to log in again
+	MFAMaxAttempts = 5
+	// MFAUserMaxAttempts codes without an accepted one lock the user's second factor for
+	// MFALockout, however many logins they were spread over
+	MFAUserMaxAttempts = 10
+	MFALockout         = 15 * time.Minute
+	// MFAEmailCodeInterval is the least time between two email codes of a challenge
+	MFAEmailCodeInterval = time.Minute
+	// MFARecoveryCodeCount codes are generated at a time
+	MFARecoveryCodeCount = 10
+)
+
+// GetUserMFA returns the user's authenticator, confirmed or not
+func GetUserMFA(userID uint) (*UserMFA, error) {
+	var mfa UserMFA
+	if err := database.DB.Where("user_id = ?", userID).First(&mfa).Error; err != nil {
+		return nil, err
+	}
+	return &mfa, nil
+}
+
+// IsMFAEnabled reports whether the user has a confirmed authenticator
+func IsMFAEnabled(userID uint) bool {
+	mfa, err := GetUserMFA(userID)
+	return err == nil && mfa.Enabled()
+}
+
+func (m UserMFA) Enabled() bool {
+	return m.ConfirmedAt != nil
+}
+
+// StartUserMFA gives the user a new, unconfirmed secret, replacing an unconfirmed one
+func StartUserMFA(userID uint) (*UserMFA, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	mfa := &UserMFA{
+		UserID: userID,
+		Secret: secret,
+	}
+
+	db := database.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"secret":         secret,
+			"confirmed_at":   nil,
+			"email_fallback": false,
+			"last_step":      0,
+			"updated_at":     time.Now(),
+		}),
+	})
+	if err := db.Create(mfa).Error; err != nil {
+		return nil, err
+	}
+
+	return GetUserMFA(userID)
+}
+
+// VerifyTOTP checks a code of the user's app, each code is accepted once
+func (m *UserMFA) VerifyTOTP(code string) bool {
+	step, ok := totp.Validate(m.Secret, code, time.Now())
+	if !ok || step <= m.LastStep {
+		return false
+	}
+
+	// The condition keeps two requests with the same code from both passing
+	db := database.DB.Model(&UserMFA{}).Where("id = ? AND last_step < ?", m.ID, step)
+	result := db.UpdateColumn("last_step", step)
+	if result.Error != nil || result.RowsAffected != 1 {
+		return false
+	}
+
+	m.LastStep = step
+	return true
+}
+
+// RecordMFAAttempt counts a code tried for the user, it returns false while the user is
+// locked out. The condition keeps parallel requests from going over the limit.
+func (m *UserMFA) RecordMFAAttempt() (bool, error) {
+	now := time.Now()
+
+	db := database.DB.Model(&UserMFA{}).Where("id = ?", m.ID)
+	db = db.Where("attempts < ? OR last_attempt_at < ?", MFAUserMaxAttempts, now.Add(-MFALockout))
+	result := db.UpdateColumns(map[string]interface{}{
+		"attempts":        gorm.Expr("CASE WHEN attempts < ? THEN attempts + 1 ELSE 1 END", MFAUserMaxAttempts),
+		"last_attempt_at": now,
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ResetMFAAttempts clears the user's attempts after an accepted code
+func (m *UserMFA) ResetMFAAttempts() error {
+	m.Attempts = 0
+	return database.DB.Model(&UserMFA{}).Where("id = ?", m.ID).UpdateColumn("attempts", 0).Error
+}
+
+// ConfirmUserMFA turns MFA on
+func (m *UserMFA) ConfirmUserMFA() error {
+	now := time.Now()
+	m.ConfirmedAt = &now
+
+	// The counters are only changed with conditional updates
+	if err := database.DB.Omit("LastStep", "Attempts", "LastAttemptAt").Save(&m).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *UserMFA) UpdateUserMFA() error {
+	if err := database.DB.Omit("LastStep", "Attempts", "LastAttemptAt").Save(&m).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteUserMFA turns MFA off, removing the user's authenticator, recovery codes and
+// pending challenges
+func DeleteUserMFA(userID uint) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&UserMFA{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&MFAChallenge{}).Error
+	})
+}
+
+// GenerateMFARecoveryCodes replaces the user's recovery codes and returns the new ones
+func GenerateMFARecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, MFARecoveryCodeCount)
+	rows := make([]MFARecoveryCode, MFARecoveryCodeCount)
+	for i := range codes {
+		secret := make([]byte, 10)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(secret))
+		codes[i] = code[:8] + "-" + code[8:]
+		rows[i] = MFARecoveryCode{
+			UserID: userID,
+			Hash:   hashMFACode(codes[i]),
+		}
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// UseMFARecoveryCode spends one of the user's unused recovery codes
+func UseMFARecoveryCode(userID uint, code string) bool {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if len(code) == 16 {
+		code = code[:8] + "-" + code[8:]
+	}
+
+	db := database.DB.Model(&MFARecoveryCode{})
+	db = db.Where("user_id = ? AND hash = ? AND used_at IS NULL", userID, hashMFACode(code))
+	result := db.UpdateColumn("used_at", time.Now())
+	return result.Error == nil && result.RowsAffected == 1
+}
+
+// CountMFARecoveryCodes returns how many of the user's recovery codes are unused
+func CountMFARecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	db := database.DB.Model(&MFARecoveryCode{})
+	db = db.Where("user_id = ? AND used_at IS NULL", userID)
+	if err := db.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (ch *MFAChallenge) CreateMFAChallenge() error {
+	ch.ExpiresAt = time.Now().Add(MFAChallengeTTL)
+	if err := database.DB.Create(&ch).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetMFAChallenge returns the challenge if it has not expired
+func GetMFAChallenge(id uint) (*MFAChallenge, error) {
+	var challenge MFAChallenge
+	db := database.DB.Where("id = ? AND expires_at > ?", id, time.Now())
+	if err := db.First(&challenge).Error; err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// RecordMFAAttempt counts an attempt at the challenge, it returns false once the attempts
+// are used up. The condition keeps parallel requests from going over the limit.
+func (ch *MFAChallenge) RecordMFAAttempt() (bool, error) {
+	db := database.DB.Model(&MFAChallenge{}).Where("id = ? AND attempts < ?", ch.ID, MFAMaxAttempts)
+	result := db.UpdateColumn("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected != 1 {
+		return false, nil
+	}
+
+	ch.Attempts++
+	return true, nil
+}
+
+// CreateEmailCode gives the challenge a new six digit email code and returns it
+func (ch *MFAChallenge) CreateEmailCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+
+	code := fmt.Sprintf("%06d", n.Int64())
+	now := time.Now()
+	ch.EmailCodeHash = hashMFACode(fmt.Sprintf("%d:%s", ch.ID, code))
+	ch.EmailCodeSentAt = &now
+
+	// Only the code columns, a wrong code counted meanwhile must not be overwritten
+	db := database.DB.Model(&MFAChallenge{})
+	db = db.Where("id = ?", ch.ID)
+	if err := db.UpdateColumns(map[string]interface{}{
+		"email_code_hash":    ch.EmailCodeHash,
+		"email_code_sent_at": ch.EmailCodeSentAt,
+	}).Error; err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// CheckEmailCode reports whether the code is the challenge's email code
+func (ch MFAChallenge) CheckEmailCode(code string) bool {
+	if ch.EmailCodeHash == "" {
+		return false
+	}
+
+	hash := hashMFACode(fmt.Sprintf("%d:%s", ch.ID, strings.TrimSpace(code)))
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(ch.EmailCodeHash)) == 1
+}
+
+func (ch *MFAChallenge) DeleteMFAChallenge() error {
+	if err := database.DB.Delete(&ch).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func hashMFACode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -19,10 +19,13 @@ type User struct {
 	Role              string       `json:"role" gorm:"not null" example:"admin"`
 	DOB               string       `json:"dob" gorm:"not null" example:"2000-01-01"`
 	Location          string       `json:"location" gorm:"not null" example:"Dallas, TX"`
+	Address           string       `json:"address" gorm:"null" example:"123 Main St"`
 	City              string       `json:"city" gorm:"null" example:"Dallas"`
 	ZipCode           string       `json:"zipcode" gorm:"null" example:"32343"`
 	State             string       `json:"state" gorm:"null" example:"TX"`
 	Country           string       `json:"country" gorm:"null" example:"USA"`
+	Timezone          string       `json:"timezone" gorm:"null" example:"America/Chicago"`
+	MRN               string       `json:"mrn" gorm:"null; index" example:"A-10023"`
 	AvatarSRC         string       `json:"avatar_src" gorm:"not null" example:"https://cdn.med-kick.com/xxx.jpg"`
 	InsuranceProvider string       `json:"insurance_provider" gorm:"not null" example:"Aetna"`
 	InsuranceID       string       `json:"insurance_id" gorm:"not null" example:"123456789"`
@@ -57,6 +60,10 @@ type DeviceTelemetryDataResponse struct {
 	SampleType   string `json:"sample_type"`
 	Meal         string `json:"meal"`
 
+	Quality      ReadingQuality `json:"quality"`
+	QualityFlags string         `json:"quality_flags,omitempty"`
+	Source       ReadingSource  `json:"source"`
+
 	DeviceID   uint      `json:"device_id"`
 	MeasuredAt time.Time `json:"measured_at"`
 	CreatedAt  time.Time `json:"created_at"`
@@ -112,10 +119,13 @@ type UserResponse struct {
 	Role              string             `json:"role"`
 	DOB               string             `json:"dob"`
 	Location          string             `json:"location"`
+	Address           string             `json:"address"`
 	City              string             `json:"city"`
 	ZipCode           string             `json:"zipcode"`
 	State             string             `json:"state"`
 	Country           string             `json:"country"`
+	Timezone          string             `json:"timezone"`
+	MRN               string             `json:"mrn"`
 	AvatarSrc         string             `json:"avatar_src"`
 	InsuranceProvider string             `json:"insurance_provider"`
 	InsuranceID       string             `json:"insurance_id"`
@@ -500,6 +510,9 @@ func (user *User) SanitizedUserResponse() UserResponse {
 				TestPaper:          telemetry.TestPaper,
 				SampleType:         telemetry.SampleType,
 				Meal:               telemetry.Meal,
+				Quality:            telemetry.Quality,
+				QualityFlags:       telemetry.QualityFlags,
+				Source:             telemetry.Source,
 				DeviceID:           telemetry.DeviceID,
 				MeasuredAt:         telemetry.MeasuredAt,
 				CreatedAt:          telemetry.CreatedAt,
@@ -567,10 +580,13 @@ func (user *User) SanitizedUserResponse() UserResponse {
 		Role:              user.Role,
 		DOB:               user.DOB,
 		Location:          user.Location,
+		Address:           user.Address,
 		City:              user.City,
 		ZipCode:           user.ZipCode,
 		State:             user.State,
 		Country:           user.Country,
+		Timezone:          user.Timezone,
+		MRN:               user.MRN,
 		AvatarSrc:         user.AvatarSRC,
 		InsuranceProvider: user.InsuranceProvider,
 		InsuranceID:       user.InsuranceID,
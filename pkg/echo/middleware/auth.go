@@ -7,8 +7,13 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// Auth signs the request in from an "Authorization: Bearer" API key, or else the session cookie
 func Auth(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
+		if token, ok := bearerToken(c); ok {
+			return authenticateAPIKey(c, next, token)
+		}
+
 		session, err := Store.Get(c.Request(), "medkick-session")
 		if err != nil {
 			http.Error(c.Response(), "Failed to get session", http.StatusInternalServerError)
@@ -30,6 +35,10 @@ func Auth(next echo.HandlerFunc) echo.HandlerFunc {
 			return next(c)
 		}
 
+		if enroll, _ := session.Values["mfa-enrollment"].(bool); enroll && !mfaEnrollmentPath(c.Request().URL.Path) {
+			return c.JSON(http.StatusForbidden, map[string]string{"message": "Your organization requires multi-factor authentication, set it up to continue."})
+		}
+
 		if err == nil {
 			c.Set("x-guest", false)
 			c.Set("x-id", userId)
@@ -79,6 +88,11 @@ func IsGuest(c echo.Context) bool {
 	return c.Get("x-guest").(bool)
 }
 
+// IsAPIKey reports whether the request was signed in with an API key rather than a session
+func IsAPIKey(c echo.Context) bool {
+	return c.Get("x-auth-type") == "api-key"
+}
+
 func GetSelf(c echo.Context) models.User {
 	return c.Get("x-user").(models.User)
 }
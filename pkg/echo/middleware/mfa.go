@@ -0,0 +1,23 @@
+package middleware
+
+import "strings"
+
+// mfaEnrollmentPaths can be called by a user whose organization requires MFA before they
+// set it up, everything else is refused until they do
+var mfaEnrollmentPaths = []string{
+	"/v1/user/mfa",
+	"/v1/auth/logout",
+}
+
+func mfaEnrollmentPath(path string) bool {
+	if path == "/v1/user" {
+		return true
+	}
+
+	for _, prefix := range mfaEnrollmentPaths {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
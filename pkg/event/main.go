@@ -0,0 +1,66 @@
+package event
+
+import (
+	"os"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+type Type string
+
+const (
+	TelemetryCreated Type = "telemetry.created"
+	AlertRaised      Type = "alert.raised"
+	AlertUpdated     Type = "alert.updated"
+	AlertResolved    Type = "alert.resolved"
+	DeviceStatus     Type = "device.status"
+)
+
+// Event is something that happened to a patient of an organization. Data is the record it
+// happened to, as it is returned by the API.
+type Event struct {
+	ID             uint64      `json:"id" example:"1"`
+	Type           Type        `json:"type" example:"telemetry.created"`
+	OrganizationID uint        `json:"organization_id" example:"1"`
+	PatientID      uint        `json:"patient_id" example:"1"`
+	Data           interface{} `json:"data"`
+	CreatedAt      time.Time   `json:"created_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// Filter decides whether a subscriber receives an event
+type Filter func(e Event) bool
+
+// Broker fans events out to subscribers. The in-process broker only reaches subscribers of
+// this instance, a broker backed by a message queue can take its place when the API runs on
+// more than one instance.
+type Broker interface {
+	Publish(e Event)
+	// Subscribe returns the channel events matching the filter are delivered on and a
+	// function that ends the subscription
+	Subscribe(filter Filter) (<-chan Event, func())
+}
+
+var Bus Broker = NewMemoryBroker()
+
+// Setup picks the broker from the environment
+func Setup() {
+	switch os.Getenv("EVENT_BROKER") {
+	case "", "memory":
+		Bus = NewMemoryBroker()
+	default:
+		log.Warnf("Unknown event broker %s, using the in-process broker", os.Getenv("EVENT_BROKER"))
+		Bus = NewMemoryBroker()
+	}
+}
+
+// Publish sends an event through the configured broker
+func Publish(eventType Type, organizationID, patientID uint, data interface{}) {
+	Bus.Publish(Event{
+		Type:           eventType,
+		OrganizationID: organizationID,
+		PatientID:      patientID,
+		Data:           data,
+		CreatedAt:      time.Now().UTC(),
+	})
+}
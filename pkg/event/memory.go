@@ -0,0 +1,78 @@
+package event
+
+import (
+	"sync"
+
+	"github.com/labstack/gommon/log"
+)
+
+// subscriberBuffer is how many events a slow subscriber may fall behind before events are
+// dropped for it
+const subscriberBuffer = 64
+
+type subscriber struct {
+	ch     chan Event
+	filter Filter
+}
+
+// MemoryBroker delivers events to the subscribers of this process. Publishing never blocks,
+// a subscriber that doesn't keep up misses events.
+type MemoryBroker struct {
+	mu          sync.RWMutex
+	lastID      uint64
+	nextSub     int
+	subscribers map[int]*subscriber
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		subscribers: make(map[int]*subscriber),
+	}
+}
+
+func (b *MemoryBroker) Publish(e Event) {
+	b.mu.Lock()
+	b.lastID++
+	e.ID = b.lastID
+	b.mu.Unlock()
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for id, s := range b.subscribers {
+		if s.filter != nil && !s.filter(e) {
+			continue
+		}
+
+		select {
+		case s.ch <- e:
+		default:
+			log.Warnf("Event subscriber %d is full, dropping event %d", id, e.ID)
+		}
+	}
+}
+
+func (b *MemoryBroker) Subscribe(filter Filter) (<-chan Event, func()) {
+	s := &subscriber{
+		ch:     make(chan Event, subscriberBuffer),
+		filter: filter,
+	}
+
+	b.mu.Lock()
+	b.nextSub++
+	id := b.nextSub
+	b.subscribers[id] = s
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, id)
+			b.mu.Unlock()
+			close(s.ch)
+		})
+	}
+
+	return s.ch, unsubscribe
+}
@@ -1,10 +1,10 @@
 package mio_api
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
+	"context"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -20,67 +20,54 @@ type Device struct {
 }
 
 type DeviceResponse struct {
-	Items []Device `json:"items"`
+	Items     []Device `json:"items"`
+	NextToken string   `json:"nextToken,omitempty"`
 }
 
-func (c *Client) GetDeviceList() (*DeviceResponse, error) {
-	url := fmt.Sprintf("%s/devices", baseURL)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Add("x-api-key", c.APIKey)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API responded with status code %d", resp.StatusCode)
-	}
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+// ListDevicesPage returns one page of devices. Pass the NextToken of the previous page to
+// get the next one; an empty NextToken in the response means it was the last page.
+func (c *Client) ListDevicesPage(ctx context.Context, nextToken string) (*DeviceResponse, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(c.PageSize))
+	if nextToken != "" {
+		query.Set("nextToken", nextToken)
 	}
 
 	var deviceResponse DeviceResponse
-	if err := json.Unmarshal(bodyBytes, &deviceResponse); err != nil {
+	if err := c.do(ctx, http.MethodGet, "/devices?"+query.Encode(), nil, &deviceResponse); err != nil {
 		return nil, err
 	}
 
 	return &deviceResponse, nil
 }
 
-func (c *Client) GetDevice(deviceId string) (*Device, error) {
-	url := fmt.Sprintf("%s/devices/%s", baseURL, deviceId)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
+// GetDeviceList returns every device on the account, following the pages
+func (c *Client) GetDeviceList(ctx context.Context) (*DeviceResponse, error) {
+	deviceResponse := &DeviceResponse{
+		Items: []Device{},
 	}
-	req.Header.Add("x-api-key", c.APIKey)
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	nextToken := ""
+	for {
+		page, err := c.ListDevicesPage(ctx, nextToken)
+		if err != nil {
+			return nil, err
+		}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API responded with status code %d", resp.StatusCode)
-	}
+		deviceResponse.Items = append(deviceResponse.Items, page.Items...)
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+		if page.NextToken == "" || page.NextToken == nextToken {
+			break
+		}
+		nextToken = page.NextToken
 	}
 
+	return deviceResponse, nil
+}
+
+func (c *Client) GetDevice(ctx context.Context, deviceId string) (*Device, error) {
 	var device Device
-	if err := json.Unmarshal(bodyBytes, &device); err != nil {
+	if err := c.do(ctx, http.MethodGet, "/devices/"+url.PathEscape(deviceId), nil, &device); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,153 @@
+package mio_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIError is returned when Mio answers a request with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Message    string
+	Method     string
+	Path       string
+	// RetryAfter is the delay Mio asked for with a 429, if any
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("mio: %s %s responded with status code %d", e.Method, e.Path, e.StatusCode)
+	}
+	return fmt.Sprintf("mio: %s %s responded with status code %d: %s", e.Method, e.Path, e.StatusCode, e.Message)
+}
+
+// Temporary reports whether the request may succeed if it is sent again
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// IsNotFound reports whether err is a 404 from Mio
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// do sends a request to Mio and decodes the JSON response into out. Network errors, 429 and
+// 5xx responses are retried with exponential backoff until the context is done. Requests that
+// change data are only retried on 429, so an order Mio may have accepted is never placed twice.
+func (c *Client) do(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	var reqBytes []byte
+	if body != nil {
+		var err error
+		reqBytes, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	backoff := c.Backoff
+	var lastErr error
+
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		if attempt > 0 {
+			wait := backoff
+			var apiErr *APIError
+			if errors.As(lastErr, &apiErr) && apiErr.RetryAfter > 0 {
+				wait = apiErr.RetryAfter
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+			backoff *= 2
+		}
+
+		var retry bool
+		retry, lastErr = c.attempt(ctx, method, path, reqBytes, out)
+		if lastErr == nil || !retry {
+			return lastErr
+		}
+	}
+
+	return lastErr
+}
+
+func (c *Client) attempt(ctx context.Context, method string, path string, reqBytes []byte, out interface{}) (bool, error) {
+	var reqBody io.Reader
+	if reqBytes != nil {
+		reqBody = bytes.NewReader(reqBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reqBody)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Add("x-api-key", c.APIKey)
+	req.Header.Add("Accept", "application/json")
+	if reqBytes != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	idempotent := method == http.MethodGet
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		// Retry network errors unless the caller gave up
+		return idempotent && ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return idempotent, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    errorMessage(respBytes),
+			Method:     method,
+			Path:       path,
+		}
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			apiErr.RetryAfter = time.Duration(seconds) * time.Second
+		}
+		retry := apiErr.StatusCode == http.StatusTooManyRequests || (idempotent && apiErr.Temporary())
+		return retry, apiErr
+	}
+
+	if out == nil || len(respBytes) == 0 {
+		return false, nil
+	}
+
+	return false, json.Unmarshal(respBytes, out)
+}
+
+// errorMessage keeps the message Mio sent with a failed request so it can be shown to the user
+func errorMessage(body []byte) string {
+	var apiError struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &apiError); err == nil && apiError.Message != "" {
+		return apiError.Message
+	}
+
+	message := strings.TrimSpace(string(body))
+	if len(message) > 200 {
+		message = message[:200]
+	}
+	return message
+}
@@ -1,14 +1,93 @@
 package mio_api
 
-const baseURL = "https://api.connect.mio-labs.com/v1"
+import (
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	DefaultBaseURL    = "https://api.connect.mio-labs.com/v1"
+	DefaultTimeout    = 15 * time.Second
+	DefaultMaxRetries = 3
+	DefaultBackoff    = 500 * time.Millisecond
+	DefaultPageSize   = 100
+)
 
 type Client struct {
-	APIKey string
+	APIKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+	MaxRetries int
+	Backoff    time.Duration
+	PageSize   int
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithBaseURL points the client at another API host, e.g. the fake Mio server
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithTimeout sets the timeout of each attempt of a request
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		httpClient := http.Client{}
+		if c.HTTPClient != nil {
+			httpClient = *c.HTTPClient
+		}
+		httpClient.Timeout = timeout
+		c.HTTPClient = &httpClient
+	}
 }
 
-// NewClient initializes a new API client
-func NewClient(apiKey string) *Client {
-	return &Client{
-		APIKey: apiKey,
+// WithRetries sets how often a request that failed with a network error, 429 or 5xx is
+// retried, and the backoff before the first retry. The backoff doubles after each retry.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.MaxRetries = maxRetries
+		c.Backoff = backoff
 	}
 }
+
+// WithPageSize sets how many devices are requested per page
+func WithPageSize(pageSize int) Option {
+	return func(c *Client) {
+		c.PageSize = pageSize
+	}
+}
+
+// NewClient initializes a new API client. MIO_API_URL overrides the default base URL so the
+// client can be pointed at a local fake of the API.
+func NewClient(apiKey string, opts ...Option) *Client {
+	baseURL := os.Getenv("MIO_API_URL")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	c := &Client{
+		APIKey:     apiKey,
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		MaxRetries: DefaultMaxRetries,
+		Backoff:    DefaultBackoff,
+		PageSize:   DefaultPageSize,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
@@ -1,10 +1,17 @@
 package mio_api
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
+	"context"
 	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	ShipmentPending   = "pending"
+	ShipmentShipped   = "shipped"
+	ShipmentDelivered = "delivered"
+	ShipmentCancelled = "cancelled"
 )
 
 type ShipmentAddress struct {
@@ -28,29 +35,38 @@ type ShipmentRequest struct {
 	Items   []ShipmentItem  `json:"items"`
 }
 
-func (c *Client) ShipItem(req ShipmentRequest) error {
-	url := fmt.Sprintf("%s/shipments", baseURL)
+// ShipmentDevice is a device that was packed into a shipment
+type ShipmentDevice struct {
+	DeviceID    string `json:"deviceId"`
+	IMEI        string `json:"imei"`
+	ModelNumber string `json:"modelNumber"`
+}
 
-	reqBytes, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
+type Shipment struct {
+	ID             string           `json:"id"`
+	Status         string           `json:"status"`
+	Carrier        string           `json:"carrier"`
+	TrackingNumber string           `json:"trackingNumber"`
+	Items          []ShipmentItem   `json:"items"`
+	Devices        []ShipmentDevice `json:"devices"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	UpdatedAt      time.Time        `json:"updatedAt"`
+}
 
-	httpReq, err := http.NewRequest("POST", url, bytes.NewReader(reqBytes))
-	if err != nil {
-		return err
+func (c *Client) ShipItem(ctx context.Context, req ShipmentRequest) (*Shipment, error) {
+	var shipment Shipment
+	if err := c.do(ctx, http.MethodPost, "/shipments", req, &shipment); err != nil {
+		return nil, err
 	}
-	httpReq.Header.Add("x-api-key", c.APIKey)
 
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+	return &shipment, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API responded with status code %d", resp.StatusCode)
+func (c *Client) GetShipment(ctx context.Context, shipmentId string) (*Shipment, error) {
+	var shipment Shipment
+	if err := c.do(ctx, http.MethodGet, "/shipments/"+url.PathEscape(shipmentId), nil, &shipment); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &shipment, nil
 }
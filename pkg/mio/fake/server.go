@@ -0,0 +1,327 @@
+package mio_fake
+
+import (
+	mioApi "MedKick-backend/pkg/mio/api"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is an in-memory stand-in for the Mio Connect device and shipment API. Use Start to
+// run it in-process, or run cmd/mio-fake and point MIO_API_URL at it.
+type Server struct {
+	APIKey string
+
+	mu          sync.Mutex
+	shipments   map[string]*mioApi.Shipment
+	devices     map[string]mioApi.Device
+	deviceOrder []string
+	nextID      int
+	failCode    int
+	failMsg     string
+	failNext    int
+	failNextMsg string
+	failNextN   int
+	retryAfter  int
+}
+
+// Start runs the fake on a local port and returns a client pointed at it. Retries are kept
+// fast so failure scenarios don't slow tests down. Call close when done.
+func Start(apiKey string, opts ...mioApi.Option) (*Server, *mioApi.Client, func()) {
+	s := NewServer(apiKey)
+	httpServer := httptest.NewServer(s)
+
+	opts = append([]mioApi.Option{
+		mioApi.WithBaseURL(httpServer.URL + "/v1"),
+		mioApi.WithRetries(mioApi.DefaultMaxRetries, time.Millisecond),
+	}, opts...)
+
+	return s, mioApi.NewClient(apiKey, opts...), httpServer.Close
+}
+
+func NewServer(apiKey string) *Server {
+	return &Server{
+		APIKey:    apiKey,
+		shipments: map[string]*mioApi.Shipment{},
+		devices:   map[string]mioApi.Device{},
+	}
+}
+
+// AddDevice registers a device on the account as if it had been provisioned by Mio
+func (s *Server) AddDevice(device mioApi.Device) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addDevice(device)
+}
+
+func (s *Server) addDevice(device mioApi.Device) {
+	if _, ok := s.devices[device.DeviceID]; !ok {
+		s.deviceOrder = append(s.deviceOrder, device.DeviceID)
+	}
+	s.devices[device.DeviceID] = device
+}
+
+// RemoveDevice takes a device off the account
+func (s *Server) RemoveDevice(deviceID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.devices, deviceID)
+	for i, id := range s.deviceOrder {
+		if id == deviceID {
+			s.deviceOrder = append(s.deviceOrder[:i], s.deviceOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+// FailNext makes the next count requests to any endpoint fail with the given status, e.g.
+// 503 to exercise retries or 429 to exercise rate limiting
+func (s *Server) FailNext(count int, statusCode int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failNextN = count
+	s.failNext = statusCode
+	s.failNextMsg = message
+}
+
+// SetRetryAfter makes failed 429 responses ask the client to wait the given seconds
+func (s *Server) SetRetryAfter(seconds int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.retryAfter = seconds
+}
+
+// FailShipments makes every following shipment order fail with the given status and message.
+// A status of 0 lets orders succeed again.
+func (s *Server) FailShipments(statusCode int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failCode = statusCode
+	s.failMsg = message
+}
+
+// SetShipmentStatus moves a shipment forward the way the carrier would
+func (s *Server) SetShipmentStatus(id string, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shipment, ok := s.shipments[id]
+	if !ok {
+		return fmt.Errorf("shipment %s not found", id)
+	}
+
+	switch status {
+	case mioApi.ShipmentPending, mioApi.ShipmentShipped, mioApi.ShipmentDelivered, mioApi.ShipmentCancelled:
+	default:
+		return fmt.Errorf("unknown shipment status %s", status)
+	}
+
+	shipment.Status = status
+	shipment.UpdatedAt = time.Now()
+	if status != mioApi.ShipmentPending && shipment.TrackingNumber == "" {
+		shipment.Carrier = "USPS"
+		shipment.TrackingNumber = fmt.Sprintf("9400%018d", s.nextID)
+	}
+
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("x-api-key") != s.APIKey {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	s.mu.Lock()
+	if s.failNextN > 0 {
+		s.failNextN--
+		statusCode, message, retryAfter := s.failNext, s.failNextMsg, s.retryAfter
+		s.mu.Unlock()
+
+		if statusCode == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		}
+		writeError(w, statusCode, message)
+		return
+	}
+	s.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1"), "/"), "/")
+
+	switch {
+	case len(parts) == 1 && parts[0] == "shipments" && r.Method == http.MethodPost:
+		s.createShipment(w, r)
+	case len(parts) == 2 && parts[0] == "shipments" && r.Method == http.MethodGet:
+		s.getShipment(w, parts[1])
+	case len(parts) == 3 && parts[0] == "shipments" && parts[2] == "status" && r.Method == http.MethodPost:
+		s.updateShipmentStatus(w, r, parts[1])
+	case len(parts) == 1 && parts[0] == "devices" && r.Method == http.MethodGet:
+		s.listDevices(w, r)
+	case len(parts) == 2 && parts[0] == "devices" && r.Method == http.MethodGet:
+		s.getDevice(w, parts[1])
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) createShipment(w http.ResponseWriter, r *http.Request) {
+	var req mioApi.ShipmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Address.AddressLine == "" || req.Address.City == "" || req.Address.State == "" || req.Address.ZipCode == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Address is incomplete")
+		return
+	}
+	if len(req.Items) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "Shipment has no items")
+		return
+	}
+	for _, item := range req.Items {
+		if item.DeviceId == "" || item.Count == 0 {
+			writeError(w, http.StatusUnprocessableEntity, "Shipment item needs an id and a count")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failCode != 0 {
+		writeError(w, s.failCode, s.failMsg)
+		return
+	}
+
+	now := time.Now()
+	s.nextID++
+	shipment := &mioApi.Shipment{
+		ID:        fmt.Sprintf("shp_%d", s.nextID),
+		Status:    mioApi.ShipmentPending,
+		Items:     req.Items,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	for _, item := range req.Items {
+		for i := uint(0); i < item.Count; i++ {
+			device := mioApi.Device{
+				DeviceID:        fmt.Sprintf("dev_%d_%d", s.nextID, len(shipment.Devices)+1),
+				IMEI:            fmt.Sprintf("35%013d", s.nextID*1000+len(shipment.Devices)+1),
+				Status:          "active",
+				ModelNumber:     item.DeviceId,
+				FirmwareVersion: "1.0.0",
+				SerialNumber:    fmt.Sprintf("SN%08d", s.nextID*1000+len(shipment.Devices)+1),
+				CreatedAt:       now,
+			}
+			s.addDevice(device)
+			shipment.Devices = append(shipment.Devices, mioApi.ShipmentDevice{
+				DeviceID:    device.DeviceID,
+				IMEI:        device.IMEI,
+				ModelNumber: device.ModelNumber,
+			})
+		}
+	}
+
+	s.shipments[shipment.ID] = shipment
+	writeJSON(w, http.StatusCreated, shipment)
+}
+
+func (s *Server) getShipment(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shipment, ok := s.shipments[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Shipment not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, shipment)
+}
+
+func (s *Server) updateShipmentStatus(w http.ResponseWriter, r *http.Request, id string) {
+	var req struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := s.SetShipmentStatus(id, req.Status); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.getShipment(w, id)
+}
+
+// listDevices pages through the devices in the order they were added. The next token is the
+// offset of the next page.
+func (s *Server) listDevices(w http.ResponseWriter, r *http.Request) {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = mioApi.DefaultPageSize
+	}
+
+	offset := 0
+	if token := r.URL.Query().Get("nextToken"); token != "" {
+		offset, err = strconv.Atoi(token)
+		if err != nil || offset < 0 {
+			writeError(w, http.StatusBadRequest, "Invalid nextToken")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	response := mioApi.DeviceResponse{
+		Items: []mioApi.Device{},
+	}
+	for i := offset; i < len(s.deviceOrder) && i < offset+limit; i++ {
+		response.Items = append(response.Items, s.devices[s.deviceOrder[i]])
+	}
+	if offset+limit < len(s.deviceOrder) {
+		response.NextToken = strconv.Itoa(offset + limit)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) getDevice(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	device, ok := s.devices[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Device not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, device)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]string{
+		"message": message,
+	})
+}
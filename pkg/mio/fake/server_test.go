@@ -0,0 +1,207 @@
+package mio_fake
+
+import (
+	mioApi "MedKick-backend/pkg/mio/api"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func addDevices(s *Server, count int) {
+	for i := 1; i <= count; i++ {
+		s.AddDevice(mioApi.Device{
+			DeviceID:    fmt.Sprintf("dev_%d", i),
+			IMEI:        fmt.Sprintf("86%013d", i),
+			Status:      "active",
+			ModelNumber: "TBM-2092-G",
+		})
+	}
+}
+
+func TestGetDeviceListFollowsPages(t *testing.T) {
+	s, client, stop := Start("test", mioApi.WithPageSize(2))
+	defer stop()
+	addDevices(s, 5)
+
+	page, err := client.ListDevicesPage(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 2 || page.NextToken == "" {
+		t.Fatalf("first page has %d devices and next token %q, want 2 and a token", len(page.Items), page.NextToken)
+	}
+
+	devices, err := client.GetDeviceList(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices.Items) != 5 {
+		t.Fatalf("got %d devices, want 5", len(devices.Items))
+	}
+	for i, d := range devices.Items {
+		if want := fmt.Sprintf("dev_%d", i+1); d.DeviceID != want {
+			t.Errorf("device %d is %s, want %s", i, d.DeviceID, want)
+		}
+	}
+}
+
+func TestGetRetriesServerErrors(t *testing.T) {
+	s, client, stop := Start("test")
+	defer stop()
+	addDevices(s, 1)
+
+	s.FailNext(mioApi.DefaultMaxRetries, http.StatusServiceUnavailable, "Try again")
+	if _, err := client.GetDevice(context.Background(), "dev_1"); err != nil {
+		t.Fatalf("request failed after retries: %s", err)
+	}
+
+	s.FailNext(mioApi.DefaultMaxRetries+1, http.StatusServiceUnavailable, "Try again")
+	_, err := client.GetDevice(context.Background(), "dev_1")
+
+	var apiErr *mioApi.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got %v, want an APIError once retries run out", err)
+	}
+	if apiErr.StatusCode != http.StatusServiceUnavailable || apiErr.Message != "Try again" || !apiErr.Temporary() {
+		t.Errorf("got %+v, want a temporary 503 with the fake's message", apiErr)
+	}
+}
+
+func TestPostIsNotRetriedOnServerErrors(t *testing.T) {
+	s, client, stop := Start("test")
+	defer stop()
+
+	s.FailNext(1, http.StatusBadGateway, "Upstream failed")
+	_, err := client.ShipItem(context.Background(), shipmentRequest())
+
+	var apiErr *mioApi.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("got %v, want the 502 of the first attempt", err)
+	}
+	if _, err := client.GetShipment(context.Background(), "shp_1"); !mioApi.IsNotFound(err) {
+		t.Errorf("got %v, want no shipment to have been placed", err)
+	}
+}
+
+func TestRateLimitHonorsRetryAfter(t *testing.T) {
+	s, client, stop := Start("test")
+	defer stop()
+
+	s.SetRetryAfter(1)
+	s.FailNext(1, http.StatusTooManyRequests, "Slow down")
+
+	started := time.Now()
+	if _, err := client.ShipItem(context.Background(), shipmentRequest()); err != nil {
+		t.Fatalf("rate limited order was not retried: %s", err)
+	}
+	if waited := time.Since(started); waited < time.Second {
+		t.Errorf("retried after %s, want at least the 1s Retry-After", waited)
+	}
+}
+
+func TestRetryStopsWithContext(t *testing.T) {
+	s, client, stop := Start("test")
+	defer stop()
+
+	s.SetRetryAfter(10)
+	s.FailNext(1, http.StatusTooManyRequests, "Slow down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetDevice(ctx, "dev_1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want the context's deadline", err)
+	}
+}
+
+func TestAPIErrorCarriesRequestAndMessage(t *testing.T) {
+	_, client, stop := Start("test")
+	defer stop()
+
+	req := shipmentRequest()
+	req.Address.City = ""
+	_, err := client.ShipItem(context.Background(), req)
+
+	var apiErr *mioApi.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got %v, want an APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusUnprocessableEntity || apiErr.Method != http.MethodPost || apiErr.Path != "/shipments" {
+		t.Errorf("got %+v, want a 422 for POST /shipments", apiErr)
+	}
+	if apiErr.Message != "Address is incomplete" || apiErr.Temporary() {
+		t.Errorf("got message %q, want the fake's message on a permanent error", apiErr.Message)
+	}
+
+	if _, err := client.GetDevice(context.Background(), "missing"); !mioApi.IsNotFound(err) {
+		t.Errorf("got %v, want a not found error", err)
+	}
+
+	_, wrongKey, stopWrongKey := Start("test")
+	defer stopWrongKey()
+	wrongKey.APIKey = "wrong"
+	if _, err := wrongKey.GetDevice(context.Background(), "dev_1"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("got %v, want a 401 for a wrong key", err)
+	}
+}
+
+func TestShipmentRoundTrip(t *testing.T) {
+	s, client, stop := Start("test")
+	defer stop()
+
+	ctx := context.Background()
+	shipment, err := client.ShipItem(ctx, shipmentRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shipment.Status != mioApi.ShipmentPending || len(shipment.Devices) != 1 || shipment.TrackingNumber != "" {
+		t.Fatalf("got %+v, want a pending shipment with one device and no tracking", shipment)
+	}
+
+	// Packed devices show up on the account
+	device, err := client.GetDevice(ctx, shipment.Devices[0].DeviceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if device.IMEI != shipment.Devices[0].IMEI || device.ModelNumber != "TBM-2092-G" {
+		t.Errorf("got device %+v, want the packed device", device)
+	}
+
+	if err := s.SetShipmentStatus(shipment.ID, mioApi.ShipmentShipped); err != nil {
+		t.Fatal(err)
+	}
+	shipment, err = client.GetShipment(ctx, shipment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shipment.Status != mioApi.ShipmentShipped || shipment.Carrier == "" || shipment.TrackingNumber == "" {
+		t.Errorf("got %+v, want a shipped shipment with carrier and tracking", shipment)
+	}
+
+	s.FailShipments(http.StatusUnprocessableEntity, "Address is not deliverable")
+	_, err = client.ShipItem(ctx, shipmentRequest())
+
+	var apiErr *mioApi.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity || apiErr.Message != "Address is not deliverable" {
+		t.Errorf("got %v, want the failure set with FailShipments", err)
+	}
+}
+
+func shipmentRequest() mioApi.ShipmentRequest {
+	return mioApi.ShipmentRequest{
+		Address: mioApi.ShipmentAddress{
+			FirstName:   "Jane",
+			LastName:    "Doe",
+			Country:     "USA",
+			ZipCode:     "75001",
+			City:        "Dallas",
+			State:       "TX",
+			AddressLine: "123 Main St",
+		},
+		Items: []mioApi.ShipmentItem{
+			{DeviceId: "TBM-2092-G", Count: 1},
+		},
+	}
+}
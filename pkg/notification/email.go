@@ -0,0 +1,12 @@
+package notification
+
+import (
+	"MedKick-backend/pkg/sendgrid"
+)
+
+// SendgridSender delivers email through the sendgrid package, which must be set up first
+type SendgridSender struct{}
+
+func (s *SendgridSender) Send(msg Message) error {
+	return sendgrid.SendEmail(msg.ToName, msg.To, msg.Subject, msg.Body)
+}
@@ -0,0 +1,15 @@
+package notification
+
+import (
+	"github.com/labstack/gommon/log"
+)
+
+// LogSender only logs the message, it is used when no real sender is configured
+type LogSender struct {
+	Channel string
+}
+
+func (s *LogSender) Send(msg Message) error {
+	log.Infof("[notification:%s] to %s <%s>: %s - %s", s.Channel, msg.ToName, msg.To, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,47 @@
+package notification
+
+import (
+	"os"
+)
+
+// Message is a single notification to one recipient. To is an email address, a phone
+// number or a webhook URL depending on the sender it is given to.
+type Message struct {
+	ToName  string
+	To      string
+	Subject string
+	Body    string
+	// Payload is sent as the JSON body of webhook notifications
+	Payload map[string]interface{}
+}
+
+// Sender delivers a message over one channel
+type Sender interface {
+	Send(msg Message) error
+}
+
+var (
+	Email   Sender = &LogSender{Channel: "email"}
+	SMS     Sender = &LogSender{Channel: "sms"}
+	Webhook Sender = &WebhookSender{}
+)
+
+// Setup picks the senders from the environment. Email and SMS fall back to the log sender
+// so alerts can be exercised locally without delivering anything.
+func Setup() {
+	switch os.Getenv("NOTIFICATION_EMAIL_SENDER") {
+	case "sendgrid":
+		Email = &SendgridSender{}
+	default:
+		Email = &LogSender{Channel: "email"}
+	}
+
+	switch os.Getenv("NOTIFICATION_SMS_SENDER") {
+	case "twilio":
+		SMS = NewTwilioSender(os.Getenv("TWILIO_ACCOUNT_SID"), os.Getenv("TWILIO_AUTH_TOKEN"), os.Getenv("TWILIO_FROM_NUMBER"))
+	default:
+		SMS = &LogSender{Channel: "sms"}
+	}
+
+	Webhook = &WebhookSender{}
+}
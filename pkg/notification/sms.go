@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const twilioBaseURL = "https://api.twilio.com/2010-04-01"
+
+// TwilioSender delivers SMS through the Twilio messages API
+type TwilioSender struct {
+	AccountSID string
+	AuthToken  string
+	From       string
+	client     *http.Client
+}
+
+func NewTwilioSender(accountSID, authToken, from string) *TwilioSender {
+	return &TwilioSender{
+		AccountSID: accountSID,
+		AuthToken:  authToken,
+		From:       from,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *TwilioSender) Send(msg Message) error {
+	endpoint := fmt.Sprintf("%s/Accounts/%s/Messages.json", twilioBaseURL, s.AccountSID)
+
+	form := url.Values{}
+	form.Set("To", msg.To)
+	form.Set("From", s.From)
+	form.Set("Body", msg.Body)
+
+	req, err := http.NewRequest("POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(s.AccountSID, s.AuthToken)
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("twilio responded with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
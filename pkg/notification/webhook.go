@@ -0,0 +1,118 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// WebhookSender posts the message payload as JSON to the URL in Message.To
+type WebhookSender struct{}
+
+var webhookClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		Proxy: nil,
+		// The address is checked again when connecting, a host that passed ValidateWebhookURL
+		// could since resolve to an internal address
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if !isPublicIP(net.ParseIP(host)) {
+					return fmt.Errorf("webhook address %s is not public", host)
+				}
+				return nil
+			},
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+	// Redirects could point the request at an internal address
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// ValidateWebhookURL makes sure a webhook target is an https URL of a public host, so alert
+// webhooks can't be pointed at the internal network
+func ValidateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return errors.New("webhook target must be a URL")
+	}
+
+	if u.Scheme != "https" {
+		return errors.New("webhook target must use https")
+	}
+
+	if u.User != nil {
+		return errors.New("webhook target can't contain credentials")
+	}
+
+	ips, err := net.LookupIP(u.Hostname())
+	if err != nil || len(ips) == 0 {
+		return errors.New("webhook target host can't be resolved")
+	}
+
+	for _, ip := range ips {
+		if !isPublicIP(ip) {
+			return errors.New("webhook target must be a public host")
+		}
+	}
+
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() && !ip.IsMulticast()
+}
+
+func (s *WebhookSender) Send(msg Message) error {
+	if err := ValidateWebhookURL(msg.To); err != nil {
+		return err
+	}
+
+	payload := msg.Payload
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+	payload["subject"] = msg.Subject
+	payload["body"] = msg.Body
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", msg.To, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
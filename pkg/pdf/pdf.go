@@ -0,0 +1,182 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// US Letter in points
+const (
+	PageWidth  = 612.0
+	PageHeight = 792.0
+)
+
+// Document is a PDF of letter sized pages drawn with the standard Helvetica fonts, enough for
+// the generated reports without a PDF library
+type Document struct {
+	pages []*Page
+}
+
+// Page collects the drawing operators of one page. Coordinates are in points from the top
+// left corner, y grows downwards.
+type Page struct {
+	content bytes.Buffer
+}
+
+type Point struct {
+	X, Y float64
+}
+
+type Color struct {
+	R, G, B float64
+}
+
+var (
+	Black = Color{0, 0, 0}
+	Gray  = Color{0.55, 0.55, 0.55}
+	Light = Color{0.9, 0.9, 0.9}
+)
+
+func New() *Document {
+	return &Document{}
+}
+
+// AddPage starts a new page at the end of the document
+func (d *Document) AddPage() *Page {
+	p := &Page{}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Text writes s with its baseline at y
+func (p *Page) Text(x, y, size float64, bold bool, color Color, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(&p.content, "BT %s rg /%s %s Tf %s %s Td (%s) Tj ET\n",
+		color.operands(), font, num(size), num(x), num(PageHeight-y), escape(s))
+}
+
+// Line strokes a straight line, dashed lines are used for goals
+func (p *Page) Line(x1, y1, x2, y2, width float64, color Color, dashed bool) {
+	dash := "[] 0 d"
+	if dashed {
+		dash = "[3 2] 0 d"
+	}
+	fmt.Fprintf(&p.content, "%s RG %s w %s %s %s m %s %s l S\n",
+		color.operands(), num(width), dash, num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// Polyline strokes a line through the points
+func (p *Page) Polyline(points []Point, width float64, color Color) {
+	if len(points) < 2 {
+		return
+	}
+	fmt.Fprintf(&p.content, "%s RG %s w [] 0 d %s %s m", color.operands(), num(width), num(points[0].X), num(PageHeight-points[0].Y))
+	for _, pt := range points[1:] {
+		fmt.Fprintf(&p.content, " %s %s l", num(pt.X), num(PageHeight-pt.Y))
+	}
+	p.content.WriteString(" S\n")
+}
+
+// Rect draws a rectangle with its top left corner at x, y, filled or outlined
+func (p *Page) Rect(x, y, w, h float64, color Color, fill bool) {
+	op := "S"
+	if fill {
+		op = "f"
+	}
+	fmt.Fprintf(&p.content, "%s RG %s rg 0.5 w [] 0 d %s %s %s %s re %s\n",
+		color.operands(), color.operands(), num(x), num(PageHeight-y-h), num(w), num(h), op)
+}
+
+// Dot fills a small square centered on the point, for charts with a single reading
+func (p *Page) Dot(pt Point, size float64, color Color) {
+	p.Rect(pt.X-size/2, pt.Y-size/2, size, size, color, true)
+}
+
+// Bytes writes the document out
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// 1 catalog, 2 page tree, 3 and 4 fonts, then a page and its content per page
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, p := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.content.Len(), p.content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// TextWidth estimates the width of s, Helvetica averages a little over half the font size
+func TextWidth(s string, size float64) float64 {
+	return float64(len([]rune(s))) * size * 0.52
+}
+
+// Truncate shortens s with an ellipsis to fit the width
+func Truncate(s string, size, width float64) string {
+	if TextWidth(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && TextWidth(string(runes)+"...", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+func (c Color) operands() string {
+	return num(c.R) + " " + num(c.G) + " " + num(c.B)
+}
+
+func num(v float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+}
+
+// escape encodes s for a literal string in the fonts' WinAnsi encoding, characters outside
+// Latin-1 become '?'
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 32 || r > 255:
+			b.WriteByte('?')
+		case r > 127:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
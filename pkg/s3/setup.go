@@ -13,6 +13,9 @@ var sess *session.Session
 // avatar folder
 const AvatarFolder = "avatar_src/"
 
+// ArchiveFolder holds the compressed telemetry and status rows moved out of the database
+const ArchiveFolder = "archive/"
+
 func Setup() {
 	awsConfig := &aws.Config{
 		Region:      aws.String("us-east-2"),
@@ -1,6 +1,7 @@
 package s3
 
 import (
+	"io"
 	"mime/multipart"
 	"os"
 
@@ -19,3 +20,17 @@ func UploadFile(filename string, file multipart.File) error {
 
 	return err
 }
+
+// UploadObject uploads a body that isn't a form file, like a generated archive or document
+func UploadObject(filename string, body io.ReadSeeker, contentType string) error {
+	uploader := s3.New(sess)
+
+	_, err := uploader.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(os.Getenv("S3_BUCKET_NAME")),
+		Key:         aws.String(filename),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+
+	return err
+}
@@ -0,0 +1,121 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 key of the RFC 6238 test vectors, "12345678901234567890" in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCodeMatchesRFC6238(t *testing.T) {
+	// The RFC lists 8 digit codes, authenticator apps show their last 6
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("got %s at %d, want %s", got, tt.unix, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := now.Unix() / Period
+
+	codeAt := func(offset time.Duration) string {
+		c, err := Code(rfcSecret, now.Add(offset))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		secret   string
+		passcode string
+		want     bool
+		wantStep int64
+	}{
+		{"current code", rfcSecret, codeAt(0), true, step},
+		{"surrounding spaces", rfcSecret, " " + codeAt(0) + " ", true, step},
+		{"lowercase padded secret", strings.ToLower(rfcSecret) + "====", codeAt(0), true, step},
+		{"previous period", rfcSecret, codeAt(-Period * time.Second), true, step - 1},
+		{"next period", rfcSecret, codeAt(Period * time.Second), true, step + 1},
+		{"two periods ago", rfcSecret, codeAt(-2 * Period * time.Second), false, 0},
+		{"two periods ahead", rfcSecret, codeAt(2 * Period * time.Second), false, 0},
+		{"wrong code", rfcSecret, "000000", false, 0},
+		{"too short", rfcSecret, codeAt(0)[:5], false, 0},
+		{"too long", rfcSecret, codeAt(0) + "1", false, 0},
+		{"empty", rfcSecret, "", false, 0},
+		{"secret is not base32", "not base32!", "123456", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, ok := Validate(tt.secret, tt.passcode, now)
+			if ok != tt.want || gotStep != tt.wantStep {
+				t.Errorf("got step %d and %v, want step %d and %v", gotStep, ok, tt.wantStep, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("got a %d character secret, want 32 for 160 bits", len(secret))
+	}
+
+	other, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret == other {
+		t.Error("got the same secret twice")
+	}
+
+	passcode, err := Code(secret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := Validate(secret, passcode, time.Now()); !ok {
+		t.Error("a code of a generated secret was refused")
+	}
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(URI("MedKick", "jane@example.com", rfcSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/MedKick:jane@example.com" {
+		t.Errorf("got %s, want an otpauth totp URI labelled with issuer and account", u)
+	}
+
+	q := u.Query()
+	if q.Get("secret") != rfcSecret || q.Get("issuer") != "MedKick" || q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Errorf("got query %v, want the secret, issuer, 6 digits and 30 seconds", q)
+	}
+}
@@ -0,0 +1,234 @@
+package worker
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/notification"
+	"fmt"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// ProcessAlertNotifications notifies the first tier of new alerts and escalates alerts
+// that are still unacknowledged after the escalation delay of their current tier
+func ProcessAlertNotifications() error {
+	now := time.Now().UTC()
+
+	alerts, err := models.ListAlertsPendingNotification(now)
+	if err != nil {
+		return err
+	}
+
+	rulesByOrg := make(map[uint][]models.AlertNotificationRule)
+	for _, alert := range alerts {
+		rules, ok := rulesByOrg[alert.OrganizationID]
+		if !ok {
+			rules, err = models.ListAlertNotificationRules(alert.OrganizationID)
+			if err != nil {
+				return err
+			}
+			rulesByOrg[alert.OrganizationID] = rules
+		}
+
+		if err := notifyAlert(alert, rules, now); err != nil {
+			log.Errorf("Failed to notify telemetry alert %d: %s", alert.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// NotifyTelemetryAlert notifies the first tier of a newly raised alert right away instead
+// of waiting for the next worker run
+func NotifyTelemetryAlert(alert models.TelemetryAlert) {
+	rules, err := models.ListAlertNotificationRules(alert.OrganizationID)
+	if err != nil {
+		log.Errorf("Failed to list alert notification rules: %s", err)
+		return
+	}
+
+	if err := notifyAlert(alert, rules, time.Now().UTC()); err != nil {
+		log.Errorf("Failed to notify telemetry alert %d: %s", alert.ID, err)
+	}
+}
+
+func notifyAlert(alert models.TelemetryAlert, rules []models.AlertNotificationRule, now time.Time) error {
+	// Rules of tiers the alert already reached that did not match it then, like a Critical
+	// rule when the alert was raised as a Warning and has since become Critical
+	for _, r := range rules {
+		if r.Tier <= alert.NotifiedTier && r.Matches(alert.AlertType) {
+			deliverAlertRule(alert, r)
+		}
+	}
+
+	next := uint(0)
+	for _, r := range rules {
+		if r.Tier > alert.NotifiedTier && r.Matches(alert.AlertType) && (next == 0 || r.Tier < next) {
+			next = r.Tier
+		}
+	}
+
+	if next == 0 {
+		return nil
+	}
+
+	if alert.NotifiedTier > 0 && alert.LastNotifiedAt != nil {
+		var wait time.Duration
+		for _, r := range rules {
+			if r.Tier == alert.NotifiedTier && r.Matches(alert.AlertType) {
+				if d := time.Duration(r.EscalateAfterMinutes) * time.Minute; d > wait {
+					wait = d
+				}
+			}
+		}
+		if now.Before(alert.LastNotifiedAt.Add(wait)) {
+			return nil
+		}
+	}
+
+	claimed, err := alert.ClaimNotificationTier(next)
+	if err != nil || !claimed {
+		return err
+	}
+
+	for _, r := range rules {
+		if r.Tier == next && r.Matches(alert.AlertType) {
+			deliverAlertRule(alert, r)
+		}
+	}
+
+	return nil
+}
+
+// deliverAlertRule delivers the alert for the rule unless the rule was already notified
+// about the alert
+func deliverAlertRule(alert models.TelemetryAlert, rule models.AlertNotificationRule) {
+	claimed, err := models.ClaimAlertNotificationRule(alert.ID, rule.ID, rule.Tier)
+	if err != nil {
+		log.Errorf("Failed to claim alert notification rule %d for alert %d: %s", rule.ID, alert.ID, err)
+		return
+	}
+
+	if claimed {
+		deliverAlert(alert, rule)
+	}
+}
+
+// deliverAlert sends the alert to every recipient of the rule and records each attempt
+func deliverAlert(alert models.TelemetryAlert, rule models.AlertNotificationRule) {
+	msg := alertMessage(alert, rule)
+	sender := channelSender(rule.Channel)
+
+	for _, recipient := range alertRecipients(alert, rule) {
+		msg.ToName = recipient.name
+		msg.To = recipient.address
+
+		attempt := models.AlertNotification{
+			AlertID:         alert.ID,
+			RuleID:          rule.ID,
+			Tier:            rule.Tier,
+			Channel:         rule.Channel,
+			RecipientUserID: recipient.userID,
+			Recipient:       recipient.address,
+			Status:          models.NotificationSent,
+		}
+
+		if recipient.address == "" {
+			attempt.Status = models.NotificationFailed
+			attempt.Error = "recipient has no address for this channel"
+		} else if err := sender.Send(msg); err != nil {
+			attempt.Status = models.NotificationFailed
+			attempt.Error = err.Error()
+		}
+
+		if err := attempt.CreateAlertNotification(); err != nil {
+			log.Errorf("Failed to record alert notification: %s", err)
+		}
+	}
+}
+
+// channelSender returns the notification sender of the channel
+func channelSender(channel models.NotificationChannel) notification.Sender {
+	switch channel {
+	case models.ChannelSMS:
+		return notification.SMS
+	case models.ChannelWebhook:
+		return notification.Webhook
+	}
+	return notification.Email
+}
+
+type alertRecipient struct {
+	userID  *uint
+	name    string
+	address string
+}
+
+func alertRecipients(alert models.TelemetryAlert, rule models.AlertNotificationRule) []alertRecipient {
+	if rule.Channel == models.ChannelWebhook {
+		return []alertRecipient{{name: "webhook", address: rule.Target}}
+	}
+
+	var users []models.User
+	switch rule.RecipientType {
+	case models.RecipientCareManager:
+		users, _ = models.GetUsersInOrgWithRole(&alert.OrganizationID, "care_manager")
+	case models.RecipientProvider:
+		users, _ = models.GetUsersInOrgWithRole(&alert.OrganizationID, "doctor")
+	case models.RecipientOnCall:
+		if rule.RecipientUserID == nil {
+			return []alertRecipient{{name: "on-call", address: rule.Target}}
+		}
+		u := models.User{ID: rule.RecipientUserID}
+		if err := u.GetUser(); err != nil {
+			log.Errorf("Failed to get on-call user: %s", err)
+			return nil
+		}
+		users = []models.User{u}
+	}
+
+	recipients := make([]alertRecipient, 0, len(users))
+	for _, u := range users {
+		address := u.Email
+		if rule.Channel == models.ChannelSMS {
+			address = u.Phone
+		}
+		recipients = append(recipients, alertRecipient{
+			userID:  u.ID,
+			name:    fmt.Sprintf("%s %s", u.FirstName, u.LastName),
+			address: address,
+		})
+	}
+
+	return recipients
+}
+
+// alertMessage only says that there is an alert and where to look, patient names and vitals
+// stay in the app since email, SMS and webhooks leave the system
+func alertMessage(alert models.TelemetryAlert, rule models.AlertNotificationRule) notification.Message {
+	condition := string(alert.DeviceType)
+	if alert.MeasurementType != "" {
+		condition = string(alert.MeasurementType)
+	}
+
+	subject := fmt.Sprintf("%s %s alert for patient #%d", alert.AlertType, condition, alert.PatientID)
+	if rule.Tier > 1 {
+		subject = fmt.Sprintf("[Escalation tier %d] %s", rule.Tier, subject)
+	}
+
+	body := fmt.Sprintf("%s (alert #%d). Log in to MedKick to review it.", subject, alert.ID)
+
+	return notification.Message{
+		Subject: subject,
+		Body:    body,
+		Payload: map[string]interface{}{
+			"alert_id":         alert.ID,
+			"organization_id":  alert.OrganizationID,
+			"patient_id":       alert.PatientID,
+			"alert_type":       alert.AlertType,
+			"device_type":      alert.DeviceType,
+			"measurement_type": alert.MeasurementType,
+			"tier":             rule.Tier,
+		},
+	}
+}
@@ -64,6 +64,7 @@ func processCPTCode99490(patientIDs ...uint) error {
 	var filteredPatientList2 []uint
 	db = database.DB.Model(&models.Device{}).
 		Joins("JOIN device_telemetry_data ON device_telemetry_data.device_id = devices.id").
+		Where("device_telemetry_data.quality IN (?)", models.CountableReadingQualities).
 		Where("devices.user_id IN (?)", filteredPatientList).
 		Where("device_telemetry_data.measured_at >= ?", startDate).
 		Group("devices.user_id")
@@ -165,6 +166,7 @@ func processCPTCode99439(patientIDs ...uint) error {
 	var filteredPatientList2 []uint
 	db = database.DB.Model(&models.Device{}).
 		Joins("JOIN device_telemetry_data ON device_telemetry_data.device_id = devices.id").
+		Where("device_telemetry_data.quality IN (?)", models.CountableReadingQualities).
 		Where("devices.user_id IN (?)", filteredPatientList).
 		Where("device_telemetry_data.measured_at >= ?", startDate).
 		Group("devices.user_id")
@@ -0,0 +1,300 @@
+package worker
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/pdf"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	pageMargin   = 36.0
+	contentWidth = pdf.PageWidth - 2*pageMargin
+	// The lists are cut to keep the report on one page
+	maxSummaryAlerts       = 6
+	maxSummaryInteractions = 6
+)
+
+var measurementLabels = map[models.MeasurementType]string{
+	models.Systolic:        "Systolic",
+	models.Diastolic:       "Diastolic",
+	models.Pulse:           "Pulse",
+	models.Weight:          "Weight",
+	models.FastingGlucose:  "Fasting glucose",
+	models.PostMealGlucose: "Post-meal glucose",
+}
+
+var measurementUnits = map[models.MeasurementType]string{
+	models.Systolic:        "mmHg",
+	models.Diastolic:       "mmHg",
+	models.Pulse:           "bpm",
+	models.Weight:          "lb",
+	models.FastingGlucose:  "mg/dL",
+	models.PostMealGlucose: "mg/dL",
+}
+
+// chartSeries is one measurement drawn on a trend chart
+type chartSeries struct {
+	stats *models.TelemetryStats
+	color pdf.Color
+}
+
+// renderClinicalSummary lays the month out on one page: key figures, trend charts, averages
+// against the patient's goals, then alerts and interventions and a line for the sign-off
+func renderClinicalSummary(data *models.ClinicalSummaryData, generatedAt time.Time) []byte {
+	doc := pdf.New()
+	page := doc.AddPage()
+	loc := data.Start.Location()
+	right := pdf.PageWidth - pageMargin
+
+	title := data.Start.Format("January 2006")
+	page.Text(pageMargin, 50, 16, true, pdf.Black, "RPM Monthly Clinical Summary")
+	page.Text(right-pdf.TextWidth(title, 12), 50, 12, true, pdf.Black, title)
+	page.Text(pageMargin, 64, 9, false, pdf.Gray, data.Patient.Organization.Name)
+	page.Line(pageMargin, 72, right, 72, 1, pdf.Black, false)
+
+	patient := fmt.Sprintf("Patient: %s, %s    DOB: %s", data.Patient.LastName, data.Patient.FirstName, data.Patient.DOB)
+	if data.Patient.MRN != "" {
+		patient += "    MRN: " + data.Patient.MRN
+	}
+	patient += "    Timezone: " + loc.String()
+	page.Text(pageMargin, 88, 9, false, pdf.Black, patient)
+
+	criticalAlerts := 0
+	for _, a := range data.Alerts {
+		if a.AlertType == models.AlertCritical {
+			criticalAlerts++
+		}
+	}
+
+	figures := [][2]string{
+		{"Reading days", fmt.Sprintf("%d of %d", data.ReadingDays, models.ReadingDaysTarget)},
+		{"RPM management time", fmt.Sprintf("%d min", data.ManagementSeconds/60)},
+		{"Alerts", fmt.Sprintf("%d (%d critical)", len(data.Alerts), criticalAlerts)},
+		{"Interventions", fmt.Sprintf("%d", len(data.Interactions))},
+	}
+	boxWidth := (contentWidth - 3*8) / 4
+	for i, figure := range figures {
+		x := pageMargin + float64(i)*(boxWidth+8)
+		page.Rect(x, 98, boxWidth, 40, pdf.Light, true)
+		page.Text(x+8, 112, 8, false, pdf.Gray, figure[0])
+		page.Text(x+8, 130, 13, true, pdf.Black, figure[1])
+	}
+
+	page.Text(pageMargin, 160, 11, true, pdf.Black, "Trends")
+	chartWidth := (contentWidth - 2*12) / 3
+	charts := []struct {
+		title  string
+		series []chartSeries
+	}{
+		{"Blood pressure (mmHg)", []chartSeries{{data.Stats[models.Systolic], pdf.Black}, {data.Stats[models.Diastolic], pdf.Gray}}},
+		{"Weight (lb)", []chartSeries{{data.Stats[models.Weight], pdf.Black}}},
+		{"Glucose (mg/dL)", []chartSeries{{data.Stats[models.FastingGlucose], pdf.Black}, {data.Stats[models.PostMealGlucose], pdf.Gray}}},
+	}
+	for i, chart := range charts {
+		drawTrendChart(page, pageMargin+float64(i)*(chartWidth+12), 168, chartWidth, 120, chart.title, chart.series)
+	}
+	page.Text(pageMargin, 308, 7, false, pdf.Gray, "Daily means. Black: systolic, fasting. Gray: diastolic, post-meal. Dashed: goal range.")
+
+	y := 332.0
+	page.Text(pageMargin, y, 11, true, pdf.Black, "Averages vs goals")
+	columns := []float64{pageMargin, pageMargin + 130, pageMargin + 200, pageMargin + 290, pageMargin + 380, pageMargin + 470}
+	y += 16
+	drawRow(page, columns, y, true, "Measurement", "Readings", "Mean", "Min - Max", "Goal", "In range")
+	page.Line(pageMargin, y+4, right, y+4, 0.5, pdf.Gray, false)
+	for _, measurementType := range models.ClinicalSummaryMeasurements {
+		y += 14
+		stats := data.Stats[measurementType]
+		unit := measurementUnits[measurementType]
+
+		mean, span, inRange := "-", "-", "-"
+		if stats.Overall.Mean != nil {
+			mean = fmt.Sprintf("%.1f %s", *stats.Overall.Mean, unit)
+			span = fmt.Sprintf("%d - %d", *stats.Overall.Min, *stats.Overall.Max)
+		}
+		if stats.Overall.TimeInRange != nil {
+			inRange = fmt.Sprintf("%.0f%%", stats.Overall.TimeInRange.InRange)
+		}
+
+		drawRow(page, columns, y, false, measurementLabels[measurementType], fmt.Sprintf("%d", stats.Overall.Count), mean, span, goalRange(stats.Threshold, unit), inRange)
+	}
+
+	y += 30
+	page.Text(pageMargin, y, 11, true, pdf.Black, fmt.Sprintf("Alerts (%d)", len(data.Alerts)))
+	y += 16
+	if len(data.Alerts) == 0 {
+		page.Text(pageMargin, y, 8, false, pdf.Gray, "No alerts this month")
+	} else {
+		columns = []float64{pageMargin, pageMargin + 80, pageMargin + 190, pageMargin + 260, pageMargin + 330, pageMargin + 410}
+		drawRow(page, columns, y, true, "Date", "Measurement", "Level", "Readings", "Status", "Outcome")
+		page.Line(pageMargin, y+4, right, y+4, 0.5, pdf.Gray, false)
+		for i, a := range data.Alerts {
+			if i == maxSummaryAlerts {
+				y += 12
+				page.Text(pageMargin, y, 8, false, pdf.Gray, fmt.Sprintf("and %d more", len(data.Alerts)-maxSummaryAlerts))
+				break
+			}
+			y += 12
+
+			measurement := string(a.DeviceType)
+			if label, ok := measurementLabels[a.MeasurementType]; ok {
+				measurement = label
+			}
+			status := "Open"
+			if !a.IsActive {
+				status = "Resolved"
+			}
+			outcome := string(a.Outcome)
+			if outcome == "" {
+				outcome = "-"
+			}
+
+			drawRow(page, columns, y, false, a.CreatedAt.In(loc).Format("01/02 15:04"), measurement, string(a.AlertType), fmt.Sprintf("%d", a.EpisodeCount), status, outcome)
+		}
+	}
+
+	y += 28
+	page.Text(pageMargin, y, 11, true, pdf.Black, fmt.Sprintf("Interventions (%d)", len(data.Interactions)))
+	y += 16
+	if len(data.Interactions) == 0 {
+		page.Text(pageMargin, y, 8, false, pdf.Gray, "No interventions this month")
+	} else {
+		columns = []float64{pageMargin, pageMargin + 60, pageMargin + 110, pageMargin + 160, pageMargin + 260}
+		drawRow(page, columns, y, true, "Date", "Minutes", "Type", "By", "Notes")
+		page.Line(pageMargin, y+4, right, y+4, 0.5, pdf.Gray, false)
+		for i, interaction := range data.Interactions {
+			if i == maxSummaryInteractions {
+				y += 12
+				page.Text(pageMargin, y, 8, false, pdf.Gray, fmt.Sprintf("and %d more", len(data.Interactions)-maxSummaryInteractions))
+				break
+			}
+			y += 12
+
+			by := strings.TrimSpace(interaction.Doctor.FirstName + " " + interaction.Doctor.LastName)
+			drawRow(page, columns, y, false,
+				interaction.SessionDate.In(loc).Format("01/02"),
+				fmt.Sprintf("%d", interaction.Duration/60),
+				interaction.CostCategory,
+				pdf.Truncate(by, 8, 95),
+				pdf.Truncate(interaction.Notes, 8, right-columns[4]))
+		}
+	}
+
+	page.Text(pageMargin, 720, 9, false, pdf.Black, "Reviewed by")
+	page.Line(pageMargin+60, 722, pageMargin+300, 722, 0.5, pdf.Black, false)
+	page.Text(pageMargin+330, 720, 9, false, pdf.Black, "Date")
+	page.Line(pageMargin+358, 722, right, 722, 0.5, pdf.Black, false)
+	page.Text(pageMargin, 760, 7, false, pdf.Gray, "Generated "+generatedAt.In(loc).Format("01/02/2006 15:04 MST")+" from device readings, alerts and interactions on record")
+
+	return doc.Bytes()
+}
+
+func drawRow(page *pdf.Page, columns []float64, y float64, header bool, values ...string) {
+	color := pdf.Black
+	if header {
+		color = pdf.Gray
+	}
+	for i, value := range values {
+		page.Text(columns[i], y, 8, false, color, value)
+	}
+}
+
+// drawTrendChart plots the daily means of the series over the month with the goal range of the
+// first series as dashed lines
+func drawTrendChart(page *pdf.Page, x, y, width, height float64, title string, series []chartSeries) {
+	page.Text(x, y+8, 9, true, pdf.Black, title)
+	top := y + 16
+	plotHeight := height - 28
+	page.Rect(x, top, width, plotHeight, pdf.Light, false)
+
+	low, high := math.Inf(1), math.Inf(-1)
+	days := 0
+	for _, s := range series {
+		if s.stats == nil {
+			continue
+		}
+		if len(s.stats.Buckets) > days {
+			days = len(s.stats.Buckets)
+		}
+		for _, b := range s.stats.Buckets {
+			if b.Mean != nil {
+				low = math.Min(low, *b.Mean)
+				high = math.Max(high, *b.Mean)
+			}
+		}
+	}
+
+	if math.IsInf(low, 1) {
+		page.Text(x+width/2-pdf.TextWidth("No readings", 8)/2, top+plotHeight/2+3, 8, false, pdf.Gray, "No readings")
+		return
+	}
+
+	var goals []float64
+	if series[0].stats != nil && series[0].stats.Threshold != nil {
+		threshold := series[0].stats.Threshold
+		for _, limit := range []*uint{threshold.WarningLow, threshold.WarningHigh} {
+			if limit != nil {
+				goals = append(goals, float64(*limit))
+				low = math.Min(low, float64(*limit))
+				high = math.Max(high, float64(*limit))
+			}
+		}
+	}
+
+	padding := math.Max((high-low)*0.1, 5)
+	low, high = math.Max(low-padding, 0), high+padding
+
+	px := func(day int) float64 {
+		if days <= 1 {
+			return x + width/2
+		}
+		return x + 4 + float64(day)*(width-8)/float64(days-1)
+	}
+	py := func(value float64) float64 {
+		return top + plotHeight - (value-low)/(high-low)*plotHeight
+	}
+
+	for _, goal := range goals {
+		page.Line(x, py(goal), x+width, py(goal), 0.5, pdf.Gray, true)
+	}
+
+	for _, s := range series {
+		if s.stats == nil {
+			continue
+		}
+		var points []pdf.Point
+		for day, b := range s.stats.Buckets {
+			if b.Mean != nil {
+				points = append(points, pdf.Point{X: px(day), Y: py(*b.Mean)})
+			}
+		}
+		page.Polyline(points, 1, s.color)
+		for _, pt := range points {
+			page.Dot(pt, 2.5, s.color)
+		}
+	}
+
+	page.Text(x+2, top+7, 6, false, pdf.Gray, fmt.Sprintf("%.0f", high))
+	page.Text(x+2, top+plotHeight-2, 6, false, pdf.Gray, fmt.Sprintf("%.0f", low))
+	page.Text(x, top+plotHeight+9, 6, false, pdf.Gray, "1")
+	last := fmt.Sprintf("%d", days)
+	page.Text(x+width-pdf.TextWidth(last, 6), top+plotHeight+9, 6, false, pdf.Gray, last)
+}
+
+// goalRange is the in range band of the patient's threshold
+func goalRange(threshold *models.AlertThreshold, unit string) string {
+	if threshold == nil {
+		return "-"
+	}
+	switch {
+	case threshold.WarningLow != nil && threshold.WarningHigh != nil:
+		return fmt.Sprintf("%d - %d %s", *threshold.WarningLow, *threshold.WarningHigh, unit)
+	case threshold.WarningHigh != nil:
+		return fmt.Sprintf("<= %d %s", *threshold.WarningHigh, unit)
+	case threshold.WarningLow != nil:
+		return fmt.Sprintf(">= %d %s", *threshold.WarningLow, unit)
+	}
+	return "-"
+}
@@ -0,0 +1,136 @@
+package worker
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/s3"
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// GenerateClinicalSummary renders the patient's report for the summary's month, stores it in
+// the bucket and records the headline numbers. A failure is recorded on the summary too.
+func GenerateClinicalSummary(summary *models.ClinicalSummary) error {
+	err := generateClinicalSummary(summary)
+	if err != nil {
+		summary.Status = models.ClinicalSummaryFailed
+		summary.Error = err.Error()
+	}
+
+	if updateErr := summary.UpdateClinicalSummary(); updateErr != nil {
+		return updateErr
+	}
+
+	return err
+}
+
+func generateClinicalSummary(summary *models.ClinicalSummary) error {
+	patient := models.User{
+		ID: &summary.PatientID,
+	}
+	if err := patient.GetUser(); err != nil {
+		return err
+	}
+
+	data, err := models.GetClinicalSummaryData(patient, summary.Period)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	report := renderClinicalSummary(data, now)
+
+	key := fmt.Sprintf("clinical-summary/%d/%s.pdf", summary.PatientID, summary.Period)
+	if err := s3.UploadObject(key, bytes.NewReader(report), "application/pdf"); err != nil {
+		return err
+	}
+
+	summary.FileKey = key
+	summary.Status = models.ClinicalSummaryGenerated
+	summary.Error = ""
+	summary.ReadingDays = data.ReadingDays
+	summary.ManagementMinutes = int(data.ManagementSeconds / 60)
+	summary.AlertCount = len(data.Alerts)
+	summary.InteractionCount = len(data.Interactions)
+	summary.GeneratedAt = &now
+
+	return nil
+}
+
+// PrepareClinicalSummaries marks the month's summaries of the organization's RPM patients
+// pending. Signed summaries are left alone, and so are generated ones unless regenerate is set.
+func PrepareClinicalSummaries(organizationID uint, period string, generatedByID *uint, regenerate bool) ([]models.ClinicalSummary, error) {
+	patients, err := models.ListEnrolledPatients("RPM", organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	patientIDs := make([]uint, 0, len(patients))
+	for _, p := range patients {
+		patientIDs = append(patientIDs, *p.ID)
+	}
+
+	generated, err := models.ListGeneratedClinicalSummaryPatients(patientIDs, period)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]models.ClinicalSummary, 0, len(patients))
+	for _, p := range patients {
+		if p.OrganizationID == nil || (generated[*p.ID] && !regenerate) {
+			continue
+		}
+
+		summary := models.ClinicalSummary{
+			PatientID:      *p.ID,
+			OrganizationID: *p.OrganizationID,
+			Period:         period,
+			GeneratedByID:  generatedByID,
+		}
+		if err := summary.PrepareClinicalSummary(); err != nil {
+			return nil, err
+		}
+		if summary.SignedAt != nil {
+			continue
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// GenerateClinicalSummaries generates the prepared summaries one after the other, failures
+// are recorded on each summary and don't stop the others
+func GenerateClinicalSummaries(summaries []models.ClinicalSummary) {
+	for i := range summaries {
+		if err := GenerateClinicalSummary(&summaries[i]); err != nil {
+			log.Errorf("Failed to generate clinical summary %d: %s", summaries[i].ID, err)
+		}
+	}
+}
+
+// ProcessClinicalSummaries generates last month's summaries for every organization's RPM
+// patients that don't have one yet
+func ProcessClinicalSummaries() error {
+	organizations, err := models.GetOrganizations()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().In(models.DefaultLocation())
+	period := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, now.Location()).Format("2006-01")
+
+	for _, org := range organizations {
+		summaries, err := PrepareClinicalSummaries(org.ID, period, nil, false)
+		if err != nil {
+			return err
+		}
+
+		GenerateClinicalSummaries(summaries)
+	}
+
+	return nil
+}
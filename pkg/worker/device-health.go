@@ -0,0 +1,100 @@
+package worker
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// ProcessDeviceHealth checks every assigned device against its organization's health
+// limits, raising a device alert for each new issue and resolving the ones that cleared
+func ProcessDeviceHealth() error {
+	devices, err := models.ListAssignedDevices()
+	if err != nil {
+		return err
+	}
+
+	if len(devices) == 0 {
+		return nil
+	}
+
+	deviceIDs := make([]uint, 0, len(devices))
+	for _, d := range devices {
+		deviceIDs = append(deviceIDs, d.ID)
+	}
+
+	activeAlerts, err := models.ListActiveDeviceAlerts(deviceIDs)
+	if err != nil {
+		return err
+	}
+
+	open := make(map[uint]map[models.DeviceAlertType]models.DeviceAlert)
+	for _, a := range activeAlerts {
+		if open[a.DeviceID] == nil {
+			open[a.DeviceID] = make(map[models.DeviceAlertType]models.DeviceAlert)
+		}
+		open[a.DeviceID][a.Type] = a
+	}
+
+	assignedAt, err := models.ListAssignmentStarts(deviceIDs)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	limitsByOrg := make(map[uint]models.DeviceHealthLimits)
+
+	for _, d := range devices {
+		organizationID := uint(0)
+		if d.User.OrganizationID != nil {
+			organizationID = *d.User.OrganizationID
+		}
+
+		limits, ok := limitsByOrg[organizationID]
+		if !ok {
+			limits = models.GetDeviceHealthLimits(organizationID)
+			limitsByOrg[organizationID] = limits
+		}
+
+		since, ok := assignedAt[d.ID]
+		if !ok {
+			since = d.CreatedAt
+		}
+
+		issues := d.HealthIssues(limits, since, now)
+
+		for alertType, data := range issues {
+			if existing, ok := open[d.ID][alertType]; ok {
+				existing.Data = data
+				if err := existing.UpdateDeviceAlertData(); err != nil {
+					log.Errorf("Failed to update device alert: %s", err)
+				}
+				continue
+			}
+
+			alert := models.DeviceAlert{
+				DeviceID:       d.ID,
+				PatientID:      d.UserID,
+				OrganizationID: organizationID,
+				Type:           alertType,
+				Data:           data,
+				IsActive:       true,
+			}
+			if err := alert.CreateDeviceAlert(); err != nil {
+				log.Errorf("Failed to create device alert: %s", err)
+			}
+		}
+
+		for alertType, existing := range open[d.ID] {
+			if _, ok := issues[alertType]; ok {
+				continue
+			}
+			if err := existing.ResolveDeviceAlert(); err != nil {
+				log.Errorf("Failed to resolve device alert: %s", err)
+			}
+		}
+	}
+
+	return nil
+}
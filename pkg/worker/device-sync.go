@@ -0,0 +1,196 @@
+package worker
+
+import (
+	"MedKick-backend/pkg/database/models"
+	mioApi "MedKick-backend/pkg/mio/api"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// SyncDevices reconciles the local device inventory with the devices on the Mio account.
+// New devices go into the unassigned pool, Mio-owned fields of known devices are refreshed
+// without touching names or assignments, and a device that fails doesn't stop the others.
+// The findings are stored as a DeviceSyncRun.
+func SyncDevices(ctx context.Context, mioClient *mioApi.Client) (*models.DeviceSyncRun, error) {
+	run := &models.DeviceSyncRun{
+		Status:    models.DeviceSyncRunning,
+		StartedAt: time.Now(),
+	}
+	if err := run.CreateDeviceSyncRun(); err != nil {
+		return nil, err
+	}
+
+	if err := syncDevices(ctx, mioClient, run); err != nil {
+		run.Status = models.DeviceSyncFailed
+		run.Error = err.Error()
+	} else if run.ErrorCount > 0 {
+		run.Status = models.DeviceSyncCompletedWithErrors
+	} else {
+		run.Status = models.DeviceSyncCompleted
+	}
+
+	if err := run.FinishDeviceSyncRun(); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func syncDevices(ctx context.Context, mioClient *mioApi.Client, run *models.DeviceSyncRun) error {
+	mioDevices, err := mioClient.GetDeviceList(ctx)
+	if err != nil {
+		return err
+	}
+	run.UpstreamCount = len(mioDevices.Items)
+
+	devices, err := models.GetDevices()
+	if err != nil {
+		return err
+	}
+
+	localByIMEI := make(map[string]*models.Device, len(devices))
+	for i := range devices {
+		localByIMEI[devices[i].IMEI] = &devices[i]
+	}
+
+	upstream := make(map[string]bool, len(mioDevices.Items))
+
+	for _, mioDevice := range mioDevices.Items {
+		if mioDevice.IMEI == "" {
+			run.AddEntry(models.DeviceSyncEntry{
+				Result:      models.DeviceSyncError,
+				MioDeviceID: mioDevice.DeviceID,
+				Message:     "Device has no IMEI",
+			})
+			continue
+		}
+		upstream[mioDevice.IMEI] = true
+
+		// The list may leave out details, fetch the device when it does
+		if mioDevice.ModelNumber == "" {
+			fetchDevice, err := mioClient.GetDevice(ctx, mioDevice.DeviceID)
+			if err != nil {
+				run.AddEntry(models.DeviceSyncEntry{
+					Result:      models.DeviceSyncError,
+					MioDeviceID: mioDevice.DeviceID,
+					IMEI:        mioDevice.IMEI,
+					Message:     err.Error(),
+				})
+				continue
+			}
+			mioDevice = *fetchDevice
+		}
+
+		if _, ok := models.DeviceModelNames[mioDevice.ModelNumber]; !ok {
+			run.AddEntry(models.DeviceSyncEntry{
+				Result:      models.DeviceSyncUnknownModel,
+				MioDeviceID: mioDevice.DeviceID,
+				IMEI:        mioDevice.IMEI,
+				ModelNumber: mioDevice.ModelNumber,
+				Message:     "Model number is not one we can read telemetry from",
+			})
+		}
+
+		entry := syncDevice(mioDevice, localByIMEI[mioDevice.IMEI])
+		if entry == nil {
+			run.UnchangedCount++
+			continue
+		}
+		run.AddEntry(*entry)
+	}
+
+	for _, device := range devices {
+		if upstream[device.IMEI] || device.Status == models.DeviceRetired {
+			continue
+		}
+
+		deviceID := device.ID
+		message := "Device is no longer on the Mio account"
+		if device.UserID != 0 {
+			message = fmt.Sprintf("Device is no longer on the Mio account but is assigned to patient %d", device.UserID)
+		}
+		run.AddEntry(models.DeviceSyncEntry{
+			Result:      models.DeviceSyncMissingUpstream,
+			DeviceID:    &deviceID,
+			IMEI:        device.IMEI,
+			ModelNumber: device.ModelNumber,
+			Message:     message,
+		})
+	}
+
+	return nil
+}
+
+// syncDevice adds or refreshes one device and returns the finding, or nil if nothing changed
+func syncDevice(mioDevice mioApi.Device, device *models.Device) *models.DeviceSyncEntry {
+	entry := &models.DeviceSyncEntry{
+		MioDeviceID: mioDevice.DeviceID,
+		IMEI:        mioDevice.IMEI,
+		ModelNumber: mioDevice.ModelNumber,
+	}
+
+	if device == nil {
+		device = &models.Device{
+			Name:            models.DeviceModelNames[mioDevice.ModelNumber],
+			ModelNumber:     mioDevice.ModelNumber,
+			IMEI:            mioDevice.IMEI,
+			SerialNumber:    mioDevice.SerialNumber,
+			FirmwareVersion: mioDevice.FirmwareVersion,
+			Status:          models.DeviceInStock,
+		}
+		if !mioDevice.CreatedAt.IsZero() {
+			device.CreatedAt = mioDevice.CreatedAt
+		}
+
+		if err := device.CreateDevice(); err != nil {
+			log.Errorf("Failed to create device %s: %s", mioDevice.IMEI, err)
+			entry.Result = models.DeviceSyncError
+			entry.Message = "Failed to create device"
+			return entry
+		}
+
+		entry.Result = models.DeviceSyncAdded
+		entry.DeviceID = &device.ID
+		return entry
+	}
+
+	entry.DeviceID = &device.ID
+
+	var changes []string
+	if mioDevice.ModelNumber != "" && device.ModelNumber != mioDevice.ModelNumber {
+		changes = append(changes, fmt.Sprintf("model_number %s -> %s", device.ModelNumber, mioDevice.ModelNumber))
+		device.ModelNumber = mioDevice.ModelNumber
+	}
+	if mioDevice.SerialNumber != "" && device.SerialNumber != mioDevice.SerialNumber {
+		changes = append(changes, fmt.Sprintf("serial_number %s -> %s", device.SerialNumber, mioDevice.SerialNumber))
+		device.SerialNumber = mioDevice.SerialNumber
+	}
+	if mioDevice.FirmwareVersion != "" && device.FirmwareVersion != mioDevice.FirmwareVersion {
+		changes = append(changes, fmt.Sprintf("firmware_version %s -> %s", device.FirmwareVersion, mioDevice.FirmwareVersion))
+		device.FirmwareVersion = mioDevice.FirmwareVersion
+	}
+	// Only fill in a missing name, names may have been edited by hand
+	if device.Name == "" && models.DeviceModelNames[device.ModelNumber] != "" {
+		device.Name = models.DeviceModelNames[device.ModelNumber]
+		changes = append(changes, "name "+device.Name)
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if err := device.UpdateDeviceInfo(); err != nil {
+		log.Errorf("Failed to update device %s: %s", mioDevice.IMEI, err)
+		entry.Result = models.DeviceSyncError
+		entry.Message = "Failed to update device"
+		return entry
+	}
+
+	entry.Result = models.DeviceSyncUpdated
+	entry.Message = strings.Join(changes, ", ")
+	return entry
+}
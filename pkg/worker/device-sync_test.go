@@ -0,0 +1,159 @@
+package worker
+
+import (
+	"MedKick-backend/pkg/database"
+	"MedKick-backend/pkg/database/models"
+	mioApi "MedKick-backend/pkg/mio/api"
+	mioFake "MedKick-backend/pkg/mio/fake"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+// setupTestDatabase connects to the MySQL database named by TEST_DB_DATABASE, using the DB_*
+// settings for the rest, and empties the tables the Mio flows write. Tests are skipped
+// without it, the database is wiped so it must not be one holding real data.
+func setupTestDatabase(t *testing.T) {
+	t.Helper()
+
+	name := os.Getenv("TEST_DB_DATABASE")
+	if name == "" {
+		t.Skip("TEST_DB_DATABASE is not set")
+	}
+
+	config := database.Config()
+	config.Database = name
+	database.ConnectDatabase(config)
+
+	err := database.DB.AutoMigrate(
+		&models.Organization{},
+		&models.User{},
+		&models.Device{},
+		&models.DeviceAssignment{},
+		&models.Shipment{},
+		&models.ShipmentItem{},
+		&models.DeviceSyncRun{},
+		&models.DeviceSyncEntry{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, table := range []string{"device_sync_entries", "device_sync_runs", "shipment_items", "shipments", "device_assignments", "devices", "users", "organizations"} {
+		if err := database.DB.Exec("DELETE FROM " + table).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func syncEntries(run *models.DeviceSyncRun, result models.DeviceSyncResult) []models.DeviceSyncEntry {
+	var entries []models.DeviceSyncEntry
+	for _, e := range run.Entries {
+		if e.Result == result {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+func TestSyncDevicesWithFake(t *testing.T) {
+	setupTestDatabase(t)
+
+	s, client, stop := mioFake.Start("test", mioApi.WithPageSize(2))
+	defer stop()
+
+	for i := 1; i <= 5; i++ {
+		s.AddDevice(mioApi.Device{
+			DeviceID:        fmt.Sprintf("dev_%d", i),
+			IMEI:            fmt.Sprintf("86%013d", i),
+			Status:          "active",
+			ModelNumber:     "TBM-2092-G",
+			FirmwareVersion: "1.0.0",
+			SerialNumber:    fmt.Sprintf("SN%d", i),
+		})
+	}
+	s.AddDevice(mioApi.Device{
+		DeviceID:    "dev_unknown",
+		IMEI:        "860000000000099",
+		ModelNumber: "XYZ-1",
+	})
+
+	// The first page request fails once and is retried
+	s.FailNext(1, http.StatusServiceUnavailable, "Try again")
+
+	run, err := SyncDevices(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != models.DeviceSyncCompleted {
+		t.Fatalf("run is %s (%s), want Completed", run.Status, run.Error)
+	}
+	if run.UpstreamCount != 6 || run.AddedCount != 6 || run.UnknownModelCount != 1 {
+		t.Errorf("got %d upstream, %d added and %d unknown models, want 6, 6 and 1", run.UpstreamCount, run.AddedCount, run.UnknownModelCount)
+	}
+
+	devices, err := models.GetDevices()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 6 {
+		t.Fatalf("got %d local devices, want 6", len(devices))
+	}
+	for _, d := range devices {
+		if d.Status != models.DeviceInStock || d.UserID != 0 {
+			t.Errorf("device %s is %s for user %d, want in the unassigned pool", d.IMEI, d.Status, d.UserID)
+		}
+	}
+
+	// A firmware update and a device taken off the account
+	s.AddDevice(mioApi.Device{
+		DeviceID:        "dev_1",
+		IMEI:            "860000000000001",
+		Status:          "active",
+		ModelNumber:     "TBM-2092-G",
+		FirmwareVersion: "1.0.1",
+		SerialNumber:    "SN1",
+	})
+	s.RemoveDevice("dev_5")
+
+	run, err = SyncDevices(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated := syncEntries(run, models.DeviceSyncUpdated)
+	if len(updated) != 1 || !strings.Contains(updated[0].Message, "firmware_version 1.0.0 -> 1.0.1") {
+		t.Errorf("got updates %+v, want the firmware change of dev_1", updated)
+	}
+	missing := syncEntries(run, models.DeviceSyncMissingUpstream)
+	if len(missing) != 1 || missing[0].IMEI != "860000000000005" {
+		t.Errorf("got missing %+v, want dev_5", missing)
+	}
+	// dev_2 to dev_4 and the unknown model
+	if run.UnchangedCount != 4 {
+		t.Errorf("got %d unchanged devices, want 4", run.UnchangedCount)
+	}
+}
+
+func TestSyncDevicesFailsOnAPIError(t *testing.T) {
+	setupTestDatabase(t)
+
+	s, client, stop := mioFake.Start("test")
+	defer stop()
+
+	s.FailNext(mioApi.DefaultMaxRetries+1, http.StatusInternalServerError, "Mio is down")
+
+	run, err := SyncDevices(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != models.DeviceSyncFailed {
+		t.Fatalf("run is %s, want Failed", run.Status)
+	}
+	if !strings.Contains(run.Error, "status code 500: Mio is down") {
+		t.Errorf("got error %q, want the API error with Mio's message", run.Error)
+	}
+}
@@ -64,6 +64,7 @@ func processCPTCode99426(patientIDs ...uint) error {
 	var filteredPatientList2 []uint
 	db = database.DB.Model(&models.Device{}).
 		Joins("JOIN device_telemetry_data ON device_telemetry_data.device_id = devices.id").
+		Where("device_telemetry_data.quality IN (?)", models.CountableReadingQualities).
 		Where("devices.user_id IN (?)", filteredPatientList).
 		Where("device_telemetry_data.measured_at >= ?", startDate).
 		Group("devices.user_id")
@@ -161,6 +162,7 @@ func processCPTCode99427(patientIDs ...uint) error {
 	var filteredPatientList2 []uint
 	db = database.DB.Model(&models.Device{}).
 		Joins("JOIN device_telemetry_data ON device_telemetry_data.device_id = devices.id").
+		Where("device_telemetry_data.quality IN (?)", models.CountableReadingQualities).
 		Where("devices.user_id IN (?)", filteredPatientList).
 		Where("device_telemetry_data.measured_at >= ?", startDate).
 		Group("devices.user_id")
@@ -0,0 +1,120 @@
+package worker
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/notification"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// ProcessAdherenceReminders reminds the at risk RPM patients of organizations that turned
+// reminders on to take their readings. A patient is reminded at most once per streak break
+// period. The channel is ADHERENCE_REMINDER_CHANNEL, SMS by default.
+func ProcessAdherenceReminders() error {
+	organizations, err := models.GetOrganizations()
+	if err != nil {
+		return err
+	}
+
+	channel := models.NotificationChannel(os.Getenv("ADHERENCE_REMINDER_CHANNEL"))
+	if channel != models.ChannelEmail {
+		channel = models.ChannelSMS
+	}
+
+	now := time.Now().UTC()
+
+	for _, o := range organizations {
+		if models.GetInteractionSettingValue(o.ID, models.AdherenceReminders, models.DefaultAdherenceReminders) != 1 {
+			continue
+		}
+
+		if err := remindOrganizationPatients(o.ID, channel, now); err != nil {
+			log.Errorf("Failed to send adherence reminders for organization %d: %s", o.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func remindOrganizationPatients(organizationID uint, channel models.NotificationChannel, now time.Time) error {
+	adherence, err := models.GetReadingAdherence(organizationID, now)
+	if err != nil {
+		return err
+	}
+
+	var atRisk []models.ReadingAdherence
+	var patientIDs []uint
+	for _, a := range adherence {
+		if a.Status == models.AdherenceAtRisk {
+			atRisk = append(atRisk, a)
+			patientIDs = append(patientIDs, a.PatientID)
+		}
+	}
+
+	streakDays := models.GetInteractionSettingValue(organizationID, models.AdherenceStreakBreakDays, models.DefaultAdherenceStreakBreakDays)
+	reminded, err := models.ListRemindedPatientsSince(patientIDs, now.AddDate(0, 0, -int(streakDays)))
+	if err != nil {
+		return err
+	}
+
+	sender := channelSender(channel)
+
+	for _, a := range atRisk {
+		if reminded[a.PatientID] {
+			continue
+		}
+
+		patient := models.User{ID: &a.PatientID}
+		if err := patient.GetUser(); err != nil {
+			log.Errorf("Failed to get patient %d: %s", a.PatientID, err)
+			continue
+		}
+
+		address := patient.Phone
+		if channel == models.ChannelEmail {
+			address = patient.Email
+		}
+
+		reminder := models.AdherenceReminder{
+			PatientID:      a.PatientID,
+			OrganizationID: organizationID,
+			Channel:        channel,
+			Recipient:      address,
+			Reason:         a.Status,
+			ReadingDays:    a.ReadingDays,
+			DaysNeeded:     a.DaysNeeded,
+			Status:         models.NotificationSent,
+		}
+
+		if address == "" {
+			reminder.Status = models.NotificationFailed
+			reminder.Error = "patient has no address for this channel"
+		} else if err := sender.Send(adherenceMessage(patient, a, address)); err != nil {
+			reminder.Status = models.NotificationFailed
+			reminder.Error = err.Error()
+		}
+
+		if err := reminder.CreateAdherenceReminder(); err != nil {
+			log.Errorf("Failed to record adherence reminder: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func adherenceMessage(patient models.User, a models.ReadingAdherence, address string) notification.Message {
+	body := fmt.Sprintf("Hi %s, you have taken readings on %d days this month. Please take a reading today, %d more days are needed before the end of the month.", patient.FirstName, a.ReadingDays, a.DaysNeeded)
+	if a.LastReadingAt == nil {
+		body = fmt.Sprintf("Hi %s, we haven't received a reading from you yet this month. Please take a reading today, %d days are needed before the end of the month.", patient.FirstName, a.DaysNeeded)
+	}
+
+	return notification.Message{
+		ToName:  fmt.Sprintf("%s %s", patient.FirstName, patient.LastName),
+		To:      address,
+		Subject: "Reminder to take your reading",
+		Body:    body,
+	}
+}
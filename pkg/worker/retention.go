@@ -0,0 +1,215 @@
+package worker
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/s3"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+const (
+	archiveBatchSize = 5000
+	// maxArchiveBatches bounds one run, a backlog is worked off over several nights
+	maxArchiveBatches = 100
+)
+
+// ArchiveTelemetry moves readings and device status reports that left their hot window out of
+// the tables into gzip compressed JSON lines files in the s3 bucket, one file per batch, owner
+// and month. Archived readings are folded into the daily summaries first. Reads over old ranges
+// pull the rows back from the files.
+func ArchiveTelemetry() error {
+	now := time.Now().UTC()
+
+	telemetryBefore := now.AddDate(0, 0, -models.HotWindowDays(models.ArchiveTelemetry))
+	importsBefore := now.AddDate(0, 0, -models.ImportRevertDays)
+	readings, err := archiveReadings(telemetryBefore, importsBefore)
+	if err != nil {
+		return err
+	}
+
+	statusBefore := now.AddDate(0, 0, -models.HotWindowDays(models.ArchiveStatus))
+	statuses, err := archiveStatus(statusBefore)
+	if err != nil {
+		return err
+	}
+
+	if readings > 0 || statuses > 0 {
+		log.Infof("Archived %d readings and %d status reports", readings, statuses)
+	}
+
+	return nil
+}
+
+func archiveReadings(before, importsBefore time.Time) (int, error) {
+	archived := 0
+
+	for i := 0; i < maxArchiveBatches; i++ {
+		readings, err := models.ListArchivableTelemetry(before, importsBefore, archiveBatchSize)
+		if err != nil {
+			return archived, err
+		}
+		if len(readings) == 0 {
+			break
+		}
+
+		patientIDs := make([]uint, 0)
+		seen := make(map[uint]bool)
+		for _, r := range readings {
+			if !seen[r.UserID] {
+				seen[r.UserID] = true
+				patientIDs = append(patientIDs, r.UserID)
+			}
+		}
+
+		locations, err := models.GetPatientLocations(patientIDs)
+		if err != nil {
+			return archived, err
+		}
+
+		// One file per patient and month, so reading a patient's history only fetches the
+		// patient's files
+		for _, group := range groupReadings(readings) {
+			rows := make([]interface{}, 0, len(group))
+			for _, r := range group {
+				rows = append(rows, r)
+			}
+
+			archive := &models.TelemetryArchive{
+				Source:   models.ArchiveTelemetry,
+				OwnerID:  group[0].UserID,
+				Period:   group[0].MeasuredAt.UTC().Format("2006-01"),
+				RowCount: len(group),
+				FirstAt:  group[0].MeasuredAt,
+				LastAt:   group[len(group)-1].MeasuredAt,
+			}
+			if archive.ObjectKey, err = uploadArchive(archive, group[0].ID, rows); err != nil {
+				return archived, err
+			}
+
+			if err := models.SaveTelemetryArchive(archive, group, locations); err != nil {
+				return archived, err
+			}
+			archived += len(group)
+		}
+
+		if len(readings) < archiveBatchSize {
+			break
+		}
+	}
+
+	return archived, nil
+}
+
+func archiveStatus(before time.Time) (int, error) {
+	archived := 0
+
+	for i := 0; i < maxArchiveBatches; i++ {
+		statuses, err := models.ListArchivableStatus(before, archiveBatchSize)
+		if err != nil {
+			return archived, err
+		}
+		if len(statuses) == 0 {
+			break
+		}
+
+		// One file per device and month
+		for _, group := range groupStatus(statuses) {
+			rows := make([]interface{}, 0, len(group))
+			for _, s := range group {
+				rows = append(rows, s)
+			}
+
+			archive := &models.TelemetryArchive{
+				Source:   models.ArchiveStatus,
+				OwnerID:  group[0].DeviceID,
+				Period:   group[0].CreatedAt.UTC().Format("2006-01"),
+				RowCount: len(group),
+				FirstAt:  group[0].CreatedAt,
+				LastAt:   group[len(group)-1].CreatedAt,
+			}
+			if archive.ObjectKey, err = uploadArchive(archive, group[0].ID, rows); err != nil {
+				return archived, err
+			}
+
+			if err := models.SaveStatusArchive(archive, group); err != nil {
+				return archived, err
+			}
+			archived += len(group)
+		}
+
+		if len(statuses) < archiveBatchSize {
+			break
+		}
+	}
+
+	return archived, nil
+}
+
+type archiveGroup struct {
+	owner  uint
+	period string
+}
+
+// groupReadings splits a batch by patient and UTC month. The batch is oldest first, and so is
+// every group.
+func groupReadings(readings []models.DeviceTelemetryData) [][]models.DeviceTelemetryData {
+	index := make(map[archiveGroup]int)
+	groups := make([][]models.DeviceTelemetryData, 0)
+	for _, r := range readings {
+		key := archiveGroup{r.UserID, r.MeasuredAt.UTC().Format("2006-01")}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], r)
+	}
+	return groups
+}
+
+// groupStatus splits a batch by device and UTC month
+func groupStatus(statuses []models.DeviceStatusData) [][]models.DeviceStatusData {
+	index := make(map[archiveGroup]int)
+	groups := make([][]models.DeviceStatusData, 0)
+	for _, s := range statuses {
+		key := archiveGroup{s.DeviceID, s.CreatedAt.UTC().Format("2006-01")}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], s)
+	}
+	return groups
+}
+
+// uploadArchive writes the rows as gzip compressed JSON lines to a new file in the bucket and
+// returns its key. The rows are only deleted after the upload, a failed run leaves at most
+// an unreferenced file behind.
+func uploadArchive(archive *models.TelemetryArchive, firstID uint, rows []interface{}) (string, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gz)
+	for _, row := range rows {
+		if err := encoder.Encode(row); err != nil {
+			return "", err
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return "", err
+	}
+
+	key := fmt.Sprintf("%s%s/%s/%d/%d-%d.jsonl.gz", s3.ArchiveFolder, archive.Source, archive.Period, archive.OwnerID, firstID, time.Now().UnixNano())
+	if err := s3.UploadObject(key, bytes.NewReader(buf.Bytes()), "application/gzip"); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
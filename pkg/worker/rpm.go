@@ -39,8 +39,10 @@ func processCPTCode99453(...uint) error {
 	var filteredPatientList []uint
 	db = database.DB.Model(&models.Device{}).
 		Joins("JOIN device_telemetry_data ON device_telemetry_data.device_id = devices.id").
+		Where("device_telemetry_data.quality IN (?)", models.CountableReadingQualities).
 		Where("devices.user_id IN (?)", patientList).
 		Where("device_telemetry_data.measured_at < ?", getEndTimeOfToday().AddDate(0, 0, -16)).
+		Scopes(models.DeviceUploadedReadings).
 		Group("devices.user_id")
 
 	if err := db.Pluck("devices.user_id", &filteredPatientList).Error; err != nil {
@@ -108,18 +110,19 @@ func processCPTCode99454(...uint) error {
 
 	startDate := getStartDateOfMonth()
 
-	var filteredPatientList []uint
-	db = database.DB.Model(&models.Device{}).
-		Joins("JOIN device_telemetry_data ON device_telemetry_data.device_id = devices.id").
-		Where("devices.user_id IN (?)", patientList).
-		Where("device_telemetry_data.measured_at >= ?", startDate).
-		Group("devices.user_id").
-		Having("COUNT(DISTINCT DATE(device_telemetry_data.measured_at)) >= 16")
-
-	if err := db.Pluck("devices.user_id", &filteredPatientList).Error; err != nil {
+	// Reading days are counted in each patient's own timezone
+	readingDays, err := models.CountPatientReadingDays(patientList, time.Now())
+	if err != nil {
 		return err
 	}
 
+	var filteredPatientList []uint
+	for _, patientID := range patientList {
+		if readingDays[patientID] >= 16 {
+			filteredPatientList = append(filteredPatientList, patientID)
+		}
+	}
+
 	fmt.Println("Total Patients for Billing: (99454)", len(filteredPatientList))
 
 	if len(filteredPatientList) == 0 {
@@ -128,8 +131,10 @@ func processCPTCode99454(...uint) error {
 
 	db = database.DB.Model(&models.Device{}).
 		Joins("JOIN device_telemetry_data ON device_telemetry_data.device_id = devices.id").
+		Where("device_telemetry_data.quality IN (?)", models.CountableReadingQualities).
 		Where("devices.user_id IN (?)", filteredPatientList).
 		Where("device_telemetry_data.measured_at >= ?", startDate).
+		Scopes(models.DeviceUploadedReadings).
 		Group("devices.user_id")
 
 	if err := db.Pluck("devices.user_id", &filteredPatientList).Error; err != nil {
@@ -214,6 +219,7 @@ func processCPTCode99457(patientIDs ...uint) error {
 	var filteredPatientList2 []uint
 	db = database.DB.Model(&models.Device{}).
 		Joins("JOIN device_telemetry_data ON device_telemetry_data.device_id = devices.id").
+		Where("device_telemetry_data.quality IN (?)", models.CountableReadingQualities).
 		Where("devices.user_id IN (?)", filteredPatientList).
 		Where("device_telemetry_data.measured_at >= ?", startDate).
 		Group("devices.user_id")
@@ -315,6 +321,7 @@ func processCPTCode99458(patientIDs ...uint) error {
 	var filteredPatientList2 []uint
 	db = database.DB.Model(&models.Device{}).
 		Joins("JOIN device_telemetry_data ON device_telemetry_data.device_id = devices.id").
+		Where("device_telemetry_data.quality IN (?)", models.CountableReadingQualities).
 		Where("devices.user_id IN (?)", filteredPatientList).
 		Where("device_telemetry_data.measured_at >= ?", startDate).
 		Group("devices.user_id")
@@ -0,0 +1,182 @@
+package worker
+
+import (
+	"MedKick-backend/pkg/database/models"
+	mioApi "MedKick-backend/pkg/mio/api"
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+// ProcessShipments pulls the status of every shipment that is still on its way
+func ProcessShipments() error {
+	shipments, err := models.ListOpenShipments()
+	if err != nil {
+		return err
+	}
+
+	for i := range shipments {
+		if err := RefreshShipment(context.Background(), &shipments[i]); err != nil {
+			log.Errorf("Failed to refresh shipment %d: %s", shipments[i].ID, err)
+		}
+	}
+
+	return nil
+}
+
+// RefreshShipment syncs a shipment with Mio. Devices Mio packed are created or found locally
+// and linked to the shipment, marked as shipped once it leaves, and assigned to the patient
+// once it is delivered.
+func RefreshShipment(ctx context.Context, shipment *models.Shipment) error {
+	if shipment.MioShipmentID == "" {
+		return errors.New("shipment was never accepted by Mio")
+	}
+
+	mioClient := mioApi.NewClient(os.Getenv("MIO_API_KEY"))
+	remote, err := mioClient.GetShipment(ctx, shipment.MioShipmentID)
+	if err != nil {
+		return err
+	}
+
+	if len(remote.Devices) > 0 && !shipmentHasDevices(shipment) {
+		items := make([]models.ShipmentItem, 0, len(remote.Devices))
+		for _, d := range remote.Devices {
+			items = append(items, models.ShipmentItem{
+				ModelNumber: d.ModelNumber,
+				Count:       1,
+				IMEI:        d.IMEI,
+			})
+		}
+		if err := shipment.ReplaceShipmentItems(items); err != nil {
+			return err
+		}
+	}
+
+	for i := range shipment.Items {
+		item := &shipment.Items[i]
+		if item.IMEI == "" || item.DeviceID != nil {
+			continue
+		}
+
+		device, err := shipmentDevice(ctx, mioClient, remote, item)
+		if err != nil {
+			log.Errorf("Failed to link shipped device %s: %s", item.IMEI, err)
+			continue
+		}
+		if err := item.LinkShipmentItemDevice(device.ID); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	shipment.Carrier = remote.Carrier
+	shipment.TrackingNumber = remote.TrackingNumber
+
+	switch remote.Status {
+	case mioApi.ShipmentShipped:
+		shipment.Status = models.ShipmentShipped
+		if shipment.ShippedAt == nil {
+			shipment.ShippedAt = &now
+		}
+	case mioApi.ShipmentDelivered:
+		shipment.Status = models.ShipmentDelivered
+		if shipment.ShippedAt == nil {
+			shipment.ShippedAt = &now
+		}
+		if shipment.DeliveredAt == nil {
+			shipment.DeliveredAt = &now
+		}
+	case mioApi.ShipmentCancelled:
+		shipment.Status = models.ShipmentCancelled
+	}
+
+	if err := shipment.UpdateShipment(); err != nil {
+		return err
+	}
+
+	return updateShippedDevices(shipment)
+}
+
+func shipmentHasDevices(shipment *models.Shipment) bool {
+	for _, item := range shipment.Items {
+		if item.IMEI != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// shipmentDevice finds the local device for a shipped item, creating it from Mio when the
+// device sync hasn't picked it up yet
+func shipmentDevice(ctx context.Context, mioClient *mioApi.Client, remote *mioApi.Shipment, item *models.ShipmentItem) (*models.Device, error) {
+	device := &models.Device{
+		IMEI: item.IMEI,
+	}
+	err := device.GetDeviceByIMEI()
+	if err == nil {
+		return device, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	device = &models.Device{
+		Name:        models.DeviceModelNames[item.ModelNumber],
+		ModelNumber: item.ModelNumber,
+		IMEI:        item.IMEI,
+		Status:      models.DeviceInStock,
+	}
+
+	for _, d := range remote.Devices {
+		if d.IMEI != item.IMEI {
+			continue
+		}
+		if fetchDevice, err := mioClient.GetDevice(ctx, d.DeviceID); err == nil {
+			device.SerialNumber = fetchDevice.SerialNumber
+			device.FirmwareVersion = fetchDevice.FirmwareVersion
+		}
+	}
+
+	if err := device.CreateDevice(); err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// updateShippedDevices moves the shipment's devices along with it
+func updateShippedDevices(shipment *models.Shipment) error {
+	for _, item := range shipment.Items {
+		if item.DeviceID == nil {
+			continue
+		}
+
+		device := &models.Device{
+			ID: *item.DeviceID,
+		}
+		if err := device.GetDevice(); err != nil {
+			return err
+		}
+
+		switch shipment.Status {
+		case models.ShipmentShipped:
+			if device.UserID == 0 && device.Status != models.DeviceShipped {
+				if err := device.UpdateStatus(models.DeviceShipped); err != nil {
+					return err
+				}
+			}
+		case models.ShipmentDelivered:
+			if device.UserID != shipment.PatientID {
+				if err := device.AssignPatient(shipment.PatientID, shipment.OrderedByID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"MedKick-backend/pkg/database/models"
+	mioApi "MedKick-backend/pkg/mio/api"
+	mioFake "MedKick-backend/pkg/mio/fake"
+	"context"
+	"testing"
+)
+
+func TestRefreshShipmentWithFake(t *testing.T) {
+	setupTestDatabase(t)
+
+	s, client, stop := mioFake.Start("test")
+	defer stop()
+
+	// RefreshShipment builds its own client from the environment
+	t.Setenv("MIO_API_URL", client.BaseURL)
+	t.Setenv("MIO_API_KEY", "test")
+
+	org := models.Organization{
+		Name: "Test Clinic",
+	}
+	if err := org.CreateOrganization(); err != nil {
+		t.Fatal(err)
+	}
+
+	patient := models.User{
+		FirstName:      "Jane",
+		LastName:       "Doe",
+		Email:          "jane.doe@example.com",
+		Phone:          "5550100",
+		Role:           "patient",
+		OrganizationID: &org.ID,
+	}
+	if err := patient.CreateUser(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	remote, err := client.ShipItem(ctx, mioApi.ShipmentRequest{
+		Address: mioApi.ShipmentAddress{
+			FirstName:   patient.FirstName,
+			LastName:    patient.LastName,
+			Country:     "USA",
+			ZipCode:     "75001",
+			City:        "Dallas",
+			State:       "TX",
+			AddressLine: "123 Main St",
+		},
+		Items: []mioApi.ShipmentItem{
+			{DeviceId: "TBM-2092-G", Count: 2},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shipment := models.Shipment{
+		OrganizationID: org.ID,
+		PatientID:      *patient.ID,
+		MioShipmentID:  remote.ID,
+		Status:         models.ShipmentOrdered,
+		AddressLine:    "123 Main St",
+		City:           "Dallas",
+		State:          "TX",
+		ZipCode:        "75001",
+		Country:        "USA",
+		Items: []models.ShipmentItem{
+			{ModelNumber: "TBM-2092-G", Count: 2},
+		},
+	}
+	if err := shipment.CreateShipment(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Packed: the order line is split into one item per device and the devices are created
+	if err := RefreshShipment(ctx, &shipment); err != nil {
+		t.Fatal(err)
+	}
+	shipment = reloadShipment(t, shipment.ID)
+	if shipment.Status != models.ShipmentOrdered || len(shipment.Items) != 2 {
+		t.Fatalf("shipment is %s with %d items, want Ordered with 2", shipment.Status, len(shipment.Items))
+	}
+	for _, item := range shipment.Items {
+		if item.IMEI == "" || item.Device == nil || item.Device.Status != models.DeviceInStock {
+			t.Fatalf("item %+v has no in stock device linked", item)
+		}
+	}
+
+	if err := s.SetShipmentStatus(remote.ID, mioApi.ShipmentShipped); err != nil {
+		t.Fatal(err)
+	}
+	if err := RefreshShipment(ctx, &shipment); err != nil {
+		t.Fatal(err)
+	}
+	shipment = reloadShipment(t, shipment.ID)
+	if shipment.Status != models.ShipmentShipped || shipment.ShippedAt == nil || shipment.TrackingNumber == "" {
+		t.Fatalf("shipment is %s with tracking %q, want Shipped with a tracking number", shipment.Status, shipment.TrackingNumber)
+	}
+	for _, item := range shipment.Items {
+		if item.Device.Status != models.DeviceShipped {
+			t.Errorf("device %s is %s, want Shipped", item.IMEI, item.Device.Status)
+		}
+	}
+
+	if err := s.SetShipmentStatus(remote.ID, mioApi.ShipmentDelivered); err != nil {
+		t.Fatal(err)
+	}
+	if err := RefreshShipment(ctx, &shipment); err != nil {
+		t.Fatal(err)
+	}
+	shipment = reloadShipment(t, shipment.ID)
+	if shipment.Status != models.ShipmentDelivered || shipment.DeliveredAt == nil {
+		t.Fatalf("shipment is %s, want Delivered", shipment.Status)
+	}
+	for _, item := range shipment.Items {
+		if item.Device.UserID != *patient.ID || item.Device.Status != models.DeviceActive {
+			t.Errorf("device %s is %s for user %d, want Active for the patient", item.IMEI, item.Device.Status, item.Device.UserID)
+		}
+	}
+}
+
+func reloadShipment(t *testing.T, id uint) models.Shipment {
+	t.Helper()
+
+	shipment := models.Shipment{
+		ID: id,
+	}
+	if err := shipment.GetShipment(); err != nil {
+		t.Fatal(err)
+	}
+	return shipment
+}
@@ -0,0 +1,474 @@
+package worker
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// importStaleAfter is far longer than the largest file takes to import
+const importStaleAfter = 6 * time.Hour
+
+// ImportFields are the fields import rows are read into. A file's columns are mapped onto
+// them by the import's column map, unmapped fields are read from a column of the same name.
+var ImportFields = []string{
+	"mrn", "email", "first_name", "last_name", "dob", "measured_at",
+	"systolic", "diastolic", "pulse", "irregular_heartbeat",
+	"weight", "weight_unit", "glucose", "glucose_unit", "meal",
+}
+
+// ImportRow is one record of an import file keyed by import field
+type ImportRow struct {
+	Line   int
+	Values map[string]string
+	Raw    string
+}
+
+// importError is a problem with one field of a row
+type importError struct {
+	column  string
+	message string
+}
+
+func (e importError) Error() string {
+	return e.message
+}
+
+// ParseTelemetryImport reads a CSV file with a header row, or a JSON array of objects, into
+// rows. Column names are matched case-insensitively. The file must have the columns the
+// patient match needs and measured_at.
+func ParseTelemetryImport(format models.ImportFormat, r io.Reader, match models.PatientMatch, columnMap map[string]string) ([]ImportRow, error) {
+	columns := make(map[string]string, len(ImportFields))
+	for _, field := range ImportFields {
+		column := field
+		if mapped, ok := columnMap[field]; ok && mapped != "" {
+			column = mapped
+		}
+		columns[field] = normalizeColumn(column)
+	}
+
+	var records []map[string]string
+	var raws []string
+	var lineOffset int
+
+	switch format {
+	case models.ImportCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the header row: %w", err)
+		}
+		for i := range header {
+			header[i] = normalizeColumn(header[i])
+		}
+
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			values := make(map[string]string, len(record))
+			for i, value := range record {
+				if i < len(header) {
+					values[header[i]] = strings.TrimSpace(value)
+				}
+			}
+			records = append(records, values)
+			raws = append(raws, strings.Join(record, ","))
+		}
+		// The header is line 1
+		lineOffset = 2
+	case models.ImportJSON:
+		decoder := json.NewDecoder(r)
+		decoder.UseNumber()
+
+		var objects []map[string]interface{}
+		if err := decoder.Decode(&objects); err != nil {
+			return nil, fmt.Errorf("file must be a JSON array of objects: %w", err)
+		}
+
+		for _, object := range objects {
+			values := make(map[string]string, len(object))
+			for key, value := range object {
+				if value != nil {
+					values[normalizeColumn(key)] = strings.TrimSpace(fmt.Sprint(value))
+				}
+			}
+			raw, _ := json.Marshal(object)
+			records = append(records, values)
+			raws = append(raws, string(raw))
+		}
+		// Rows are numbered by their position in the array
+		lineOffset = 1
+	default:
+		return nil, errors.New("unsupported format " + string(format))
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("file has no rows")
+	}
+
+	present := make(map[string]bool)
+	for _, record := range records {
+		for column := range record {
+			present[column] = true
+		}
+	}
+
+	required := []string{"measured_at"}
+	switch match {
+	case models.MatchMRN:
+		required = append(required, "mrn")
+	case models.MatchEmail:
+		required = append(required, "email")
+	case models.MatchNameDOB:
+		required = append(required, "first_name", "last_name", "dob")
+	}
+
+	for _, field := range required {
+		if !present[columns[field]] {
+			return nil, fmt.Errorf("file has no %s column", columns[field])
+		}
+	}
+
+	rows := make([]ImportRow, 0, len(records))
+	for i, record := range records {
+		values := make(map[string]string, len(ImportFields))
+		for field, column := range columns {
+			if value := record[column]; value != "" {
+				values[field] = value
+			}
+		}
+
+		rows = append(rows, ImportRow{
+			Line:   i + lineOffset,
+			Values: values,
+			Raw:    raws[i],
+		})
+	}
+
+	return rows, nil
+}
+
+// ImportTelemetry writes the rows of an import batch as readings of the organization's
+// patients. Rows that already exist, in the file or in our data, are counted as duplicates
+// and skipped, rows that can't be imported go into the batch's error report. Imported
+// readings raise no alerts and are not pushed to dashboards, they are history.
+func ImportTelemetry(batch *models.TelemetryImport, rows []ImportRow) {
+	batch.TotalRows = len(rows)
+
+	if err := runImport(batch, rows); err != nil {
+		batch.Status = models.ImportFailed
+		batch.Error = err.Error()
+	} else if batch.ErrorCount > 0 {
+		batch.Status = models.ImportCompletedWithErrors
+	} else {
+		batch.Status = models.ImportCompleted
+	}
+
+	if err := batch.FinishTelemetryImport(); err != nil {
+		log.Errorf("Failed to finish telemetry import %d: %s", batch.ID, err)
+	}
+}
+
+// runImport turns a panic of the import into an error, so the batch still finishes and its
+// readings can be reverted
+func runImport(batch *models.TelemetryImport, rows []ImportRow) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("Telemetry import %d panicked: %v\n%s", batch.ID, r, debug.Stack())
+			err = errors.New("import stopped unexpectedly")
+		}
+	}()
+
+	return importTelemetry(batch, rows)
+}
+
+// FailStaleTelemetryImports fails batches still running after importStaleAfter. Their worker
+// was stopped by a restart, failing them lets their readings be reverted.
+func FailStaleTelemetryImports() error {
+	failed, err := models.FailStaleTelemetryImports(time.Now().Add(-importStaleAfter))
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		log.Infof("Failed %d stale telemetry imports", failed)
+	}
+
+	return nil
+}
+
+func importTelemetry(batch *models.TelemetryImport, rows []ImportRow) error {
+	patients, err := models.ListImportablePatients(batch.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	patientsByKey, ambiguous := keyPatients(batch.PatientMatch, patients)
+
+	patientIDs := make([]uint, 0, len(patients))
+	for _, p := range patients {
+		patientIDs = append(patientIDs, *p.ID)
+	}
+
+	locations, err := models.GetPatientLocations(patientIDs)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	now := time.Now()
+
+	for _, row := range rows {
+		key := patientKey(batch.PatientMatch, row.Values)
+		patient, ok := patientsByKey[key]
+		if key == "" || !ok || ambiguous[key] {
+			message := "no patient matches the row"
+			if ambiguous[key] {
+				message = "more than one patient matches the row"
+			}
+			batch.AddError(models.TelemetryImportError{
+				Line:    row.Line,
+				Column:  matchColumn(batch.PatientMatch),
+				Message: message,
+				Raw:     row.Raw,
+			})
+			continue
+		}
+
+		readings, err := rowReadings(row, locations[*patient.ID], now)
+		if err != nil {
+			entry := models.TelemetryImportError{
+				Line:    row.Line,
+				Message: err.Error(),
+				Raw:     row.Raw,
+			}
+			var rowErr importError
+			if errors.As(err, &rowErr) {
+				entry.Column = rowErr.column
+			}
+			batch.AddError(entry)
+			continue
+		}
+
+		for _, reading := range readings {
+			reading.UserID = *patient.ID
+
+			readingKey := fmt.Sprintf("%d|%d|%d|%d|%d|%d", reading.UserID, reading.MeasuredAt.Truncate(time.Minute).Unix(),
+				reading.SystolicBP, reading.DiastolicBP, reading.Weight, reading.BloodGlucose)
+			if seen[readingKey] {
+				batch.DuplicateCount++
+				continue
+			}
+			seen[readingKey] = true
+
+			duplicate, err := models.HasDuplicateReading(reading)
+			if err != nil {
+				return err
+			}
+			if duplicate {
+				batch.DuplicateCount++
+				continue
+			}
+
+			reading.Source = models.SourceImported
+			reading.ImportBatchID = &batch.ID
+			if err := reading.CreateDeviceTelemetryData(); err != nil {
+				return err
+			}
+			batch.ImportedCount++
+		}
+	}
+
+	return nil
+}
+
+// keyPatients indexes the patients by what rows are matched on. A key shared by several
+// patients matches none of them, it is returned in ambiguous instead.
+func keyPatients(match models.PatientMatch, patients []models.User) (map[string]*models.User, map[string]bool) {
+	patientsByKey := make(map[string]*models.User, len(patients))
+	ambiguous := make(map[string]bool)
+	for i := range patients {
+		key := patientKey(match, map[string]string{
+			"mrn":        patients[i].MRN,
+			"email":      patients[i].Email,
+			"first_name": patients[i].FirstName,
+			"last_name":  patients[i].LastName,
+			"dob":        patients[i].DOB,
+		})
+		if key == "" {
+			continue
+		}
+		if _, ok := patientsByKey[key]; ok {
+			ambiguous[key] = true
+		}
+		patientsByKey[key] = &patients[i]
+	}
+	return patientsByKey, ambiguous
+}
+
+// rowReadings turns a row into one reading per device type it has measurements for
+func rowReadings(row ImportRow, loc *time.Location, now time.Time) ([]models.DeviceTelemetryData, error) {
+	v := row.Values
+
+	measuredAt, err := models.ParseImportedTime(v["measured_at"], loc)
+	if err != nil {
+		return nil, importError{"measured_at", err.Error()}
+	}
+	if measuredAt.After(now) {
+		return nil, importError{"measured_at", "measured_at is in the future"}
+	}
+
+	var readings []models.DeviceTelemetryData
+
+	if v["systolic"] != "" || v["diastolic"] != "" || v["pulse"] != "" {
+		if v["systolic"] == "" || v["diastolic"] == "" {
+			return nil, importError{"systolic", "blood pressure needs systolic and diastolic"}
+		}
+
+		systolic, err := parseMeasurement(v, "systolic", 300)
+		if err != nil {
+			return nil, err
+		}
+		diastolic, err := parseMeasurement(v, "diastolic", 200)
+		if err != nil {
+			return nil, err
+		}
+		if systolic <= diastolic {
+			return nil, importError{"systolic", "systolic must be higher than diastolic"}
+		}
+
+		var pulse uint
+		if v["pulse"] != "" {
+			if pulse, err = parseMeasurement(v, "pulse", 250); err != nil {
+				return nil, err
+			}
+		}
+
+		irregular := false
+		if raw := strings.ToLower(v["irregular_heartbeat"]); raw != "" {
+			irregular = raw == "true" || raw == "yes" || raw == "y" || raw == "1"
+		}
+
+		readings = append(readings, models.DeviceTelemetryData{
+			SystolicBP:         systolic,
+			DiastolicBP:        diastolic,
+			Pulse:              pulse,
+			IrregularHeartBeat: irregular,
+			MeasuredAt:         measuredAt,
+		})
+	}
+
+	if v["weight"] != "" {
+		weight, err := models.ParseImportedWeight(v["weight"], v["weight_unit"])
+		if err != nil {
+			return nil, importError{"weight", err.Error()}
+		}
+
+		readings = append(readings, models.DeviceTelemetryData{
+			Weight: weight,
+			// The export only has settled weights
+			WeightStableTime: 1,
+			MeasuredAt:       measuredAt,
+		})
+	}
+
+	if v["glucose"] != "" {
+		glucose, err := models.ParseImportedGlucose(v["glucose"], v["glucose_unit"])
+		if err != nil {
+			return nil, importError{"glucose", err.Error()}
+		}
+
+		meal, err := parseMeal(v["meal"])
+		if err != nil {
+			return nil, err
+		}
+
+		readings = append(readings, models.DeviceTelemetryData{
+			BloodGlucose: glucose,
+			Unit:         models.GlucoseUnitMgDL,
+			SampleType:   models.SampleTypeBlood,
+			Meal:         meal,
+			MeasuredAt:   measuredAt,
+		})
+	}
+
+	if len(readings) == 0 {
+		return nil, importError{"", "row has no measurements"}
+	}
+
+	return readings, nil
+}
+
+func parseMeasurement(values map[string]string, field string, max uint64) (uint, error) {
+	value, err := strconv.ParseFloat(values[field], 64)
+	if err != nil || value <= 0 {
+		return 0, importError{field, field + " must be a positive number"}
+	}
+	if uint64(value) > max {
+		return 0, importError{field, field + " is out of range"}
+	}
+	return uint(value + 0.5), nil
+}
+
+func parseMeal(value string) (string, error) {
+	switch strings.ToLower(value) {
+	case "":
+		return "Unknown", nil
+	case "before meal", "before", "fasting", "pre-meal", "premeal":
+		return models.MealBefore, nil
+	case "after meal", "after", "post-meal", "postmeal", "postprandial":
+		return models.MealAfter, nil
+	}
+	return "", importError{"meal", "unknown meal " + value + ", must be before meal or after meal"}
+}
+
+// patientKey is what a row or patient is matched on, empty when the fields are missing
+func patientKey(match models.PatientMatch, values map[string]string) string {
+	switch match {
+	case models.MatchMRN:
+		return strings.TrimSpace(values["mrn"])
+	case models.MatchEmail:
+		return strings.ToLower(strings.TrimSpace(values["email"]))
+	case models.MatchNameDOB:
+		first := strings.ToLower(strings.TrimSpace(values["first_name"]))
+		last := strings.ToLower(strings.TrimSpace(values["last_name"]))
+		dob := models.NormalizeDate(values["dob"])
+		if first == "" || last == "" || dob == "" {
+			return ""
+		}
+		return first + "|" + last + "|" + dob
+	}
+	return ""
+}
+
+func matchColumn(match models.PatientMatch) string {
+	switch match {
+	case models.MatchEmail:
+		return "email"
+	case models.MatchNameDOB:
+		return "first_name,last_name,dob"
+	}
+	return "mrn"
+}
+
+func normalizeColumn(column string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+}
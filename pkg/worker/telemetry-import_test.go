@@ -0,0 +1,213 @@
+package worker
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParseTelemetryImport(t *testing.T) {
+	csvFile := "\ufeffMRN, Taken At ,SYSTOLIC,diastolic\n" +
+		"A-1, 2024-01-15 08:00 ,120,80\n" +
+		"A-2,2024-01-16 09:30,,\n"
+
+	rows, err := ParseTelemetryImport(models.ImportCSV, strings.NewReader(csvFile), models.MatchMRN, map[string]string{
+		"measured_at": "Taken At",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+
+	first := rows[0]
+	if first.Line != 2 || first.Values["mrn"] != "A-1" || first.Values["measured_at"] != "2024-01-15 08:00" ||
+		first.Values["systolic"] != "120" || first.Values["diastolic"] != "80" {
+		t.Errorf("got first row %+v, want line 2 with the mapped and trimmed values", first)
+	}
+	if first.Raw != "A-1,2024-01-15 08:00 ,120,80" {
+		t.Errorf("got raw %q, want the record as read", first.Raw)
+	}
+	if _, ok := rows[1].Values["systolic"]; ok || rows[1].Line != 3 {
+		t.Errorf("got second row %+v, want line 3 without empty values", rows[1])
+	}
+
+	jsonFile := `[{"Email": "Jane@Example.com", "measured_at": 1705305600, "weight": 180.5, "meal": null}]`
+	rows, err = ParseTelemetryImport(models.ImportJSON, strings.NewReader(jsonFile), models.MatchEmail, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Line != 1 || rows[0].Values["email"] != "Jane@Example.com" ||
+		rows[0].Values["measured_at"] != "1705305600" || rows[0].Values["weight"] != "180.5" {
+		t.Errorf("got %+v, want one row numbered 1 with numbers kept as written", rows)
+	}
+	if _, ok := rows[0].Values["meal"]; ok {
+		t.Errorf("got meal %q, want null values left out", rows[0].Values["meal"])
+	}
+}
+
+func TestParseTelemetryImportRejectsFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  models.ImportFormat
+		match   models.PatientMatch
+		file    string
+		wantErr string
+	}{
+		{"missing match column", models.ImportCSV, models.MatchMRN, "email,measured_at\na@b.c,2024-01-15 08:00\n", "file has no mrn column"},
+		{"missing name and dob", models.ImportCSV, models.MatchNameDOB, "first_name,last_name,measured_at\nJane,Doe,2024-01-15 08:00\n", "file has no dob column"},
+		{"missing measured_at", models.ImportCSV, models.MatchMRN, "mrn,systolic\nA-1,120\n", "file has no measured_at column"},
+		{"header only", models.ImportCSV, models.MatchMRN, "mrn,measured_at\n", "file has no rows"},
+		{"empty file", models.ImportCSV, models.MatchMRN, "", "failed to read the header row"},
+		{"json object", models.ImportJSON, models.MatchMRN, `{"mrn": "A-1"}`, "file must be a JSON array of objects"},
+		{"unknown format", models.ImportFormat("XML"), models.MatchMRN, "<rows/>", "unsupported format XML"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTelemetryImport(tt.format, strings.NewReader(tt.file), tt.match, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRowReadings(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	readings, err := rowReadings(ImportRow{Values: map[string]string{
+		"measured_at":         "2024-01-15 08:00",
+		"systolic":            "121.6",
+		"diastolic":           "80",
+		"pulse":               "70",
+		"irregular_heartbeat": "Yes",
+		"weight":              "80",
+		"weight_unit":         "kg",
+		"glucose":             "5.5",
+		"glucose_unit":        "mmol/L",
+		"meal":                "Fasting",
+	}}, loc, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(readings) != 3 {
+		t.Fatalf("got %d readings, want blood pressure, weight and glucose", len(readings))
+	}
+
+	// Times without an offset are the patient's local time
+	want := time.Date(2024, time.January, 15, 14, 0, 0, 0, time.UTC)
+	for _, r := range readings {
+		if !r.MeasuredAt.Equal(want) {
+			t.Errorf("got measured at %s, want %s", r.MeasuredAt, want)
+		}
+	}
+
+	bp, weight, glucose := readings[0], readings[1], readings[2]
+	if bp.SystolicBP != 122 || bp.DiastolicBP != 80 || bp.Pulse != 70 || !bp.IrregularHeartBeat {
+		t.Errorf("got blood pressure %+v, want 122/80 with pulse 70 and an irregular heartbeat", bp)
+	}
+	if weight.Weight != 176 || weight.WeightStableTime == 0 {
+		t.Errorf("got weight %d settled for %d, want 176 lb settled", weight.Weight, weight.WeightStableTime)
+	}
+	if glucose.BloodGlucose != 99 || glucose.Unit != models.GlucoseUnitMgDL || glucose.Meal != models.MealBefore || !glucose.IsGlucoseSample() {
+		t.Errorf("got glucose %+v, want a 99 mg/dL fasting blood sample", glucose)
+	}
+}
+
+func TestRowReadingsRejectsRows(t *testing.T) {
+	now := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		values     map[string]string
+		wantColumn string
+		wantErr    string
+	}{
+		{"no measured_at", map[string]string{"systolic": "120", "diastolic": "80"}, "measured_at", "measured_at is required"},
+		{"unreadable time", map[string]string{"measured_at": "yesterday", "weight": "180"}, "measured_at", "unrecognized time yesterday"},
+		{"future reading", map[string]string{"measured_at": "2024-03-01 08:00", "weight": "180"}, "measured_at", "measured_at is in the future"},
+		{"systolic without diastolic", map[string]string{"measured_at": "2024-01-15 08:00", "systolic": "120"}, "systolic", "blood pressure needs systolic and diastolic"},
+		{"pulse without blood pressure", map[string]string{"measured_at": "2024-01-15 08:00", "pulse": "70"}, "systolic", "blood pressure needs systolic and diastolic"},
+		{"systolic below diastolic", map[string]string{"measured_at": "2024-01-15 08:00", "systolic": "80", "diastolic": "120"}, "systolic", "systolic must be higher than diastolic"},
+		{"diastolic out of range", map[string]string{"measured_at": "2024-01-15 08:00", "systolic": "290", "diastolic": "250"}, "diastolic", "diastolic is out of range"},
+		{"negative pulse", map[string]string{"measured_at": "2024-01-15 08:00", "systolic": "120", "diastolic": "80", "pulse": "-1"}, "pulse", "pulse must be a positive number"},
+		{"unknown weight unit", map[string]string{"measured_at": "2024-01-15 08:00", "weight": "80", "weight_unit": "stone"}, "weight", "unknown weight unit stone"},
+		{"glucose out of range", map[string]string{"measured_at": "2024-01-15 08:00", "glucose": "60", "glucose_unit": "mmol/L"}, "glucose", "blood glucose is out of range"},
+		{"unknown meal", map[string]string{"measured_at": "2024-01-15 08:00", "glucose": "110", "meal": "lunch"}, "meal", "unknown meal lunch"},
+		{"no measurements", map[string]string{"measured_at": "2024-01-15 08:00"}, "", "row has no measurements"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := rowReadings(ImportRow{Values: tt.values}, time.UTC, now)
+
+			var rowErr importError
+			if !errors.As(err, &rowErr) {
+				t.Fatalf("got %v, want a row error", err)
+			}
+			if rowErr.column != tt.wantColumn || !strings.Contains(rowErr.message, tt.wantErr) {
+				t.Errorf("got %q on column %q, want %q on column %q", rowErr.message, rowErr.column, tt.wantErr, tt.wantColumn)
+			}
+		})
+	}
+}
+
+func TestKeyPatients(t *testing.T) {
+	patient := func(id uint, mrn, email, first, last, dob string) models.User {
+		return models.User{ID: &id, MRN: mrn, Email: email, FirstName: first, LastName: last, DOB: dob}
+	}
+	patients := []models.User{
+		patient(1, "A-1", "jane@example.com", "Jane", "Doe", "1950-03-02"),
+		patient(2, "A-2", "JOHN@example.com", "John", "Doe", "03/02/1950"),
+		patient(3, "A-2", "", "John", "Doe", "1950-03-02"),
+		patient(4, "", "", "Ann", "Lee", ""),
+	}
+
+	tests := []struct {
+		name   string
+		match  models.PatientMatch
+		row    map[string]string
+		wantID uint
+		// wantAmbiguous rows match several patients and so none of them
+		wantAmbiguous bool
+	}{
+		{"mrn", models.MatchMRN, map[string]string{"mrn": " A-1 "}, 1, false},
+		{"shared mrn", models.MatchMRN, map[string]string{"mrn": "A-2"}, 0, true},
+		{"email ignores case", models.MatchEmail, map[string]string{"email": "John@Example.com"}, 2, false},
+		{"name and dob in any date format", models.MatchNameDOB, map[string]string{"first_name": "jane", "last_name": "DOE", "dob": "3/2/1950"}, 1, false},
+		{"shared name and dob", models.MatchNameDOB, map[string]string{"first_name": "John", "last_name": "Doe", "dob": "1950-03-02"}, 0, true},
+		{"unknown mrn", models.MatchMRN, map[string]string{"mrn": "A-9"}, 0, false},
+		{"patient without a dob is never matched", models.MatchNameDOB, map[string]string{"first_name": "Ann", "last_name": "Lee", "dob": ""}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patientsByKey, ambiguous := keyPatients(tt.match, patients)
+
+			key := patientKey(tt.match, tt.row)
+			if ambiguous[key] != tt.wantAmbiguous {
+				t.Errorf("got ambiguous %v, want %v", ambiguous[key], tt.wantAmbiguous)
+			}
+			if tt.wantAmbiguous {
+				return
+			}
+
+			var gotID uint
+			if p, ok := patientsByKey[key]; ok && key != "" {
+				gotID = *p.ID
+			}
+			if gotID != tt.wantID {
+				t.Errorf("got patient %d, want %d", gotID, tt.wantID)
+			}
+		})
+	}
+}
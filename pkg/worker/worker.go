@@ -123,6 +123,48 @@ func RunCPTWorker() {
 		}
 	})
 
+	_, _ = s.Tag("AlertNotification").Every(1).Minute().Do(func() {
+		if err := ProcessAlertNotifications(); err != nil {
+			fmt.Println(err)
+		}
+	})
+
+	_, _ = s.Tag("DeviceHealth").Every(1).Hour().Do(func() {
+		if err := ProcessDeviceHealth(); err != nil {
+			fmt.Println(err)
+		}
+	})
+
+	_, _ = s.Tag("Shipments").Every(30).Minutes().Do(func() {
+		if err := ProcessShipments(); err != nil {
+			fmt.Println(err)
+		}
+	})
+
+	_, _ = s.Tag("AdherenceReminders").Every(1).Day().At("10:00").Do(func() {
+		if err := ProcessAdherenceReminders(); err != nil {
+			fmt.Println(err)
+		}
+	})
+
+	_, _ = s.Tag("Retention").Every(1).Day().At("03:00").Do(func() {
+		if err := ArchiveTelemetry(); err != nil {
+			fmt.Println(err)
+		}
+	})
+
+	_, _ = s.Tag("StaleTelemetryImports").Every(10).Minutes().Do(func() {
+		if err := FailStaleTelemetryImports(); err != nil {
+			fmt.Println(err)
+		}
+	})
+
+	_, _ = s.Tag("ClinicalSummaries").Cron("0 6 1 * *").Do(func() {
+		if err := ProcessClinicalSummaries(); err != nil {
+			fmt.Println(err)
+		}
+	})
+
 	s.RunAllWithDelay(time.Second * 2)
 
 	_, _ = s.Tag("Worker").Every(6).Hour().Do(func() {
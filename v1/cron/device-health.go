@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/validator"
+	"MedKick-backend/pkg/worker"
+	"net/http"
+	"os"
+
+	"github.com/labstack/echo/v4"
+)
+
+// processDeviceHealth godoc
+// @Summary Process Device Health
+// @Description CRON ONLY - Raises and resolves device alerts for offline, low battery and weak signal devices
+// @Tags CRON
+// @Accept json
+// @Produce json
+// @Param CronToken body Request true "Token Request"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /cron/device-health [post]
+func processDeviceHealth(c echo.Context) error {
+	var req Request
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Invalid request",
+		})
+	}
+
+	if req.Token != os.Getenv("CRON_SECRET") {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "Invalid token",
+		})
+	}
+
+	if err := worker.ProcessDeviceHealth(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to process device health",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -7,4 +7,10 @@ func Routes(r *echo.Group) {
 	r.POST("/cron/sync-devices", syncDevices)
 	r.POST("/cron/trigger-cpt-worker", triggerCptWorker)
 	r.POST("/cron/clear-test-billings", clearTestBillings)
+	r.POST("/cron/alert-notifications", processAlertNotifications)
+	r.POST("/cron/device-health", processDeviceHealth)
+	r.POST("/cron/shipments", processShipments)
+	r.POST("/cron/adherence-reminders", processAdherenceReminders)
+	r.POST("/cron/archive-telemetry", processTelemetryArchive)
+	r.POST("/cron/clinical-summaries", processClinicalSummaries)
 }
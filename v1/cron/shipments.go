@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/validator"
+	"MedKick-backend/pkg/worker"
+	"net/http"
+	"os"
+
+	"github.com/labstack/echo/v4"
+)
+
+// processShipments godoc
+// @Summary Process Shipments
+// @Description CRON ONLY - Pulls the status of open device shipments from Mio Connect and links delivered devices to their patient
+// @Tags CRON
+// @Accept json
+// @Produce json
+// @Param CronToken body Request true "Token Request"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /cron/shipments [post]
+func processShipments(c echo.Context) error {
+	var req Request
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Invalid request",
+		})
+	}
+
+	if req.Token != os.Getenv("CRON_SECRET") {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "Invalid token",
+		})
+	}
+
+	if err := worker.ProcessShipments(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to process shipments",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -1,27 +1,27 @@
 package cron
 
 import (
-	"MedKick-backend/pkg/database/models"
 	"MedKick-backend/pkg/echo/dto"
 	mioApi "MedKick-backend/pkg/mio/api"
 	"MedKick-backend/pkg/validator"
+	"MedKick-backend/pkg/worker"
 	"net/http"
 	"os"
-	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
 // syncDevices godoc
 // @Summary Sync Devices from Mio Connect
-// @Description CRON ONLY - Pulls and Syncs devices from Mio-Connect
+// @Description CRON ONLY - Pulls devices from Mio-Connect into the unassigned pool, refreshes known devices and stores a reconciliation report
 // @Tags CRON
 // @Accept json
 // @Produce json
 // @Param CronToken body Request true "Token Request"
-// @Success 200
+// @Success 200 {object} models.DeviceSyncRun
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
+// @Failure 502 {object} dto.ErrorResponse
 // @Router /cron/sync-devices [post]
 func syncDevices(c echo.Context) error {
 	var req Request
@@ -43,80 +43,18 @@ func syncDevices(c echo.Context) error {
 		})
 	}
 
-	mioClient := mioApi.NewClient(os.Getenv("MIO_API_KEY"))
-	mioDevices, err := mioClient.GetDeviceList()
+	run, err := worker.SyncDevices(c.Request().Context(), mioApi.NewClient(os.Getenv("MIO_API_KEY")))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error: "Failed to get devices from Mio Connect",
+			Error: "Failed to sync devices",
 		})
 	}
 
-	for _, mioDevice := range mioDevices.Items {
-		device := models.Device{
-			IMEI: mioDevice.IMEI,
-		}
-		if err := device.GetDeviceByIMEI(); err != nil {
-			// Create new device
-			fetchDevice, err := mioClient.GetDevice(mioDevice.DeviceID)
-			if err != nil {
-				return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-					Error: "Failed to get device from Mio Connect",
-				})
-			}
-
-			switch fetchDevice.ModelNumber {
-			case "TBM-2092-G":
-				device.Name = "Sphygmomanometer"
-			case "GBS-2104-G":
-				device.Name = "Weight Scale"
-			case "TBM-2282-G":
-				device.Name = "Blood Glucose Meter"
-			}
-
-			device.ModelNumber = fetchDevice.ModelNumber
-			device.SerialNumber = fetchDevice.SerialNumber
-			device.CreatedAt = fetchDevice.CreatedAt
-			device.FirmwareVersion = fetchDevice.FirmwareVersion
-			device.UpdatedAt = time.Now()
-			device.Name = ""
-			device.BatteryLevel = 0
-			device.SignalStrength = ""
-			device.UserID = 2
-
-			if err := device.CreateDevice(); err != nil {
-				return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-					Error: "Failed to create device",
-				})
-			}
-		}
-
-		// Update device
-		fetchDevice, err := mioClient.GetDevice(mioDevice.DeviceID)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-				Error: "Failed to get device from Mio Connect",
-			})
-		}
-
-		if device.Name == "" {
-			switch fetchDevice.ModelNumber {
-			case "TBM-2092-G":
-				device.Name = "Sphygmomanometer"
-			case "GBS-2104-G":
-				device.Name = "Weight Scale"
-			case "TBM-2282-G":
-				device.Name = "Blood Glucose Meter"
-			}
-		}
-
-		device.FirmwareVersion = fetchDevice.FirmwareVersion
-		device.UpdatedAt = time.Now()
-		if err := device.UpdateDevice(); err != nil {
-			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-				Error: "Failed to update device",
-			})
-		}
+	if run.Error != "" {
+		return c.JSON(http.StatusBadGateway, dto.ErrorResponse{
+			Error: "Failed to get devices from Mio Connect: " + run.Error,
+		})
 	}
 
-	return c.NoContent(http.StatusOK)
+	return c.JSON(http.StatusOK, run)
 }
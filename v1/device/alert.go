@@ -0,0 +1,197 @@
+package device
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/event"
+	"MedKick-backend/pkg/worker"
+	"errors"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+// raiseTelemetryAlerts evaluates a stored reading against the patient's thresholds and
+// trend rules and records the result of every condition against its alert episode. The
+// base alert carries the organization, patient, device type and reading the alerts belong to.
+func raiseTelemetryAlerts(base models.TelemetryAlert, dtd models.DeviceTelemetryData) {
+	alertThreshold, err := models.ListEffectiveAlertThresholds([]uint{dtd.UserID})
+	if err != nil {
+		log.Errorf("Failed to list alert threshold: %s", err)
+	}
+
+	requiredNormal := autoResolveNormalReadings(base.OrganizationID)
+
+	for _, alert := range thresholdAlerts(base, dtd, alertThreshold) {
+		recordTelemetryAlert(alert, requiredNormal)
+	}
+
+	raiseTrendAlerts(base, dtd, requiredNormal)
+}
+
+// autoResolveNormalReadings returns how many consecutive normal readings close an open
+// alert for the organization, 0 means alerts are only resolved by hand
+func autoResolveNormalReadings(organizationID uint) uint {
+	setting := models.InteractionSetting{
+		OrganizationID: organizationID,
+		Type:           models.AutoResolveNormalReadings,
+	}
+
+	if err := setting.GetInteractionSetting(); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Errorf("Failed to get auto resolve setting: %s", err)
+		}
+		return models.DefaultAutoResolveNormalReadings
+	}
+
+	if setting.Value < 0 {
+		return 0
+	}
+
+	return uint(setting.Value)
+}
+
+// recordTelemetryAlert applies one evaluated condition to its alert episode and publishes
+// what changed, newly opened episodes are also notified
+func recordTelemetryAlert(alert models.TelemetryAlert, requiredNormal uint) {
+	if alert.PatientID == 0 || alert.AlertType == models.AlertNotApplicable {
+		return
+	}
+
+	episode, change, err := models.RecordTelemetryAlert(alert, requiredNormal)
+	if err != nil {
+		log.Errorf("Failed to record telemetry alert: %s", err)
+		return
+	}
+
+	switch change {
+	case models.EpisodeOpened:
+		event.Publish(event.AlertRaised, episode.OrganizationID, episode.PatientID, episode)
+		go worker.NotifyTelemetryAlert(episode)
+	case models.EpisodeUpdated:
+		event.Publish(event.AlertUpdated, episode.OrganizationID, episode.PatientID, episode)
+	case models.EpisodeResolved:
+		event.Publish(event.AlertResolved, episode.OrganizationID, episode.PatientID, episode)
+	}
+}
+
+// thresholdAlerts returns one alert per condition in the reading, evaluated against the
+// patient's absolute thresholds. Conditions within range are returned as AlertOk.
+func thresholdAlerts(base models.TelemetryAlert, dtd models.DeviceTelemetryData, thresholds []models.AlertThreshold) []models.TelemetryAlert {
+	var alerts []models.TelemetryAlert
+
+	switch base.DeviceType {
+	case models.BloodPressure:
+		bpAlert := base
+		bpAlert.AlertType = dtd.GetStatusByPatientThreshold(models.BloodPressure, thresholds)
+		bpAlert.Data = map[string]interface{}{
+			string(models.Systolic):  dtd.SystolicBP,
+			string(models.Diastolic): dtd.DiastolicBP,
+		}
+		alerts = append(alerts, bpAlert)
+
+		if dtd.Pulse > 0 {
+			pulseAlert := base
+			pulseAlert.MeasurementType = models.Pulse
+			pulseAlert.AlertType = dtd.GetStatusByMeasurementThreshold(models.BloodPressure, models.Pulse, thresholds)
+			pulseAlert.Data = map[string]interface{}{
+				string(models.Pulse):              dtd.Pulse,
+				string(models.IrregularHeartBeat): dtd.IrregularHeartBeat,
+			}
+			alerts = append(alerts, pulseAlert)
+		}
+	case models.WeightScale:
+		weightAlert := base
+		weightAlert.MeasurementType = models.Weight
+		weightAlert.AlertType = dtd.GetStatusByPatientThreshold(models.WeightScale, thresholds)
+		weightAlert.Data = map[string]interface{}{
+			string(models.Weight): dtd.Weight,
+		}
+		alerts = append(alerts, weightAlert)
+	case models.BloodGlucose:
+		// Control solution and invalid samples are stored but never alerted on
+		if !dtd.IsGlucoseSample() {
+			break
+		}
+		measurementType := dtd.GlucoseMeasurementType()
+		glucoseAlert := base
+		glucoseAlert.MeasurementType = measurementType
+		glucoseAlert.AlertType = dtd.GetStatusByPatientThreshold(models.BloodGlucose, thresholds)
+		glucoseAlert.Data = map[string]interface{}{
+			string(measurementType): dtd.BloodGlucoseMgDL(),
+			"unit":                  models.GlucoseUnitMgDL,
+			"meal":                  dtd.Meal,
+		}
+		alerts = append(alerts, glucoseAlert)
+	}
+
+	return alerts
+}
+
+// raiseTrendAlerts evaluates the patient's trend rules for the reading's device type
+// against their earlier readings and records the result of every rule against its
+// alert episode. The base alert carries the patient, device and reading the alerts belong to.
+func raiseTrendAlerts(base models.TelemetryAlert, dtd models.DeviceTelemetryData, requiredNormal uint) {
+	if base.DeviceType == "" || dtd.UserID == 0 {
+		return
+	}
+
+	rules, err := models.ListAlertTrendRules([]uint{dtd.UserID})
+	if err != nil {
+		log.Errorf("Failed to list alert trend rules: %s", err)
+		return
+	}
+
+	for _, rule := range rules {
+		if rule.DeviceType != base.DeviceType {
+			continue
+		}
+
+		history, err := models.ListPatientMeasurementHistory(dtd.UserID, rule.MeasurementType, dtd.MeasuredAt.Add(-rule.Window()), dtd.MeasuredAt)
+		if err != nil {
+			log.Errorf("Failed to list measurement history: %s", err)
+			continue
+		}
+
+		ruleID := rule.ID
+		alertType, change, comparison := rule.Evaluate(dtd, history)
+		if alertType == models.AlertNotApplicable {
+			// Readings the rule can't judge must not count towards resolving its episode
+			continue
+		}
+		if alertType == models.AlertOk {
+			normal := base
+			normal.MeasurementType = rule.MeasurementType
+			normal.TrendRuleID = &ruleID
+			normal.AlertType = models.AlertOk
+			recordTelemetryAlert(normal, requiredNormal)
+			continue
+		}
+
+		value, _ := dtd.MeasurementValue(rule.MeasurementType)
+
+		comparisonData := make([]map[string]interface{}, 0, len(comparison))
+		for _, c := range comparison {
+			v, _ := c.MeasurementValue(rule.MeasurementType)
+			comparisonData = append(comparisonData, map[string]interface{}{
+				"telemetry_id": c.ID,
+				"value":        v,
+				"measured_at":  c.MeasuredAt,
+			})
+		}
+
+		alert := base
+		alert.MeasurementType = rule.MeasurementType
+		alert.TrendRuleID = &ruleID
+		alert.AlertType = alertType
+		alert.Data = map[string]interface{}{
+			string(rule.MeasurementType): value,
+			"rule_type":                  rule.RuleType,
+			"direction":                  rule.Direction,
+			"window_hours":               rule.WindowHours,
+			"change":                     change,
+			"comparison":                 comparisonData,
+		}
+
+		recordTelemetryAlert(alert, requiredNormal)
+	}
+}
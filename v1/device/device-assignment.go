@@ -0,0 +1,130 @@
+package device
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/validator"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DeviceStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=InStock Shipped Delivered Active Returned Lost Retired" example:"Shipped"`
+}
+
+// getOrganizationDevice loads a device and makes sure non-admins only reach devices held by
+// their organization's patients
+func getOrganizationDevice(c echo.Context, self models.User) (*models.Device, error) {
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return nil, c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	device := &models.Device{
+		ID: uint(idInt),
+	}
+	if err := device.GetDevice(); err != nil {
+		return nil, c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Device not found",
+		})
+	}
+
+	if self.Role != "admin" {
+		if device.User.OrganizationID == nil || self.OrganizationID == nil || *device.User.OrganizationID != *self.OrganizationID {
+			return nil, c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error: "Forbidden",
+			})
+		}
+	}
+
+	return device, nil
+}
+
+// updateDeviceStatus godoc
+// @Summary Update Device Status
+// @Description Move a device through its inventory lifecycle. Returned, lost and retired devices are taken back from their patient.
+// @Tags Devices
+// @Accept json
+// @Produce json
+// @Param id path string true "Device ID"
+// @Param request body DeviceStatusRequest true "Device Status"
+// @Success 200 {object} models.Device
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /device/{id}/status [patch]
+func updateDeviceStatus(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
+	device, err := getOrganizationDevice(c, self)
+	if device == nil {
+		return err
+	}
+
+	var request DeviceStatusRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(request); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	status := models.DeviceLifecycle(request.Status)
+	if status == models.DeviceActive && device.UserID == 0 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Assign the device to a patient to activate it",
+		})
+	}
+
+	if err := device.UpdateStatus(status); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to update device status",
+		})
+	}
+
+	return c.JSON(http.StatusOK, device)
+}
+
+// listDeviceAssignments godoc
+// @Summary List Device Assignments
+// @Description List the patients that held a device, newest first
+// @Tags Devices
+// @Accept json
+// @Produce json
+// @Param id path string true "Device ID"
+// @Success 200 {object} []models.DeviceAssignment
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /device/{id}/assignments [get]
+func listDeviceAssignments(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
+	device, err := getOrganizationDevice(c, self)
+	if device == nil {
+		return err
+	}
+
+	assignments, err := models.ListDeviceAssignments(device.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list device assignments",
+		})
+	}
+
+	return c.JSON(http.StatusOK, assignments)
+}
@@ -0,0 +1,103 @@
+package device
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	mioApi "MedKick-backend/pkg/mio/api"
+	"MedKick-backend/pkg/worker"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// createDeviceSyncRun godoc
+// @Summary Sync Devices
+// @Description Sync the device inventory with Mio Connect now and return the reconciliation report
+// @Tags Devices
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.DeviceSyncRun
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /device/sync-run [post]
+func createDeviceSyncRun(c echo.Context) error {
+	run, err := worker.SyncDevices(c.Request().Context(), mioApi.NewClient(os.Getenv("MIO_API_KEY")))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to sync devices",
+		})
+	}
+
+	return c.JSON(http.StatusOK, run)
+}
+
+// listDeviceSyncRuns godoc
+// @Summary List Device Sync Runs
+// @Description List the latest device sync runs with their counts, newest first
+// @Tags Devices
+// @Accept json
+// @Produce json
+// @Param limit query int false "Number of runs (default 20, max 100)"
+// @Success 200 {object} []models.DeviceSyncRun
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /device/sync-run [get]
+func listDeviceSyncRuns(c echo.Context) error {
+	limit := 20
+	if raw := c.QueryParam("limit"); raw != "" {
+		l, err := strconv.Atoi(raw)
+		if err != nil || l <= 0 || l > 100 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Invalid limit",
+			})
+		}
+		limit = l
+	}
+
+	runs, err := models.ListDeviceSyncRuns(limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list device sync runs",
+		})
+	}
+
+	return c.JSON(http.StatusOK, runs)
+}
+
+// getDeviceSyncRun godoc
+// @Summary Get Device Sync Run
+// @Description Get a device sync run with its findings: added, updated, missing upstream, unknown model and failed devices
+// @Tags Devices
+// @Accept json
+// @Produce json
+// @Param id path string true "Sync Run ID"
+// @Success 200 {object} models.DeviceSyncRun
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /device/sync-run/{id} [get]
+func getDeviceSyncRun(c echo.Context) error {
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	run := &models.DeviceSyncRun{
+		ID: uint(idInt),
+	}
+	if err := run.GetDeviceSyncRun(); err != nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Device sync run not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, run)
+}
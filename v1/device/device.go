@@ -115,6 +115,8 @@ func GetAvailableDevices(c echo.Context) error {
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /device/assign-device [patch]
 func AssignDevice(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
 	var request DeviceAssignRequest
 	if err := c.Bind(&request); err != nil {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
@@ -148,10 +150,14 @@ func AssignDevice(c echo.Context) error {
 		})
 	}
 
-	// Assign device to user
-	device = &models.Device{}
+	if !device.Status.IsAssignable() {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Device is " + string(device.Status) + " and cannot be assigned",
+		})
+	}
 
-	if err := device.AssignDeviceToUser(request.DeviceID, request.UserID); err != nil {
+	// Assign device to user
+	if err := device.AssignPatient(request.UserID, self.ID); err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "Failed to assign device to user",
 		})
@@ -243,16 +249,29 @@ func updateDevice(c echo.Context) error {
 	if request.FirmwareVersion != "" {
 		device.FirmwareVersion = request.FirmwareVersion
 	}
-	if request.UserID != nil {
-		device.UserID = *request.UserID
-	}
-
 	if err := device.UpdateDevice(); err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "Failed to update device",
 		})
 	}
 
+	if request.UserID != nil && *request.UserID != device.UserID {
+		if *request.UserID == 0 {
+			err = device.UnassignPatient(models.DeviceReturned)
+		} else if !device.Status.IsAssignable() {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Device is " + string(device.Status) + " and cannot be assigned",
+			})
+		} else {
+			err = device.AssignPatient(*request.UserID, self.ID)
+		}
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error: "Failed to update device assignment",
+			})
+		}
+	}
+
 	return c.JSON(http.StatusOK, dto.MessageResponse{
 		Message: "Successfully updated device",
 	})
@@ -0,0 +1,94 @@
+package device
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// getFirmwareReport godoc
+// @Summary Get Firmware Report
+// @Description Report which firmware versions are deployed per device model and the quality of the readings uploaded with each version, to spot firmware regressions. Admins see the whole fleet or one organization, org admins their organization.
+// @Tags Devices
+// @Accept json
+// @Produce json
+// @Param organization_id query int false "Organization ID, admins only"
+// @Param days query int false "Days of readings to include (default 30, max 365)"
+// @Success 200 {object} []models.FirmwareVersionReport
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /device/firmware-report [get]
+func getFirmwareReport(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
+	organizationID := uint(0)
+	if self.Role != "admin" {
+		organizationID = *self.OrganizationID
+	} else if raw := c.QueryParam("organization_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Invalid organization_id",
+			})
+		}
+		organizationID = uint(id)
+	}
+
+	days := 30
+	if raw := c.QueryParam("days"); raw != "" {
+		d, err := strconv.Atoi(raw)
+		if err != nil || d <= 0 || d > 365 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Invalid days",
+			})
+		}
+		days = d
+	}
+
+	report, err := models.GetFirmwareReport(organizationID, time.Now().UTC().AddDate(0, 0, -days))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get firmware report",
+		})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// listDeviceLogs godoc
+// @Summary List Device Logs
+// @Description List the hardware and firmware versions a device reported, newest first. A new log is kept every time a version changes.
+// @Tags Devices
+// @Accept json
+// @Produce json
+// @Param id path string true "Device ID"
+// @Success 200 {object} []models.DeviceLogData
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /device/{id}/logs [get]
+func listDeviceLogs(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
+	device, err := getOrganizationDevice(c, self)
+	if device == nil {
+		return err
+	}
+
+	logs, err := models.GetDeviceLogDataByDevice(device.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list device logs",
+		})
+	}
+
+	return c.JSON(http.StatusOK, logs)
+}
@@ -9,17 +9,35 @@ import (
 func Routes(r *echo.Group) {
 	r.POST("/mio/forwardtelemetry", ingestTelemetry)
 	r.POST("/mio/forwardstatus", ingestStatus)
+	r.POST("/mio/forwardlog", ingestLog)
 
 	r.GET("/mio/telemetry/:id", getTelemetry, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
 	r.GET("/mio/telemetry/:id/latest", getLatestTelemetry, middleware.NotGuest)
 	r.GET("/mio/telemetry/:id/count", getNumberOfTelemetryEntriesThisWeek, middleware.NotGuest)
+	r.POST("/mio/telemetry/manual", createManualReading, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
+	r.PATCH("/mio/telemetry/reading/:id/invalidate", invalidateReading, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.PATCH("/mio/telemetry/reading/:id/restore", restoreReading, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
 
 	r.GET("/mio/status/:id", getStatus, middleware.NotGuest)
 
 	r.GET("/device/available-devices", GetAvailableDevices, middleware.NotGuest)
 	r.PATCH("/device/assign-device", AssignDevice, middleware.NotGuest, middleware.HasRole("admin"))
 
+	r.POST("/device/shipment", createShipment, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.GET("/device/shipment", listShipments, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.GET("/device/shipment/:id", getShipment, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.POST("/device/shipment/:id/refresh", refreshShipment, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+
+	r.POST("/device/sync-run", createDeviceSyncRun, middleware.NotGuest, middleware.HasRole("admin"))
+	r.GET("/device/sync-run", listDeviceSyncRuns, middleware.NotGuest, middleware.HasRole("admin"))
+	r.GET("/device/sync-run/:id", getDeviceSyncRun, middleware.NotGuest, middleware.HasRole("admin"))
+
+	r.GET("/device/firmware-report", getFirmwareReport, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+
 	r.GET("/device/:id", getDevice, middleware.NotGuest)
 	r.PATCH("/device/:id", updateDevice, middleware.NotGuest)
 	r.DELETE("/device/:id", deleteDevice, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.PATCH("/device/:id/status", updateDeviceStatus, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.GET("/device/:id/assignments", listDeviceAssignments, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.GET("/device/:id/logs", listDeviceLogs, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
 }
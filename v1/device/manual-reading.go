@@ -0,0 +1,212 @@
+package device
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/event"
+	"MedKick-backend/pkg/validator"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type ManualReadingRequest struct {
+	PatientID  uint                 `json:"patient_id" validate:"required" example:"1"`
+	Source     models.ReadingSource `json:"source" validate:"required,oneof=PatientReported ClinicMeasured Device" example:"ClinicMeasured"`
+	DeviceType models.DeviceType    `json:"device_type" validate:"required,oneof=BloodPressure BloodGlucose WeightScale" example:"BloodPressure"`
+	// DeviceID is the patient's device the reading was read off, if any
+	DeviceID *uint `json:"device_id" example:"1"`
+	//Sphygmomanometer
+	SystolicBP         uint `json:"systolic_bp" validate:"omitempty,max=300" example:"120"`
+	DiastolicBP        uint `json:"diastolic_bp" validate:"omitempty,max=200" example:"80"`
+	Pulse              uint `json:"pulse" validate:"omitempty,max=250" example:"72"`
+	IrregularHeartBeat bool `json:"irregular_heartbeat" example:"false"`
+	//Weight Scale
+	Weight uint `json:"weight" validate:"omitempty,max=1000" example:"180"`
+	//Blood Glucose Meter
+	// BloodGlucose is in Unit, mmol/L values like 6.1 are stored converted to mg/dL
+	BloodGlucose float64 `json:"blood_glucose" validate:"omitempty,gt=0,max=1000" example:"110"`
+	Unit         string  `json:"unit" validate:"omitempty,oneof=mg/dL mmol/L" example:"mg/dL"`
+	Meal         string  `json:"meal" validate:"omitempty,oneof='before meal' 'after meal'" example:"before meal"`
+
+	MeasuredAt *time.Time `json:"measured_at" example:"2021-01-01T08:00:00Z"`
+	Note       string     `json:"note" validate:"max=1000" example:"Taken at the clinic after a 5 minute rest"`
+}
+
+// measurementError returns what is missing for the device type, or an empty string
+func (r ManualReadingRequest) measurementError() string {
+	switch r.DeviceType {
+	case models.BloodPressure:
+		if r.SystolicBP == 0 || r.DiastolicBP == 0 {
+			return "Blood pressure readings need systolic_bp and diastolic_bp"
+		}
+	case models.WeightScale:
+		if r.Weight == 0 {
+			return "Weight readings need weight"
+		}
+	case models.BloodGlucose:
+		glucose := models.GlucoseMgDL(r.BloodGlucose, r.Unit)
+		if glucose == 0 {
+			return "Blood glucose readings need blood_glucose"
+		}
+		if glucose > 1000 {
+			return "blood_glucose is out of range"
+		}
+	}
+	return ""
+}
+
+// createManualReading godoc
+// @Summary Create Manual Reading
+// @Description Record a reading that was called in, taken at the clinic or read off a device by hand. The reading keeps who entered it and goes through the same alerting as device uploads, but never counts towards device supply billing like 99453 and 99454. Patients can only report their own readings.
+// @Tags Mio
+// @Accept json
+// @Produce json
+// @Param request body ManualReadingRequest true "Manual Reading"
+// @Success 201 {object} models.DeviceTelemetryData
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mio/telemetry/manual [post]
+func createManualReading(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
+	var req ManualReadingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if msg := req.measurementError(); msg != "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: msg,
+		})
+	}
+
+	measuredAt := time.Now().UTC()
+	if req.MeasuredAt != nil {
+		if req.MeasuredAt.After(measuredAt) {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "measured_at can't be in the future",
+			})
+		}
+		measuredAt = req.MeasuredAt.UTC()
+	}
+
+	patient := models.User{
+		ID: &req.PatientID,
+	}
+	if err := patient.GetUser(); err != nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "User not found",
+		})
+	}
+
+	if patient.Role != "patient" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "User is not a patient",
+		})
+	}
+
+	switch self.Role {
+	case "admin":
+	case "patient":
+		if *self.ID != req.PatientID || req.Source != models.SourcePatientReported {
+			return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error: "Patients can only report their own readings",
+			})
+		}
+	default:
+		if patient.OrganizationID == nil || self.OrganizationID == nil || *patient.OrganizationID != *self.OrganizationID {
+			return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error: "Forbidden",
+			})
+		}
+	}
+
+	dtd := &models.DeviceTelemetryData{
+		Source:      req.Source,
+		EnteredByID: self.ID,
+		Note:        req.Note,
+		UserID:      req.PatientID,
+		MeasuredAt:  measuredAt,
+	}
+
+	if req.DeviceID != nil {
+		device := &models.Device{
+			ID: *req.DeviceID,
+		}
+		if err := device.GetDevice(); err != nil {
+			return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error: "Device not found",
+			})
+		}
+
+		if device.UserID != req.PatientID {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Device is not assigned to the patient",
+			})
+		}
+
+		dtd.DeviceID = device.ID
+	}
+
+	switch req.DeviceType {
+	case models.BloodPressure:
+		dtd.SystolicBP = req.SystolicBP
+		dtd.DiastolicBP = req.DiastolicBP
+		dtd.Pulse = req.Pulse
+		dtd.IrregularHeartBeat = req.IrregularHeartBeat
+	case models.WeightScale:
+		dtd.Weight = req.Weight
+		// Entered weights are final, there is no scale to settle
+		dtd.WeightStableTime = 1
+	case models.BloodGlucose:
+		dtd.BloodGlucose = models.GlucoseMgDL(req.BloodGlucose, req.Unit)
+		dtd.Unit = models.GlucoseUnitMgDL
+		dtd.SampleType = models.SampleTypeBlood
+		dtd.Meal = req.Meal
+		if dtd.Meal == "" {
+			dtd.Meal = "Unknown"
+		}
+	}
+
+	if err := dtd.CreateDeviceTelemetryData(); err != nil {
+		log.Errorf("Failed to create manual reading: %s", err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create manual reading",
+		})
+	}
+
+	organizationID := uint(0)
+	if patient.OrganizationID != nil {
+		organizationID = *patient.OrganizationID
+	}
+
+	event.Publish(event.TelemetryCreated, organizationID, req.PatientID, dtd)
+
+	raiseTelemetryAlerts(models.TelemetryAlert{
+		OrganizationID: organizationID,
+		DeviceID:       dtd.DeviceID,
+		DeviceType:     req.DeviceType,
+		TelemetryID:    dtd.ID,
+		PatientID:      req.PatientID,
+		IsActive:       true,
+		IsAutoResolved: false,
+		MeasuredAt:     measuredAt,
+	}, *dtd)
+
+	return c.JSON(http.StatusCreated, dtd)
+}
@@ -3,10 +3,12 @@ package device
 import (
 	"MedKick-backend/pkg/database/models"
 	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/event"
 	"MedKick-backend/pkg/validator"
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -14,33 +16,33 @@ import (
 )
 
 type MioData struct {
-	DataType           string `json:"data_type" validate:"required"`
-	IMEI               string `json:"imei" validate:"required"`
-	SerialNumber       string `json:"sn"`
-	Iccid              string `json:"iccid"`
-	User               uint   `json:"user"`
-	SystolicBP         uint   `json:"sys"`
-	DiastolicBP        uint   `json:"dia"`
-	Pulse              uint   `json:"pul"`
-	IrregularHeartBeat bool   `json:"ihb"`
-	HandShaking        bool   `json:"hand"`
-	TripleMeasure      bool   `json:"tri"`
-	Battery            uint   `json:"bat" validate:"required"`
-	Signal             uint   `json:"sig"`
-	Timestamp          int64  `json:"ts"`
-	Timezone           string `json:"tz"`
-	UID                string `json:"uid"`
-	Weight             uint   `json:"wt"`
-	WeightStableTime   uint   `json:"wet"`
-	WeightLockCount    uint   `json:"lts"`
-	UploadTime         int64  `json:"upload_time"`
-	BloodGlucose       uint   `json:"data"`
-	Unit               uint   `json:"unit"`
-	TestPaperType      uint   `json:"sample"`
-	SampleType         uint   `json:"sample_type"`
-	Meal               uint   `json:"meal"`
-	SignalLevel        uint   `json:"sig_lvl"`
-	Uptime             int64  `json:"uptime"`
+	DataType           string  `json:"data_type" validate:"required"`
+	IMEI               string  `json:"imei" validate:"required"`
+	SerialNumber       string  `json:"sn"`
+	Iccid              string  `json:"iccid"`
+	User               uint    `json:"user"`
+	SystolicBP         uint    `json:"sys"`
+	DiastolicBP        uint    `json:"dia"`
+	Pulse              uint    `json:"pul"`
+	IrregularHeartBeat bool    `json:"ihb"`
+	HandShaking        bool    `json:"hand"`
+	TripleMeasure      bool    `json:"tri"`
+	Battery            uint    `json:"bat" validate:"required"`
+	Signal             uint    `json:"sig"`
+	Timestamp          int64   `json:"ts"`
+	Timezone           string  `json:"tz"`
+	UID                string  `json:"uid"`
+	Weight             uint    `json:"wt"`
+	WeightStableTime   uint    `json:"wet"`
+	WeightLockCount    uint    `json:"lts"`
+	UploadTime         int64   `json:"upload_time"`
+	BloodGlucose       float64 `json:"data"`
+	Unit               uint    `json:"unit"`
+	TestPaperType      uint    `json:"sample"`
+	SampleType         uint    `json:"sample_type"`
+	Meal               uint    `json:"meal"`
+	SignalLevel        uint    `json:"sig_lvl"`
+	Uptime             int64   `json:"uptime"`
 }
 
 type MioStatus struct {
@@ -56,6 +58,24 @@ type MioStatus struct {
 	AttachTime       int64  `json:"at_t"`
 }
 
+type MioLog struct {
+	DataType        string `json:"data_type" validate:"required"`
+	IMEI            string `json:"imei" validate:"required"`
+	Battery         uint   `json:"bat"`
+	HardwareVersion string `json:"hw_ver"`
+	MCUVersion      string `json:"mcu_ver"`
+	AppVersion      string `json:"app_ver"`
+	ModemVersion    string `json:"modem_ver"`
+	BPMAlgo         string `json:"algo_ver"`
+}
+
+// statusDeviceNames maps the status data types to the name of the device that sends them
+var statusDeviceNames = map[string]string{
+	"bpm_gen2_status":   "Sphygmomanometer",
+	"scale_gen2_status": "Weight Scale",
+	"bgm_gen1_status":   "Blood Glucose Meter",
+}
+
 type RequestTelemetry struct {
 	DeviceID    string  `json:"deviceId" validate:"required"`
 	IsTest      bool    `json:"isTest"`
@@ -72,6 +92,14 @@ type RequestStatus struct {
 	CreatedAt   uint      `json:"createdAt" validate:"required"`
 }
 
+type RequestLog struct {
+	DeviceID    string `json:"deviceId" validate:"required"`
+	IsTest      bool   `json:"isTest"`
+	ModelNumber string `json:"modelNumber" validate:"required"`
+	Log         MioLog `json:"log"`
+	CreatedAt   uint   `json:"createdAt" validate:"required"`
+}
+
 // ingestTelemetry godoc
 // @Summary Ingest Data
 // @Description Mio Connect Data Ingestion Endpoint (Webhook)
@@ -143,30 +171,47 @@ func ingestTelemetry(c echo.Context) error {
 		})
 	}
 
-	organizationID := uint(0)
-	if device.User.OrganizationID != nil {
-		organizationID = *device.User.OrganizationID
+	currentTime := time.Unix(req.Data.Timestamp, 0)
+
+	// Attribute the reading to whoever held the device when it was taken, which may not be
+	// the current patient if the device was reassigned before the reading was uploaded
+	patientID, err := device.AssignedPatientAt(currentTime)
+	if err != nil {
+		log.Errorf("Failed to get device assignment: %s", err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get device assignment",
+		})
 	}
 
-	currentTime := time.Unix(req.Data.Timestamp, 0)
+	patient := device.User
+	if patientID != device.UserID {
+		patient = models.User{ID: &patientID}
+		if patientID != 0 {
+			if err := patient.GetUser(); err != nil {
+				log.Warnf("Failed to get assigned patient %d: %s", patientID, err)
+			}
+		}
+	}
+
+	organizationID := uint(0)
+	if patient.OrganizationID != nil {
+		organizationID = *patient.OrganizationID
+	}
 
 	telemetryAlert := models.TelemetryAlert{
 		OrganizationID: organizationID,
 		DeviceID:       device.ID,
-		PatientID:      device.UserID,
+		PatientID:      patientID,
 		IsActive:       true,
 		IsAutoResolved: false,
 		MeasuredAt:     currentTime,
 	}
 
-	alertThreshold, err := models.ListAlertThresholds([]uint{device.UserID})
-	if err != nil {
-		log.Errorf("Failed to list alert threshold: %s", err)
-	}
+	var dtd *models.DeviceTelemetryData
 
 	if req.Data.DataType == "bpm_gen2_measure" {
 
-		dtd := &models.DeviceTelemetryData{
+		dtd = &models.DeviceTelemetryData{
 			SystolicBP:         req.Data.SystolicBP,
 			DiastolicBP:        req.Data.DiastolicBP,
 			Pulse:              req.Data.Pulse,
@@ -174,7 +219,8 @@ func ingestTelemetry(c echo.Context) error {
 			HandShaking:        req.Data.HandShaking,
 			TripleMeasurement:  req.Data.TripleMeasure,
 			DeviceID:           device.ID,
-			UserID:             device.UserID,
+			FirmwareVersion:    device.FirmwareVersion,
+			UserID:             patientID,
 			MeasuredAt:         currentTime,
 		}
 
@@ -187,21 +233,15 @@ func ingestTelemetry(c echo.Context) error {
 			})
 		}
 
-		currentAlertType := dtd.GetStatusByPatientThreshold(models.BloodPressure, alertThreshold)
 		telemetryAlert.DeviceType = models.BloodPressure
-		telemetryAlert.TelemetryID = dtd.ID
-		telemetryAlert.AlertType = currentAlertType
-		telemetryAlert.Data = map[string]interface{}{
-			string(models.Systolic):  dtd.SystolicBP,
-			string(models.Diastolic): dtd.DiastolicBP,
-		}
 	} else if req.Data.DataType == "scale_gen2_measure" {
-		dtd := &models.DeviceTelemetryData{
+		dtd = &models.DeviceTelemetryData{
 			Weight:           req.Data.Weight,
 			WeightStableTime: req.Data.WeightStableTime,
 			WeightLockCount:  req.Data.WeightLockCount,
 			DeviceID:         device.ID,
-			UserID:           device.UserID,
+			FirmwareVersion:  device.FirmwareVersion,
+			UserID:           patientID,
 			MeasuredAt:       currentTime,
 		}
 		if err := dtd.CreateDeviceTelemetryData(); err != nil {
@@ -210,12 +250,14 @@ func ingestTelemetry(c echo.Context) error {
 				Error: "Failed to create device telemetry data",
 			})
 		}
+
+		telemetryAlert.DeviceType = models.WeightScale
 	} else if req.Data.DataType == "bgm_gen1_measure" {
 		unit := ""
 		if req.Data.Unit == 1 {
-			unit = "mmol/L"
+			unit = models.GlucoseUnitMmolL
 		} else if req.Data.Unit == 2 {
-			unit = "mg/dL"
+			unit = models.GlucoseUnitMgDL
 		} else {
 			unit = "Unknown"
 		}
@@ -231,31 +273,38 @@ func ingestTelemetry(c echo.Context) error {
 
 		sampleType := ""
 		if req.Data.SampleType == 1 {
-			sampleType = "blood or resistance"
+			sampleType = models.SampleTypeBlood
 		} else if req.Data.SampleType == 2 {
-			sampleType = "quality control liquid"
+			sampleType = models.SampleTypeControl
 		} else {
-			sampleType = "sample is invalid"
+			sampleType = models.SampleTypeInvalid
 		}
 
 		meal := ""
 		if req.Data.Meal == 1 {
-			meal = "before meal"
+			meal = models.MealBefore
 		} else if req.Data.Meal == 2 {
-			meal = "after meal"
+			meal = models.MealAfter
 		} else {
 			meal = "Unknown"
 		}
 
-		dtd := &models.DeviceTelemetryData{
-			BloodGlucose: req.Data.BloodGlucose,
-			Unit:         unit,
-			TestPaper:    testPaper,
-			SampleType:   sampleType,
-			Meal:         meal,
-			DeviceID:     device.ID,
-			UserID:       device.UserID,
-			MeasuredAt:   currentTime,
+		// Readings are stored in mg/dL whatever unit the meter displays
+		glucose := models.GlucoseMgDL(req.Data.BloodGlucose, unit)
+		if unit == models.GlucoseUnitMmolL {
+			unit = models.GlucoseUnitMgDL
+		}
+
+		dtd = &models.DeviceTelemetryData{
+			BloodGlucose:    glucose,
+			Unit:            unit,
+			TestPaper:       testPaper,
+			SampleType:      sampleType,
+			Meal:            meal,
+			DeviceID:        device.ID,
+			FirmwareVersion: device.FirmwareVersion,
+			UserID:          patientID,
+			MeasuredAt:      currentTime,
 		}
 
 		if err := dtd.CreateDeviceTelemetryData(); err != nil {
@@ -264,6 +313,8 @@ func ingestTelemetry(c echo.Context) error {
 				Error: "Failed to create device telemetry data",
 			})
 		}
+
+		telemetryAlert.DeviceType = models.BloodGlucose
 	} else {
 		log.Warnf("Unknown data type: %s", req.Data.DataType)
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
@@ -271,12 +322,26 @@ func ingestTelemetry(c echo.Context) error {
 		})
 	}
 
-	if telemetryAlert.AlertType != models.AlertOk {
-		if err := telemetryAlert.InsertTelemetryAlert(); err != nil {
-			log.Errorf("Failed to upsert telemetry alert: %s", err)
-		}
+	if err := device.RecordReading(currentTime, req.Data.Signal); err != nil {
+		log.Errorf("Failed to record device reading: %s", err)
+	}
+
+	if patientID == 0 {
+		log.Warnf("Reading from device %d at %s has no assigned patient", device.ID, currentTime)
+		return c.NoContent(http.StatusNoContent)
 	}
 
+	event.Publish(event.TelemetryCreated, organizationID, patientID, dtd)
+
+	// Control and invalid readings are stored but never alerted on
+	if !dtd.IsCountable() {
+		log.Infof("Reading %d from device %d is %s, skipping alerts", dtd.ID, device.ID, dtd.Quality)
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	telemetryAlert.TelemetryID = dtd.ID
+	raiseTelemetryAlerts(telemetryAlert, *dtd)
+
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -327,15 +392,16 @@ func ingestStatus(c echo.Context) error {
 		})
 	}
 
+	deviceName, ok := statusDeviceNames[req.Status.DataType]
+	if !ok {
+		log.Warnf("Unknown data type: %s", req.Status.DataType)
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: fmt.Sprintf("Unknown data type, %s", req.Status.DataType),
+		})
+	}
+
 	if device.Name == "" {
-		switch req.Status.DataType {
-		case "bpm_gen2_status":
-			device.Name = "Sphygmomanometer"
-		case "scale_gen2_status":
-			device.Name = "Weight Scale"
-		case "bgm_gen1_status":
-			device.Name = "Blood Glucose Meter"
-		}
+		device.Name = deviceName
 
 		if err := device.UpdateDevice(); err != nil {
 			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
@@ -344,69 +410,130 @@ func ingestStatus(c echo.Context) error {
 		}
 	}
 
-	if req.Status.DataType == "bpm_gen2_status" {
-		t := time.Unix(req.Status.AttachTime, 0)
-		dsd := &models.DeviceStatusData{
-			Timezone:      req.Status.Timezone,
-			NetworkOps:    req.Status.NetworkOperators,
-			NetworkFormat: req.Status.NetworkFormat,
-			Signal:        req.Status.Signal,
-			Temperature:   req.Status.SOCTemperature,
-			AttachTime:    t,
-		}
+	dsd := &models.DeviceStatusData{
+		Timezone:      req.Status.Timezone,
+		NetworkOps:    req.Status.NetworkOperators,
+		NetworkFormat: req.Status.NetworkFormat,
+		Battery:       req.Status.Battery,
+		Signal:        req.Status.Signal,
+		Temperature:   req.Status.SOCTemperature,
+		MeasureCount:  req.Status.MeasureCount,
+		AttachTime:    time.Unix(req.Status.AttachTime, 0),
+		DeviceID:      device.ID,
+	}
 
-		if err := dsd.CreateDeviceStatusData(); err != nil {
-			log.Errorf("Failed to create device status data: %s", err)
-			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-				Error: "Failed to create device status data",
-			})
-		}
+	if err := dsd.CreateDeviceStatusData(); err != nil {
+		log.Errorf("Failed to create device status data: %s", err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create device status data",
+		})
+	}
 
-		return c.NoContent(http.StatusNoContent)
+	if err := device.RecordStatus(*dsd); err != nil {
+		log.Errorf("Failed to record device status: %s", err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to update device status",
+		})
 	}
-	if req.Status.DataType == "scale_gen2_status" {
-		t := time.Unix(req.Status.AttachTime, 0)
-		dsd := &models.DeviceStatusData{
-			Timezone:      req.Status.Timezone,
-			NetworkOps:    req.Status.NetworkOperators,
-			NetworkFormat: req.Status.NetworkFormat,
-			Signal:        req.Status.Signal,
-			Temperature:   req.Status.SOCTemperature,
-			AttachTime:    t,
-		}
 
-		if err := dsd.CreateDeviceStatusData(); err != nil {
-			log.Errorf("Failed to create device status data: %s", err)
-			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-				Error: "Failed to create device status data",
-			})
-		}
+	if device.UserID != 0 && device.User.OrganizationID != nil {
+		event.Publish(event.DeviceStatus, *device.User.OrganizationID, device.UserID, dsd)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ingestLog godoc
+// @Summary Ingest Log
+// @Description Mio Connect Device Log Ingestion Endpoint (Webhook). Stores the hardware, MCU, app, modem and BPM algorithm versions the device reports and keeps the device's firmware version current. A log with the same versions as the last one only refreshes it.
+// @Tags Mio (DO NOT USE)
+// @Accept json
+// @Produce json
+// @Param create body RequestLog true "Request"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mio/forwardlog [post]
+func ingestLog(c echo.Context) error {
+	//Verify API Key from header
+	apiKey := c.Request().Header.Get("X-MIO-KEY")
+	if apiKey != os.Getenv("MIO_API_KEY") {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "Unauthorized",
+		})
+	}
+
+	var req RequestLog
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("Failed to bind device log: %s", err)
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
 
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if req.IsTest {
 		return c.NoContent(http.StatusNoContent)
 	}
-	if req.Status.DataType == "bgm_gen1_status" {
-		t := time.Unix(req.Status.AttachTime, 0)
-		dsd := &models.DeviceStatusData{
-			Timezone:      req.Status.Timezone,
-			NetworkOps:    req.Status.NetworkOperators,
-			NetworkFormat: req.Status.NetworkFormat,
-			Signal:        req.Status.Signal,
-			Temperature:   req.Status.SOCTemperature,
-			AttachTime:    t,
-		}
 
-		if err := dsd.CreateDeviceStatusData(); err != nil {
-			log.Errorf("Failed to create device status data: %s", err)
+	device := &models.Device{
+		IMEI: req.Log.IMEI,
+	}
+	if err := device.GetDeviceByIMEI(); err != nil {
+		log.Errorf("Failed to get device by IMEI: %s", err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get device by IMEI",
+		})
+	}
+
+	dld := &models.DeviceLogData{
+		HardwareVersion: req.Log.HardwareVersion,
+		MCUVersion:      req.Log.MCUVersion,
+		AppVersion:      req.Log.AppVersion,
+		ModemVersion:    req.Log.ModemVersion,
+		BPMAlgo:         req.Log.BPMAlgo,
+		DeviceID:        device.ID,
+	}
+	if req.Log.Battery > 0 {
+		dld.Battery = strconv.FormatUint(uint64(req.Log.Battery), 10)
+	}
+
+	// Only version changes start a new log, so the logs are the device's firmware history
+	if latest, err := models.GetLatestDeviceLogData(device.ID); err == nil && latest.SameVersions(*dld) {
+		if dld.Battery != "" {
+			latest.Battery = dld.Battery
+		}
+		if err := latest.UpdateDeviceLogData(); err != nil {
+			log.Errorf("Failed to update device log data: %s", err)
 			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-				Error: "Failed to create device status data",
+				Error: "Failed to update device log data",
 			})
 		}
+	} else if err := dld.CreateDeviceLogData(); err != nil {
+		log.Errorf("Failed to create device log data: %s", err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create device log data",
+		})
+	}
 
-		return c.NoContent(http.StatusNoContent)
+	if err := device.RecordFirmware(dld.FirmwareVersion()); err != nil {
+		log.Errorf("Failed to record device firmware: %s", err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to update device firmware",
+		})
+	}
+
+	if req.Log.Battery > 0 {
+		if err := device.UpdateBattery(req.Log.Battery); err != nil {
+			log.Errorf("Failed to update device battery: %s", err)
+		}
 	}
 
-	log.Warnf("Unknown data type: %s", req.Status.DataType)
-	return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-		Error: fmt.Sprintf("Unknown data type, %s", req.Status.DataType),
-	})
+	return c.NoContent(http.StatusNoContent)
 }
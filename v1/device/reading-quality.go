@@ -0,0 +1,141 @@
+package device
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/event"
+	"MedKick-backend/pkg/validator"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type InvalidateReadingRequest struct {
+	Reason string `json:"reason" validate:"required,max=255" example:"Cuff was on over clothing"`
+}
+
+// getOrganizationReading loads a reading and makes sure non-admins only reach readings of
+// their organization's patients
+func getOrganizationReading(c echo.Context, self models.User) (*models.DeviceTelemetryData, error) {
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return nil, c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	reading := &models.DeviceTelemetryData{
+		ID: uint(idInt),
+	}
+	if err := reading.GetDeviceTelemetryData(); err != nil {
+		return nil, c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Reading not found",
+		})
+	}
+
+	if self.Role != "admin" {
+		patient := models.User{
+			ID: &reading.UserID,
+		}
+		if err := patient.GetUser(); err != nil || patient.OrganizationID == nil || self.OrganizationID == nil || *patient.OrganizationID != *self.OrganizationID {
+			return nil, c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error: "Forbidden",
+			})
+		}
+	}
+
+	return reading, nil
+}
+
+// invalidateReading godoc
+// @Summary Invalidate Reading
+// @Description Mark a reading invalid with a reason. The reading is kept but no longer counts towards billing reading days, alerts or stats, and open alerts raised by this reading alone are resolved.
+// @Tags Mio
+// @Accept json
+// @Produce json
+// @Param id path string true "Reading ID"
+// @Param request body InvalidateReadingRequest true "Invalidate Reading"
+// @Success 200 {object} models.DeviceTelemetryData
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mio/telemetry/reading/{id}/invalidate [patch]
+func invalidateReading(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
+	var req InvalidateReadingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	reading, err := getOrganizationReading(c, self)
+	if reading == nil {
+		return err
+	}
+
+	if err := reading.InvalidateReading(self.ID, req.Reason); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to invalidate reading",
+		})
+	}
+
+	alerts, err := models.ResolveReadingAlerts(reading.ID, self.ID)
+	if err != nil {
+		log.Errorf("Failed to resolve alerts of reading %d: %s", reading.ID, err)
+	}
+	for _, alert := range alerts {
+		event.Publish(event.AlertResolved, alert.OrganizationID, alert.PatientID, alert)
+	}
+
+	return c.JSON(http.StatusOK, reading)
+}
+
+// restoreReading godoc
+// @Summary Restore Reading
+// @Description Undo the invalidation of a reading, its quality is derived from the device data again. Alerts resolved on invalidation are not reopened.
+// @Tags Mio
+// @Accept json
+// @Produce json
+// @Param id path string true "Reading ID"
+// @Success 200 {object} models.DeviceTelemetryData
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mio/telemetry/reading/{id}/restore [patch]
+func restoreReading(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
+	reading, err := getOrganizationReading(c, self)
+	if reading == nil {
+		return err
+	}
+
+	if reading.InvalidatedAt == nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Reading was not invalidated",
+		})
+	}
+
+	if err := reading.RestoreReading(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to restore reading",
+		})
+	}
+
+	return c.JSON(http.StatusOK, reading)
+}
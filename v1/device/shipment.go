@@ -0,0 +1,320 @@
+package device
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	mioApi "MedKick-backend/pkg/mio/api"
+	"MedKick-backend/pkg/validator"
+	"MedKick-backend/pkg/worker"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ShipmentItemRequest struct {
+	ModelNumber string `json:"model_number" validate:"required" example:"TBM-2092-G"`
+	Count       uint   `json:"count" validate:"required,min=1,max=10" example:"1"`
+}
+
+type CreateShipmentRequest struct {
+	PatientID uint                  `json:"patient_id" validate:"required" example:"1"`
+	Items     []ShipmentItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+// getOrganizationShipment loads a shipment and makes sure non-admins only reach their
+// organization's shipments
+func getOrganizationShipment(c echo.Context, self models.User) (*models.Shipment, error) {
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return nil, c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	shipment := &models.Shipment{
+		ID: uint(idInt),
+	}
+	if err := shipment.GetShipment(); err != nil {
+		return nil, c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Shipment not found",
+		})
+	}
+
+	if self.Role != "admin" && (self.OrganizationID == nil || shipment.OrganizationID != *self.OrganizationID) {
+		return nil, c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: "Forbidden",
+		})
+	}
+
+	return shipment, nil
+}
+
+// createShipment godoc
+// @Summary Create Shipment
+// @Description Order devices from Mio Connect to the patient's address. A failed order is kept with the error Mio returned.
+// @Tags Devices
+// @Accept json
+// @Produce json
+// @Param request body CreateShipmentRequest true "Shipment"
+// @Success 201 {object} models.Shipment
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 502 {object} dto.ErrorResponse
+// @Router /device/shipment [post]
+func createShipment(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
+	var request CreateShipmentRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(request); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	patient := models.User{
+		ID: &request.PatientID,
+	}
+	if err := patient.GetUser(); err != nil || patient.Role != "patient" {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Patient not found",
+		})
+	}
+
+	if patient.OrganizationID == nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Patient has no organization",
+		})
+	}
+
+	if self.Role != "admin" && (self.OrganizationID == nil || *patient.OrganizationID != *self.OrganizationID) {
+		return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: "Forbidden",
+		})
+	}
+
+	if patient.Address == "" || patient.City == "" || patient.State == "" || patient.ZipCode == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Patient address is incomplete",
+		})
+	}
+
+	country := patient.Country
+	if country == "" {
+		country = "USA"
+	}
+
+	shipment := &models.Shipment{
+		OrganizationID: *patient.OrganizationID,
+		PatientID:      request.PatientID,
+		OrderedByID:    self.ID,
+		Status:         models.ShipmentPending,
+		AddressLine:    patient.Address,
+		City:           patient.City,
+		State:          patient.State,
+		ZipCode:        patient.ZipCode,
+		Country:        country,
+		Phone:          patient.Phone,
+	}
+
+	mioItems := make([]mioApi.ShipmentItem, 0, len(request.Items))
+	for _, item := range request.Items {
+		shipment.Items = append(shipment.Items, models.ShipmentItem{
+			ModelNumber: item.ModelNumber,
+			Count:       item.Count,
+		})
+		mioItems = append(mioItems, mioApi.ShipmentItem{
+			DeviceId: item.ModelNumber,
+			Count:    item.Count,
+		})
+	}
+
+	if err := shipment.CreateShipment(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create shipment",
+		})
+	}
+
+	mioClient := mioApi.NewClient(os.Getenv("MIO_API_KEY"))
+	remote, err := mioClient.ShipItem(c.Request().Context(), mioApi.ShipmentRequest{
+		Address: mioApi.ShipmentAddress{
+			FirstName:   patient.FirstName,
+			LastName:    patient.LastName,
+			Country:     shipment.Country,
+			ZipCode:     shipment.ZipCode,
+			PhoneNumber: shipment.Phone,
+			City:        shipment.City,
+			State:       shipment.State,
+			AddressLine: shipment.AddressLine,
+		},
+		Items: mioItems,
+	})
+	if err != nil {
+		shipment.Status = models.ShipmentFailed
+		shipment.Error = err.Error()
+		if err := shipment.UpdateShipment(); err != nil {
+			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error: "Failed to update shipment",
+			})
+		}
+
+		return c.JSON(http.StatusBadGateway, dto.ErrorResponse{
+			Error: "Mio Connect rejected the shipment: " + err.Error(),
+		})
+	}
+
+	shipment.MioShipmentID = remote.ID
+	shipment.Status = models.ShipmentOrdered
+	if err := shipment.UpdateShipment(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to update shipment",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, shipment)
+}
+
+// listShipments godoc
+// @Summary List Shipments
+// @Description List device shipments, newest first
+// @Tags Devices
+// @Accept json
+// @Produce json
+// @Param patient_id query string false "Patient ID"
+// @Param status query string false "Status" Enums(Pending, Ordered, Shipped, Delivered, Cancelled, Failed)
+// @Param organization_id query string false "Organization ID (admin only)"
+// @Success 200 {object} []models.Shipment
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /device/shipment [get]
+func listShipments(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
+	var patientID, organizationID uint
+	if raw := c.QueryParam("patient_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Invalid patient_id",
+			})
+		}
+		patientID = uint(id)
+	}
+	if raw := c.QueryParam("organization_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Invalid organization_id",
+			})
+		}
+		organizationID = uint(id)
+	}
+
+	status := c.QueryParam("status")
+	switch models.ShipmentStatus(status) {
+	case "", models.ShipmentPending, models.ShipmentOrdered, models.ShipmentShipped,
+		models.ShipmentDelivered, models.ShipmentCancelled, models.ShipmentFailed:
+	default:
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Invalid status",
+		})
+	}
+
+	if self.Role != "admin" {
+		if self.OrganizationID == nil {
+			return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error: "Forbidden",
+			})
+		}
+		organizationID = *self.OrganizationID
+	}
+
+	shipments, err := models.ListShipments(organizationID, patientID, status)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list shipments",
+		})
+	}
+
+	return c.JSON(http.StatusOK, shipments)
+}
+
+// getShipment godoc
+// @Summary Get Shipment
+// @Description Get a device shipment with its items and linked devices
+// @Tags Devices
+// @Accept json
+// @Produce json
+// @Param id path string true "Shipment ID"
+// @Success 200 {object} models.Shipment
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /device/shipment/{id} [get]
+func getShipment(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
+	shipment, err := getOrganizationShipment(c, self)
+	if shipment == nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shipment)
+}
+
+// refreshShipment godoc
+// @Summary Refresh Shipment
+// @Description Pull the shipment's status from Mio Connect now instead of waiting for the scheduled sync
+// @Tags Devices
+// @Accept json
+// @Produce json
+// @Param id path string true "Shipment ID"
+// @Success 200 {object} models.Shipment
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 502 {object} dto.ErrorResponse
+// @Router /device/shipment/{id}/refresh [post]
+func refreshShipment(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
+	shipment, err := getOrganizationShipment(c, self)
+	if shipment == nil {
+		return err
+	}
+
+	if !shipment.Status.IsOpen() {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Shipment is " + string(shipment.Status) + " and can no longer be tracked",
+		})
+	}
+
+	if err := worker.RefreshShipment(c.Request().Context(), shipment); err != nil {
+		return c.JSON(http.StatusBadGateway, dto.ErrorResponse{
+			Error: "Failed to refresh shipment from Mio Connect: " + err.Error(),
+		})
+	}
+
+	if err := shipment.GetShipment(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get shipment",
+		})
+	}
+
+	return c.JSON(http.StatusOK, shipment)
+}
@@ -4,6 +4,7 @@ import (
 	"MedKick-backend/pkg/database/models"
 	"MedKick-backend/pkg/echo/dto"
 	"MedKick-backend/pkg/echo/middleware"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -13,13 +14,15 @@ import (
 
 // getStatus godoc
 // @Summary Get Status Data
-// @Description Get Status Data for given ID
+// @Description Get the status history of a device. The range is given either as dates (end date inclusive) or as RFC3339 timestamps, and defaults to the last 7 days.
 // @Tags Mio
 // @Accept json
 // @Produce json
 // @Param id path string false "Device ID"
 // @Param start_date query string false "Start Date (YYYY-MM-DD)"
 // @Param end_date query string false "End Date (YYYY-MM-DD)"
+// @Param from query string false "From (RFC3339), overrides start_date"
+// @Param to query string false "To (RFC3339), overrides end_date"
 // @Success 200 {object} []models.DeviceStatusData
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -53,30 +56,13 @@ func getStatus(c echo.Context) error {
 		})
 	}
 
-	startDateRaw := c.QueryParam("start_date")
-	endDateRaw := c.QueryParam("end_date")
-
-	//convert start_date and end_date to time.Time
-	startDate, err := time.Parse("2006-01-02", startDateRaw)
+	startDate, endDate, err := parseStatusRange(c.QueryParam("start_date"), c.QueryParam("end_date"), c.QueryParam("from"), c.QueryParam("to"))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error: "Failed to parse start_date",
+			Error: err.Error(),
 		})
 	}
 
-	var endDate time.Time
-
-	if endDateRaw == "" {
-		endDate = time.Now()
-	} else {
-		endDate, err = time.Parse("2006-01-02", endDateRaw)
-		if err != nil {
-			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-				Error: "Failed to parse end_date",
-			})
-		}
-	}
-
 	// Make sure startDate is before endDate
 	if startDate.After(endDate) {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
@@ -100,3 +86,44 @@ func getStatus(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, statuses)
 }
+
+// parseStatusRange resolves the status history range from either the date or the
+// timestamp query parameters
+func parseStatusRange(startDateRaw, endDateRaw, fromRaw, toRaw string) (time.Time, time.Time, error) {
+	endDate := time.Now()
+	startDate := endDate.AddDate(0, 0, -7)
+
+	if startDateRaw != "" {
+		d, err := time.Parse("2006-01-02", startDateRaw)
+		if err != nil {
+			return startDate, endDate, errors.New("Failed to parse start_date")
+		}
+		startDate = d
+	}
+
+	if endDateRaw != "" {
+		d, err := time.Parse("2006-01-02", endDateRaw)
+		if err != nil {
+			return startDate, endDate, errors.New("Failed to parse end_date")
+		}
+		endDate = d.AddDate(0, 0, 1)
+	}
+
+	if fromRaw != "" {
+		d, err := time.Parse(time.RFC3339, fromRaw)
+		if err != nil {
+			return startDate, endDate, errors.New("Failed to parse from")
+		}
+		startDate = d
+	}
+
+	if toRaw != "" {
+		d, err := time.Parse(time.RFC3339, toRaw)
+		if err != nil {
+			return startDate, endDate, errors.New("Failed to parse to")
+		}
+		endDate = d
+	}
+
+	return startDate, endDate, nil
+}
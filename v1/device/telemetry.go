@@ -56,8 +56,11 @@ func getTelemetry(c echo.Context) error {
 	startDateRaw := c.QueryParam("start_date")
 	endDateRaw := c.QueryParam("end_date")
 
+	// Dates are days in the patient's timezone
+	loc := models.GetPatientLocation(device.UserID)
+
 	//convert start_date and end_date to time.Time
-	startDate, err := time.Parse("01-02-2006", startDateRaw)
+	startDate, err := time.ParseInLocation("01-02-2006", startDateRaw, loc)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
 			Error: "Failed to parse start_date",
@@ -69,13 +72,15 @@ func getTelemetry(c echo.Context) error {
 	if endDateRaw == "" {
 		endDate = time.Now()
 	} else {
-		endDate, err = time.Parse("01-02-2006", endDateRaw)
+		endDate, err = time.ParseInLocation("01-02-2006", endDateRaw, loc)
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
 				Error: "Failed to parse end_date",
 			})
 		}
 	}
+	// The end date is inclusive
+	endDate = models.StartOfDay(endDate, loc).AddDate(0, 0, 1)
 
 	// Make sure startDate is before endDate
 	if startDate.After(endDate) {
@@ -153,7 +158,7 @@ func getLatestTelemetry(c echo.Context) error {
 
 // getNumberOfTelemetryEntriesThisWeek godoc
 // @Summary Get Number of Telemetry Entries This Week
-// @Description Get Number of Telemetry Entries This Week for given ID
+// @Description Get Number of Telemetry Entries over the last seven days, today included, for given ID. Days follow the patient's timezone.
 // @Tags Mio
 // @Accept json
 // @Produce json
@@ -191,7 +196,7 @@ func getNumberOfTelemetryEntriesThisWeek(c echo.Context) error {
 		})
 	}
 
-	count, err := models.GetNumberOfTelemetryEntriesThisWeek(device.ID)
+	count, err := models.GetNumberOfTelemetryEntriesThisWeek(device.ID, models.GetPatientLocation(device.UserID))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "Failed to get number of telemetry entries this week",
@@ -0,0 +1,235 @@
+package organization
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/notification"
+	"MedKick-backend/pkg/validator"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// createAlertNotificationRule godoc
+// @Summary Create Alert Notification Rule
+// @Description Create a rule for who is notified about telemetry alerts, over which channel and at which escalation tier
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param create body AlertNotificationRuleData true "Create Request"
+// @Success 201 {object} models.AlertNotificationRule
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/alert-notification-rule [post]
+func createAlertNotificationRule(c echo.Context) error {
+	req := struct {
+		OrganizationID uint `json:"-" param:"id" validate:"required"`
+		AlertNotificationRuleData
+	}{}
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role == "org_admin" || self.Role == "care_manager" {
+		req.OrganizationID = *self.OrganizationID
+	}
+
+	o := models.Organization{
+		ID: req.OrganizationID,
+	}
+
+	if err := o.GetOrganization(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get organization",
+		})
+	}
+
+	if req.Channel == models.ChannelWebhook {
+		if err := notification.ValidateWebhookURL(req.Target); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+	}
+
+	if req.RecipientType == models.RecipientOnCall && req.RecipientUserID == nil && req.Target == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "On-call rules require a recipient user or a target",
+		})
+	}
+
+	if req.RecipientUserID != nil {
+		u := models.User{
+			ID: req.RecipientUserID,
+		}
+
+		if err := u.GetUser(); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Recipient user not found",
+			})
+		}
+
+		if u.OrganizationID == nil || *u.OrganizationID != req.OrganizationID || u.Role == "patient" {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Recipient user is not staff of the organization",
+			})
+		}
+	}
+
+	rule := models.AlertNotificationRule{
+		OrganizationID:       req.OrganizationID,
+		Tier:                 req.Tier,
+		MinAlertType:         req.MinAlertType,
+		RecipientType:        req.RecipientType,
+		RecipientUserID:      req.RecipientUserID,
+		Channel:              req.Channel,
+		Target:               req.Target,
+		EscalateAfterMinutes: req.EscalateAfterMinutes,
+	}
+
+	if err := rule.CreateAlertNotificationRule(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create alert notification rule",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, rule)
+}
+
+// listAlertNotificationRules godoc
+// @Summary List Alert Notification Rules
+// @Description List the alert notification rules of an organization ordered by tier
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} []models.AlertNotificationRule
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/alert-notification-rule [get]
+func listAlertNotificationRules(c echo.Context) error {
+	var param struct {
+		OrganizationID uint `param:"id"`
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role == "org_admin" || self.Role == "care_manager" {
+		param.OrganizationID = *self.OrganizationID
+	}
+
+	rules, err := models.ListAlertNotificationRules(param.OrganizationID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list alert notification rules",
+		})
+	}
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+// deleteAlertNotificationRule godoc
+// @Summary Delete Alert Notification Rule
+// @Description Delete an alert notification rule of an organization
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param rule path int true "Rule ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/alert-notification-rule/{rule} [delete]
+func deleteAlertNotificationRule(c echo.Context) error {
+	var param struct {
+		OrganizationID uint `param:"id"`
+		RuleID         uint `param:"rule"`
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role == "org_admin" || self.Role == "care_manager" {
+		param.OrganizationID = *self.OrganizationID
+	}
+
+	if err := models.DeleteAlertNotificationRule(param.OrganizationID, param.RuleID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to delete alert notification rule",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Successfully deleted alert notification rule",
+	})
+}
+
+// listAlertNotifications godoc
+// @Summary List Alert Notifications
+// @Description List the notification delivery attempts of a telemetry alert
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param alert path int true "Alert ID"
+// @Success 200 {object} []models.AlertNotification
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/telemetry-alert/{alert}/notification [get]
+func listAlertNotifications(c echo.Context) error {
+	var param struct {
+		OrganizationID uint `param:"id"`
+		AlertID        uint `param:"alert"`
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role == "org_admin" || self.Role == "care_manager" {
+		param.OrganizationID = *self.OrganizationID
+	}
+
+	t := models.TelemetryAlert{
+		ID: param.AlertID,
+	}
+
+	if err := t.GetTelemetryAlertByID(); err != nil || t.OrganizationID != param.OrganizationID {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Telemetry alert not found",
+		})
+	}
+
+	notifications, err := models.ListAlertNotifications(t.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list alert notifications",
+		})
+	}
+
+	return c.JSON(http.StatusOK, notifications)
+}
@@ -0,0 +1,202 @@
+package organization
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/validator"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+// createAPIKey godoc
+// @Summary Create API Key
+// @Description Issue an API key for an integration or script of the organization. The key acts as one of the organization's users, limited to its scopes, and is sent as "Authorization: Bearer <token>". The token is only returned in this response. API keys can't manage API keys.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param key body APIKeyData true "API Key"
+// @Success 201 {object} APIKeyCreatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/api-key [post]
+func createAPIKey(c echo.Context) error {
+	if middleware.IsAPIKey(c) {
+		return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: "API keys can't manage API keys",
+		})
+	}
+
+	organizationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role != "admin" {
+		organizationID = int(*self.OrganizationID)
+	}
+
+	var req APIKeyData
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	userID := self.ID
+	if req.UserID != nil {
+		userID = req.UserID
+	}
+
+	// Keys act within one organization, so they can't act as an admin
+	user := models.User{
+		ID: userID,
+	}
+	if err := user.GetUser(); err != nil || user.OrganizationID == nil || *user.OrganizationID != uint(organizationID) || user.Role == "admin" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "The key's user must be a non-admin user of the organization",
+		})
+	}
+
+	key := &models.APIKey{
+		OrganizationID: uint(organizationID),
+		Name:           req.Name,
+		Scopes:         strings.Join(req.Scopes, ","),
+		UserID:         *user.ID,
+		CreatedByID:    *self.ID,
+	}
+	if req.ExpiresInDays != nil {
+		expiresAt := time.Now().AddDate(0, 0, int(*req.ExpiresInDays))
+		key.ExpiresAt = &expiresAt
+	}
+
+	token, err := key.CreateAPIKey()
+	if err != nil {
+		log.Errorf("Failed to create API key: %s", err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create API key",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, APIKeyCreatedResponse{
+		APIKey: *key,
+		Token:  token,
+	})
+}
+
+// listAPIKeys godoc
+// @Summary List API Keys
+// @Description List the organization's API keys with their scopes, expiry, last use and revocation, newest first
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} []models.APIKey
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/api-key [get]
+func listAPIKeys(c echo.Context) error {
+	organizationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role != "admin" {
+		organizationID = int(*self.OrganizationID)
+	}
+
+	keys, err := models.ListAPIKeys(uint(organizationID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list API keys",
+		})
+	}
+
+	return c.JSON(http.StatusOK, keys)
+}
+
+// revokeAPIKey godoc
+// @Summary Revoke API Key
+// @Description Revoke an API key, requests with its token are rejected from then on
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param key path int true "API Key ID"
+// @Success 200 {object} models.APIKey
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/api-key/{key}/revoke [post]
+func revokeAPIKey(c echo.Context) error {
+	if middleware.IsAPIKey(c) {
+		return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: "API keys can't manage API keys",
+		})
+	}
+
+	organizationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	keyID, err := strconv.Atoi(c.Param("key"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert key to uint",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role != "admin" {
+		organizationID = int(*self.OrganizationID)
+	}
+
+	key := &models.APIKey{
+		ID: uint(keyID),
+	}
+	if err := key.GetAPIKey(); err != nil || key.OrganizationID != uint(organizationID) {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "API key not found",
+		})
+	}
+
+	if key.RevokedAt != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "API key is already revoked",
+		})
+	}
+
+	if err := key.RevokeAPIKey(*self.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to revoke API key",
+		})
+	}
+
+	return c.JSON(http.StatusOK, key)
+}
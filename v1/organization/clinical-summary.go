@@ -0,0 +1,133 @@
+package organization
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/validator"
+	"MedKick-backend/pkg/worker"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+// generateClinicalSummaries godoc
+// @Summary Generate Clinical Summaries
+// @Description Generate the month's clinical summary PDF for every RPM patient of the organization. Existing unsigned summaries are regenerated, signed ones are kept. The reports are generated in the background, list the month's summaries for their status.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param period body ClinicalSummaryPeriodData true "Month (YYYY-MM)"
+// @Success 202 {object} []models.ClinicalSummary
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/clinical-summary [post]
+func generateClinicalSummaries(c echo.Context) error {
+	organizationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role != "admin" {
+		organizationID = int(*self.OrganizationID)
+	}
+
+	var req ClinicalSummaryPeriodData
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	start, err := models.ParsePeriod(req.Period, models.DefaultLocation())
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to parse period, must be YYYY-MM",
+		})
+	}
+	if start.After(time.Now()) {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Period is in the future",
+		})
+	}
+
+	org := models.Organization{
+		ID: uint(organizationID),
+	}
+	if err := org.GetOrganization(); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Organization does not exist",
+		})
+	}
+
+	summaries, err := worker.PrepareClinicalSummaries(org.ID, req.Period, self.ID, true)
+	if err != nil {
+		log.Errorf("Failed to prepare clinical summaries: %s", err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create clinical summaries",
+		})
+	}
+
+	response := make([]models.ClinicalSummary, len(summaries))
+	copy(response, summaries)
+	go worker.GenerateClinicalSummaries(summaries)
+
+	return c.JSON(http.StatusAccepted, response)
+}
+
+// listClinicalSummaries godoc
+// @Summary List Clinical Summaries
+// @Description List the clinical summaries of the organization's patients for a month with their status and sign-off
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param period query string true "Month (YYYY-MM)"
+// @Success 200 {object} []models.ClinicalSummary
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/clinical-summary [get]
+func listClinicalSummaries(c echo.Context) error {
+	organizationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role != "admin" {
+		organizationID = int(*self.OrganizationID)
+	}
+
+	period := c.QueryParam("period")
+	if _, err := models.ParsePeriod(period, models.DefaultLocation()); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to parse period, must be YYYY-MM",
+		})
+	}
+
+	summaries, err := models.ListOrganizationClinicalSummaries(uint(organizationID), period)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list clinical summaries",
+		})
+	}
+
+	return c.JSON(http.StatusOK, summaries)
+}
@@ -0,0 +1,116 @@
+package organization
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// getDeviceHealth godoc
+// @Summary Get Device Health
+// @Description Health of the organization's assigned devices with their open device alerts (no readings, low battery, weak signal, no heartbeat)
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param unhealthy query bool false "Only devices with open alerts"
+// @Success 200 {object} DeviceHealthResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/device-health [get]
+func getDeviceHealth(c echo.Context) error {
+	var param struct {
+		OrganizationID uint `param:"id"`
+		Unhealthy      bool `query:"unhealthy"`
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role == "org_admin" || self.Role == "care_manager" {
+		param.OrganizationID = *self.OrganizationID
+	}
+
+	devices, err := models.GetDevicesByOrganization(param.OrganizationID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get devices by organization",
+		})
+	}
+
+	deviceIDs := make([]uint, 0, len(devices))
+	for _, d := range devices {
+		deviceIDs = append(deviceIDs, d.ID)
+	}
+
+	alerts := make([]models.DeviceAlert, 0)
+	if len(deviceIDs) > 0 {
+		alerts, err = models.ListActiveDeviceAlerts(deviceIDs)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error: "Failed to get device alerts",
+			})
+		}
+	}
+
+	alertsByDevice := make(map[uint][]models.DeviceAlert)
+	for _, a := range alerts {
+		alertsByDevice[a.DeviceID] = append(alertsByDevice[a.DeviceID], a)
+	}
+
+	res := DeviceHealthResponse{
+		Limits: models.GetDeviceHealthLimits(param.OrganizationID),
+		Summary: map[string]int{
+			"total":                               len(devices),
+			"healthy":                             0,
+			string(models.DeviceAlertNoReadings):  0,
+			string(models.DeviceAlertLowBattery):  0,
+			string(models.DeviceAlertWeakSignal):  0,
+			string(models.DeviceAlertNoHeartbeat): 0,
+		},
+		Devices: make([]DeviceHealthRecord, 0),
+	}
+
+	for _, d := range devices {
+		deviceAlerts := alertsByDevice[d.ID]
+		if deviceAlerts == nil {
+			deviceAlerts = make([]models.DeviceAlert, 0)
+		}
+
+		for _, a := range deviceAlerts {
+			res.Summary[string(a.Type)]++
+		}
+
+		healthy := len(deviceAlerts) == 0
+		if healthy {
+			res.Summary["healthy"]++
+		}
+
+		if param.Unhealthy && healthy {
+			continue
+		}
+
+		res.Devices = append(res.Devices, DeviceHealthRecord{
+			DeviceID:       d.ID,
+			Name:           d.Name,
+			IMEI:           d.IMEI,
+			PatientID:      d.UserID,
+			PatientName:    d.User.FirstName + " " + d.User.LastName,
+			BatteryLevel:   d.BatteryLevel,
+			SignalStrength: d.SignalStrength,
+			LastReadingAt:  d.LastReadingAt,
+			LastStatusAt:   d.LastStatusAt,
+			Healthy:        healthy,
+			Alerts:         deviceAlerts,
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,41 @@
+package organization
+
+import (
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/event"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// streamOrganizationEvents godoc
+// @Summary Stream Organization Events
+// @Description Server-sent events stream of the organization's new telemetry (telemetry.created), raised, updated and resolved alerts (alert.raised, alert.updated, alert.resolved) and device status reports (device.status). Each message carries an Event as JSON.
+// @Tags Organization
+// @Produce text/event-stream
+// @Param id path int true "Organization ID"
+// @Success 200 {object} event.Event
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /organization/{id}/events [get]
+func streamOrganizationEvents(c echo.Context) error {
+	var param struct {
+		OrganizationID uint `param:"id"`
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role != "admin" {
+		param.OrganizationID = *self.OrganizationID
+	}
+
+	return event.Stream(c, func(e event.Event) bool {
+		return e.OrganizationID == param.OrganizationID
+	})
+}
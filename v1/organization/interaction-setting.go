@@ -87,7 +87,7 @@ func upsertInteractionSetting(c echo.Context) error {
 func getInteractionSetting(c echo.Context) error {
 	req := struct {
 		OrganizationID uint   `json:"-" param:"id" validate:"required"`
-		Filter         string `json:"-" query:"filter" validate:"required,oneof=ColorThreshold"`
+		Filter         string `json:"-" query:"filter" validate:"required,oneof=ColorThreshold AutoResolveNormalReadings AlertAckSLAMinutes AlertResolveSLAMinutes DeviceNoReadingDays DeviceLowBatteryPercent DeviceWeakSignal DeviceHeartbeatHours AdherenceStreakBreakDays AdherenceReminders"`
 	}{}
 
 	if err := c.Bind(&req); err != nil {
@@ -13,12 +13,45 @@ func Routes(r *echo.Group) {
 	r.DELETE("/organization/:id", deleteOrganization, middleware.NotGuest, middleware.HasRole("admin"))
 
 	r.GET("/organization/:id/devices", getDevicesInOrganization, middleware.NotGuest, middleware.HasRole("nurse", "doctor", "admin"))
+	r.GET("/organization/:id/device-health", getDeviceHealth, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
 
 	r.PUT("/organization/:id/interaction-setting", upsertInteractionSetting, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
 	r.GET("/organization/:id/interaction-setting", getInteractionSetting, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
 
+	r.GET("/organization/:id/events", streamOrganizationEvents, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+
 	r.GET("/organization/:id/telemetry-alert", listTelemetryAlert, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
 	r.PATCH("/organization/:id/telemetry-alert/:alert/resolve", resolvedTelemetryAlert, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.PATCH("/organization/:id/telemetry-alert/:alert/acknowledge", acknowledgeTelemetryAlert, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.PATCH("/organization/:id/telemetry-alert/:alert/assign", assignTelemetryAlert, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.PATCH("/organization/:id/telemetry-alert/:alert/triage", updateTelemetryAlertTriage, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.POST("/organization/:id/telemetry-alert/:alert/note", addTelemetryAlertNote, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.GET("/organization/:id/telemetry-alert/:alert/note", listTelemetryAlertNotes, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.GET("/organization/:id/telemetry-alert-sla", getTelemetryAlertSLA, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.GET("/organization/:id/telemetry-alert/:alert/notification", listAlertNotifications, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+
+	r.POST("/organization/:id/alert-notification-rule", createAlertNotificationRule, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.GET("/organization/:id/alert-notification-rule", listAlertNotificationRules, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.DELETE("/organization/:id/alert-notification-rule/:rule", deleteAlertNotificationRule, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+
+	r.PUT("/organization/:id/threshold-template", upsertThresholdTemplates, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.GET("/organization/:id/threshold-template", listThresholdTemplates, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.DELETE("/organization/:id/threshold-template/:template", deleteThresholdTemplate, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+
+	r.POST("/organization/:id/telemetry-import", createTelemetryImport, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.GET("/organization/:id/telemetry-import", listTelemetryImports, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.GET("/organization/:id/telemetry-import/:import", getTelemetryImport, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.GET("/organization/:id/telemetry-import/:import/errors", getTelemetryImportErrors, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.POST("/organization/:id/telemetry-import/:import/revert", revertTelemetryImport, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.POST("/organization/:id/clinical-summary", generateClinicalSummaries, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.GET("/organization/:id/clinical-summary", listClinicalSummaries, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.POST("/organization/:id/api-key", createAPIKey, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.GET("/organization/:id/api-key", listAPIKeys, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.POST("/organization/:id/api-key/:key/revoke", revokeAPIKey, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.GET("/organization/:id/mfa-policy", getMFAPolicy, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+	r.PUT("/organization/:id/mfa-policy", upsertMFAPolicy, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+
+	r.GET("/organization/:id/reading-adherence", getReadingAdherence, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
 
 	r.GET("/organization/:id/billing-report", getBillingReport, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
 
@@ -0,0 +1,127 @@
+package organization
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/validator"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// getMFAPolicy godoc
+// @Summary Get MFA Policy
+// @Description Get the roles of the organization whose users must use multi-factor authentication
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} models.MFAPolicy
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/mfa-policy [get]
+func getMFAPolicy(c echo.Context) error {
+	organizationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role != "admin" {
+		organizationID = int(*self.OrganizationID)
+	}
+
+	policy := models.MFAPolicy{
+		OrganizationID: uint(organizationID),
+	}
+	if err := policy.GetMFAPolicy(); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get MFA policy",
+		})
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
+
+// upsertMFAPolicy godoc
+// @Summary Upsert MFA Policy
+// @Description Set the roles of the organization whose users must use multi-factor authentication. Users of those roles without MFA have to set it up at their next login before they can use the API, and can't turn it off.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param policy body MFAPolicyData true "MFA Policy"
+// @Success 200 {object} models.MFAPolicy
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/mfa-policy [put]
+func upsertMFAPolicy(c echo.Context) error {
+	if middleware.IsAPIKey(c) {
+		return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: "API keys can't manage MFA",
+		})
+	}
+
+	organizationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role != "admin" {
+		organizationID = int(*self.OrganizationID)
+	}
+
+	var req MFAPolicyData
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	org := models.Organization{
+		ID: uint(organizationID),
+	}
+	if err := org.GetOrganization(); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Organization does not exist",
+		})
+	}
+
+	policy := models.MFAPolicy{
+		OrganizationID: org.ID,
+		Roles:          strings.Join(req.Roles, ","),
+		UpdatedByID:    self.ID,
+	}
+	if err := policy.UpsertMFAPolicy(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to upsert MFA policy",
+		})
+	}
+
+	if err := policy.GetMFAPolicy(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get MFA policy",
+		})
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
@@ -0,0 +1,90 @@
+package organization
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/validator"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// getReadingAdherence godoc
+// @Summary Get Reading Adherence
+// @Description Outreach worklist of the organization's RPM patients for this month: reading days so far, days remaining, whether 16 reading days are still achievable and streak breaks. Patients needing outreach come first, numbered by priority.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param status query string false "Only patients with this status" Enums(Met, OnTrack, AtRisk, Missed)
+// @Param all query bool false "Include patients that need no outreach"
+// @Success 200 {object} ReadingAdherenceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/reading-adherence [get]
+func getReadingAdherence(c echo.Context) error {
+	var param struct {
+		OrganizationID uint   `param:"id"`
+		Status         string `query:"status" validate:"omitempty,oneof=Met OnTrack AtRisk Missed"`
+		All            bool   `query:"all"`
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := validator.Validate.Struct(param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role == "org_admin" || self.Role == "care_manager" {
+		param.OrganizationID = *self.OrganizationID
+	}
+
+	now := time.Now()
+	adherence, err := models.GetReadingAdherence(param.OrganizationID, now)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get reading adherence",
+		})
+	}
+
+	res := ReadingAdherenceResponse{
+		Month:      now.In(models.DefaultLocation()).Format("2006-01"),
+		TargetDays: models.ReadingDaysTarget,
+		Summary: map[string]int{
+			"total":                         len(adherence),
+			"streak_broken":                 0,
+			string(models.AdherenceMet):     0,
+			string(models.AdherenceOnTrack): 0,
+			string(models.AdherenceAtRisk):  0,
+			string(models.AdherenceMissed):  0,
+		},
+		Patients: make([]models.ReadingAdherence, 0),
+	}
+
+	for _, a := range adherence {
+		res.Summary[string(a.Status)]++
+		if a.StreakBroken {
+			res.Summary["streak_broken"]++
+		}
+
+		if param.Status != "" && string(a.Status) != param.Status {
+			continue
+		}
+		if param.Status == "" && !param.All && !a.NeedsOutreach() {
+			continue
+		}
+
+		res.Patients = append(res.Patients, a)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,182 @@
+package organization
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/validator"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// getTelemetryAlertSLA godoc
+// @Summary Get Telemetry Alert SLA Report
+// @Description Time to acknowledge and time to resolve of the alerts raised in the date range, measured against the organization's targets
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param start_date query string true "Start Date (MM-DD-YYYY)"
+// @Param end_date query string true "End Date (MM-DD-YYYY)"
+// @Success 200 {object} TelemetryAlertSLAResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/telemetry-alert-sla [get]
+func getTelemetryAlertSLA(c echo.Context) error {
+	param := struct {
+		OrganizationID uint   `param:"id"`
+		StartDate      string `query:"start_date" validate:"required"`
+		EndDate        string `query:"end_date" validate:"required"`
+	}{}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := validator.Validate.Struct(param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role == "org_admin" || self.Role == "care_manager" {
+		param.OrganizationID = *self.OrganizationID
+	}
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to load location",
+		})
+	}
+
+	startDate, err := time.ParseInLocation("01-02-2006", param.StartDate, loc)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to parse start date",
+		})
+	}
+
+	endDate, err := time.ParseInLocation("01-02-2006", param.EndDate, loc)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to parse end date",
+		})
+	}
+	endDate = endDate.AddDate(0, 0, 1)
+
+	alerts, err := models.ListTelemetryAlertsByDateRange(param.OrganizationID, startDate, endDate)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list telemetry alerts",
+		})
+	}
+
+	ackTarget := slaTargetMinutes(param.OrganizationID, models.AlertAckSLAMinutes, models.DefaultAlertAckSLAMinutes)
+	resolveTarget := slaTargetMinutes(param.OrganizationID, models.AlertResolveSLAMinutes, models.DefaultAlertResolveSLAMinutes)
+
+	now := time.Now().UTC()
+	overall := newSLAAccumulator(ackTarget, resolveTarget)
+	critical := newSLAAccumulator(ackTarget, resolveTarget)
+	warning := newSLAAccumulator(ackTarget, resolveTarget)
+
+	for _, a := range alerts {
+		overall.add(a, now)
+		if a.AlertType == models.AlertCritical {
+			critical.add(a, now)
+		} else if a.AlertType == models.AlertWarning {
+			warning.add(a, now)
+		}
+	}
+
+	return c.JSON(http.StatusOK, TelemetryAlertSLAResponse{
+		StartDate:            param.StartDate,
+		EndDate:              param.EndDate,
+		AckTargetMinutes:     ackTarget,
+		ResolveTargetMinutes: resolveTarget,
+		Overall:              overall.stats(),
+		Critical:             critical.stats(),
+		Warning:              warning.stats(),
+	})
+}
+
+func slaTargetMinutes(organizationID uint, settingType models.InteractionSettingType, fallback int64) int64 {
+	setting := models.InteractionSetting{
+		OrganizationID: organizationID,
+		Type:           settingType,
+	}
+
+	if err := setting.GetInteractionSetting(); err != nil || setting.Value <= 0 {
+		return fallback
+	}
+
+	return setting.Value
+}
+
+type slaAccumulator struct {
+	res           TelemetryAlertSLAStats
+	ackTarget     time.Duration
+	resolveTarget time.Duration
+	ackTotal      time.Duration
+	resolveTotal  time.Duration
+}
+
+func newSLAAccumulator(ackTargetMinutes, resolveTargetMinutes int64) *slaAccumulator {
+	return &slaAccumulator{
+		ackTarget:     time.Duration(ackTargetMinutes) * time.Minute,
+		resolveTarget: time.Duration(resolveTargetMinutes) * time.Minute,
+	}
+}
+
+// add counts one alert. Auto resolved alerts never needed staff action so they only
+// count towards the totals.
+func (s *slaAccumulator) add(a models.TelemetryAlert, now time.Time) {
+	s.res.Total++
+
+	if a.IsAutoResolved {
+		s.res.AutoResolved++
+		return
+	}
+
+	if a.AcknowledgedAt != nil {
+		d := a.AcknowledgedAt.Sub(a.CreatedAt)
+		s.res.Acknowledged++
+		s.ackTotal += d
+		if d <= s.ackTarget {
+			s.res.AckWithinTarget++
+		} else {
+			s.res.AckBreached++
+		}
+	} else if now.Sub(a.CreatedAt) > s.ackTarget {
+		s.res.AckBreached++
+	}
+
+	if !a.IsActive && a.ResolvedAt != nil {
+		d := a.ResolvedAt.Sub(a.CreatedAt)
+		s.res.Resolved++
+		s.resolveTotal += d
+		if d <= s.resolveTarget {
+			s.res.ResolveWithinTarget++
+		} else {
+			s.res.ResolveBreached++
+		}
+	} else if a.IsActive && now.Sub(a.CreatedAt) > s.resolveTarget {
+		s.res.ResolveBreached++
+	}
+}
+
+func (s *slaAccumulator) stats() TelemetryAlertSLAStats {
+	res := s.res
+	if res.Acknowledged > 0 {
+		res.AvgAckMinutes = s.ackTotal.Minutes() / float64(res.Acknowledged)
+	}
+	if res.Resolved > 0 {
+		res.AvgResolveMinutes = s.resolveTotal.Minutes() / float64(res.Resolved)
+	}
+	return res
+}
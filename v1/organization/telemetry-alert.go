@@ -4,6 +4,9 @@ import (
 	"MedKick-backend/pkg/database/models"
 	"MedKick-backend/pkg/echo/dto"
 	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/event"
+	"MedKick-backend/pkg/validator"
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -16,7 +19,8 @@ import (
 // @Accept json
 // @Produce json
 // @Param id path int true "Organization ID"
-// @Param status query string false "Status"
+// @Param status query string false "Status (active, unacknowledged, acknowledged, inactive, auto_resolved)"
+// @Param assigned_to query int false "Assigned To User ID"
 // @Param page query int false "Page"
 // @Param size query int false "Size"
 // @Param sort_by query string false "Sort By"
@@ -29,6 +33,7 @@ func listTelemetryAlert(c echo.Context) error {
 	param := struct {
 		OrganizationID uint   `param:"id"`
 		Status         string `query:"status"`
+		AssignedTo     *uint  `query:"assigned_to"`
 		models.PageReq
 		models.SortReq
 	}{
@@ -57,20 +62,33 @@ func listTelemetryAlert(c echo.Context) error {
 		})
 	}
 
-	isActive := true
+	filter := models.TelemetryAlertFilter{
+		IsActive:     true,
+		AssignedToID: param.AssignedTo,
+	}
 
-	if param.Status == "inactive" {
-		isActive = false
+	switch param.Status {
+	case "inactive":
+		filter.IsActive = false
+	case "auto_resolved":
+		filter.IsActive = false
+		filter.IsAutoResolved = true
+	case "unacknowledged":
+		acknowledged := false
+		filter.IsAcknowledged = &acknowledged
+	case "acknowledged":
+		acknowledged := true
+		filter.IsAcknowledged = &acknowledged
 	}
 
-	data, err := models.ListTelemetryAlerts(param.OrganizationID, isActive, param.PageReq, param.SortReq)
+	data, err := models.ListTelemetryAlerts(param.OrganizationID, filter, param.PageReq, param.SortReq)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "Failed to get telemetry alert",
 		})
 	}
 
-	total, err := models.CountTelemetryAlerts(param.OrganizationID, isActive)
+	total, err := models.CountTelemetryAlerts(param.OrganizationID, filter)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "Failed to get telemetry alert",
@@ -89,12 +107,13 @@ func listTelemetryAlert(c echo.Context) error {
 
 // resolveTelemetryAlert godoc
 // @Summary Resolve Telemetry Alert
-// @Description Resolve Telemetry Alert
+// @Description Resolve Telemetry Alert, optionally with a closing note, an outcome and the resulting interaction
 // @Tags Organization
 // @Accept json
 // @Produce json
 // @Param id path int true "Organization ID"
 // @Param alert path int true "Alert ID"
+// @Param resolve body ResolveTelemetryAlertData false "Resolve Request"
 // @Success 200 {object} dto.MessageResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
@@ -103,6 +122,7 @@ func resolvedTelemetryAlert(c echo.Context) error {
 	var param struct {
 		OrganizationID uint `param:"id"`
 		AlertID        uint `param:"alert"`
+		ResolveTelemetryAlertData
 	}
 
 	if err := c.Bind(&param); err != nil {
@@ -111,43 +131,390 @@ func resolvedTelemetryAlert(c echo.Context) error {
 		})
 	}
 
+	if err := validator.Validate.Struct(param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
 	self := middleware.GetSelf(c)
-	if self.Role == "doctor" || self.Role == "nurse" {
-		param.OrganizationID = *self.OrganizationID
+
+	t, err := getOrganizationTelemetryAlert(self, param.OrganizationID, param.AlertID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Telemetry alert not found",
+		})
 	}
 
-	o := models.Organization{
-		ID: param.OrganizationID,
+	if !t.IsActive {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Telemetry alert is already resolved",
+		})
 	}
 
-	if err := o.GetOrganization(); err != nil {
+	if param.InteractionID != nil {
+		if err := checkAlertInteraction(*t, *param.InteractionID); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+	}
+
+	t.IsActive = false
+	t.ResolvedByID = self.ID
+	t.Outcome = param.Outcome
+	t.InteractionID = param.InteractionID
+
+	if err := t.ResolveTelemetryAlert(); err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error: "Failed to get organization",
+			Error: "Failed to update telemetry alert",
 		})
 	}
 
-	t := models.TelemetryAlert{
-		ID:             param.AlertID,
-		IsActive:       true,
-		IsAutoResolved: false,
+	if param.Note != "" {
+		note := models.TelemetryAlertNote{
+			AlertID:  t.ID,
+			AuthorID: *self.ID,
+			Note:     param.Note,
+		}
+
+		if err := note.CreateTelemetryAlertNote(); err != nil {
+			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error: "Failed to add telemetry alert note",
+			})
+		}
+	}
+
+	event.Publish(event.AlertResolved, t.OrganizationID, t.PatientID, t)
+
+	return c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Successfully resolved telemetry alert",
+	})
+}
+
+// acknowledgeTelemetryAlert godoc
+// @Summary Acknowledge Telemetry Alert
+// @Description Acknowledge a telemetry alert, which stops its notification escalation
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param alert path int true "Alert ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/telemetry-alert/{alert}/acknowledge [patch]
+func acknowledgeTelemetryAlert(c echo.Context) error {
+	var param struct {
+		OrganizationID uint `param:"id"`
+		AlertID        uint `param:"alert"`
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+
+	t, err := getOrganizationTelemetryAlert(self, param.OrganizationID, param.AlertID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Telemetry alert not found",
+		})
+	}
+
+	if t.AcknowledgedAt != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Telemetry alert is already acknowledged",
+		})
 	}
 
-	if err := t.GetTelemetryAlert(); err != nil {
+	t.AcknowledgedByID = self.ID
+
+	if err := t.AcknowledgeTelemetryAlert(); err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error: "Failed to get telemetry alert",
+			Error: "Failed to update telemetry alert",
 		})
 	}
 
-	t.IsActive = false
-	t.ResolvedByID = self.ID
+	event.Publish(event.AlertUpdated, t.OrganizationID, t.PatientID, t)
 
-	if err := t.ResolveTelemetryAlert(); err != nil {
+	return c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Successfully acknowledged telemetry alert",
+	})
+}
+
+// assignTelemetryAlert godoc
+// @Summary Assign Telemetry Alert
+// @Description Assign a telemetry alert to a staff member of the organization
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param alert path int true "Alert ID"
+// @Param assign body AssignTelemetryAlertData true "Assign Request"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/telemetry-alert/{alert}/assign [patch]
+func assignTelemetryAlert(c echo.Context) error {
+	var param struct {
+		OrganizationID uint `param:"id"`
+		AlertID        uint `param:"alert"`
+		AssignTelemetryAlertData
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := validator.Validate.Struct(param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+
+	t, err := getOrganizationTelemetryAlert(self, param.OrganizationID, param.AlertID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Telemetry alert not found",
+		})
+	}
+
+	assignee := models.User{
+		ID: &param.AssignedToID,
+	}
+
+	if err := assignee.GetUser(); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Assignee not found",
+		})
+	}
+
+	if assignee.OrganizationID == nil || *assignee.OrganizationID != t.OrganizationID || assignee.Role == "patient" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Assignee is not staff of the organization",
+		})
+	}
+
+	t.AssignedToID = assignee.ID
+
+	if err := t.AssignTelemetryAlert(); err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "Failed to update telemetry alert",
 		})
 	}
 
+	event.Publish(event.AlertUpdated, t.OrganizationID, t.PatientID, t)
+
 	return c.JSON(http.StatusOK, dto.MessageResponse{
-		Message: "Successfully resolved telemetry alert",
+		Message: "Successfully assigned telemetry alert",
 	})
 }
+
+// updateTelemetryAlertTriage godoc
+// @Summary Update Telemetry Alert Triage
+// @Description Set the outcome of a telemetry alert and link it to the interaction it resulted in
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param alert path int true "Alert ID"
+// @Param triage body TelemetryAlertTriageData true "Triage Request"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/telemetry-alert/{alert}/triage [patch]
+func updateTelemetryAlertTriage(c echo.Context) error {
+	var param struct {
+		OrganizationID uint `param:"id"`
+		AlertID        uint `param:"alert"`
+		TelemetryAlertTriageData
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := validator.Validate.Struct(param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+
+	t, err := getOrganizationTelemetryAlert(self, param.OrganizationID, param.AlertID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Telemetry alert not found",
+		})
+	}
+
+	if param.InteractionID != nil {
+		if err := checkAlertInteraction(*t, *param.InteractionID); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+	}
+
+	t.Outcome = param.Outcome
+	t.InteractionID = param.InteractionID
+
+	if err := t.UpdateTelemetryAlertTriage(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to update telemetry alert",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Successfully updated telemetry alert",
+	})
+}
+
+// addTelemetryAlertNote godoc
+// @Summary Add Telemetry Alert Note
+// @Description Add a note to a telemetry alert
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param alert path int true "Alert ID"
+// @Param note body TelemetryAlertNoteData true "Note Request"
+// @Success 201 {object} models.TelemetryAlertNote
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/telemetry-alert/{alert}/note [post]
+func addTelemetryAlertNote(c echo.Context) error {
+	var param struct {
+		OrganizationID uint `param:"id"`
+		AlertID        uint `param:"alert"`
+		TelemetryAlertNoteData
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := validator.Validate.Struct(param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+
+	t, err := getOrganizationTelemetryAlert(self, param.OrganizationID, param.AlertID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Telemetry alert not found",
+		})
+	}
+
+	note := models.TelemetryAlertNote{
+		AlertID:  t.ID,
+		AuthorID: *self.ID,
+		Note:     param.Note,
+	}
+
+	if err := note.CreateTelemetryAlertNote(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to add telemetry alert note",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, note)
+}
+
+// listTelemetryAlertNotes godoc
+// @Summary List Telemetry Alert Notes
+// @Description List the notes of a telemetry alert
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param alert path int true "Alert ID"
+// @Success 200 {object} []models.TelemetryAlertNote
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/telemetry-alert/{alert}/note [get]
+func listTelemetryAlertNotes(c echo.Context) error {
+	var param struct {
+		OrganizationID uint `param:"id"`
+		AlertID        uint `param:"alert"`
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+
+	t, err := getOrganizationTelemetryAlert(self, param.OrganizationID, param.AlertID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Telemetry alert not found",
+		})
+	}
+
+	notes, err := models.ListTelemetryAlertNotes(t.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list telemetry alert notes",
+		})
+	}
+
+	return c.JSON(http.StatusOK, notes)
+}
+
+// getOrganizationTelemetryAlert gets an alert and checks it belongs to the organization,
+// staff outside of the admin role are always scoped to their own organization
+func getOrganizationTelemetryAlert(self models.User, organizationID, alertID uint) (*models.TelemetryAlert, error) {
+	if self.Role != "admin" && self.OrganizationID != nil {
+		organizationID = *self.OrganizationID
+	}
+
+	t := models.TelemetryAlert{
+		ID: alertID,
+	}
+
+	if err := t.GetTelemetryAlertByID(); err != nil {
+		return nil, err
+	}
+
+	if t.OrganizationID != organizationID {
+		return nil, errors.New("telemetry alert is not in the organization")
+	}
+
+	return &t, nil
+}
+
+// checkAlertInteraction makes sure the interaction was with the alert's patient
+func checkAlertInteraction(t models.TelemetryAlert, interactionID uint) error {
+	i := models.Interaction{
+		ID: interactionID,
+	}
+
+	if err := i.GetInteraction(); err != nil {
+		return errors.New("interaction not found")
+	}
+
+	if i.UserID != t.PatientID {
+		return errors.New("interaction is not with the alert's patient")
+	}
+
+	return nil
+}
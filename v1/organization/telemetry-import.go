@@ -0,0 +1,334 @@
+package organization
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/worker"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+// getOrganizationImport loads an import batch of the organization in the path, non-admins
+// only reach their own organization's batches
+func getOrganizationImport(c echo.Context) (*models.TelemetryImport, error) {
+	organizationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return nil, c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	importID, err := strconv.Atoi(c.Param("import"))
+	if err != nil {
+		return nil, c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert import to uint",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role != "admin" {
+		organizationID = int(*self.OrganizationID)
+	}
+
+	batch := &models.TelemetryImport{
+		ID: uint(importID),
+	}
+	if err := batch.GetTelemetryImport(); err != nil || batch.OrganizationID != uint(organizationID) {
+		return nil, c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Import not found",
+		})
+	}
+
+	return batch, nil
+}
+
+// createTelemetryImport godoc
+// @Summary Import Telemetry
+// @Description Import historical readings from another vendor's CSV or JSON export. Rows are matched to the organization's patients by MRN, name and date of birth, or email, and columns are mapped to our fields with column_map, e.g. {"mrn":"Patient ID","measured_at":"Date","weight":"Weight (kg)","weight_unit":"Unit"}. Fields: mrn, email, first_name, last_name, dob, measured_at, systolic, diastolic, pulse, irregular_heartbeat, weight, weight_unit (lb or kg, default lb), glucose, glucose_unit (mg/dL or mmol/L, default mg/dL), meal. Times without an offset are read in the patient's timezone. Rows matching an existing reading are skipped as duplicates. The import runs in the background, poll the batch for its status.
+// @Tags Organization
+// @Accept mpfd
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param file formData file true "CSV or JSON export"
+// @Param format formData string false "File format, taken from the file extension if not set" Enums(CSV, JSON)
+// @Param patient_match formData string false "How rows are matched to patients (default MRN)" Enums(MRN, NameDOB, Email)
+// @Param column_map formData string false "JSON object mapping our fields to the file's column names"
+// @Success 202 {object} models.TelemetryImport
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/telemetry-import [post]
+func createTelemetryImport(c echo.Context) error {
+	organizationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role != "admin" {
+		organizationID = int(*self.OrganizationID)
+	}
+
+	org := models.Organization{
+		ID: uint(organizationID),
+	}
+	if err := org.GetOrganization(); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Organization does not exist",
+		})
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "File is required",
+		})
+	}
+	// Capped at 20MB
+	if file.Size > 20*1024*1024 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "File is too large",
+		})
+	}
+	if file.Size == 0 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "File is empty",
+		})
+	}
+
+	format := models.ImportFormat(strings.ToUpper(c.FormValue("format")))
+	if format == "" {
+		format = models.ImportCSV
+		if strings.EqualFold(filepath.Ext(file.Filename), ".json") {
+			format = models.ImportJSON
+		}
+	}
+	if format != models.ImportCSV && format != models.ImportJSON {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Invalid format, must be 'CSV' or 'JSON'",
+		})
+	}
+
+	match := models.PatientMatch(c.FormValue("patient_match"))
+	if match == "" {
+		match = models.MatchMRN
+	}
+	if match != models.MatchMRN && match != models.MatchNameDOB && match != models.MatchEmail {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Invalid patient_match, must be 'MRN', 'NameDOB' or 'Email'",
+		})
+	}
+
+	columnMap := map[string]string{}
+	if raw := c.FormValue("column_map"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &columnMap); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "column_map must be a JSON object of field to column name",
+			})
+		}
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to open file")
+	}
+	defer src.Close()
+
+	rows, err := worker.ParseTelemetryImport(format, src, match, columnMap)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: fmt.Sprintf("Failed to read file: %s", err),
+		})
+	}
+
+	mapping := make(map[string]interface{}, len(columnMap))
+	for field, column := range columnMap {
+		mapping[field] = column
+	}
+
+	batch := &models.TelemetryImport{
+		OrganizationID: uint(organizationID),
+		UploadedByID:   *self.ID,
+		FileName:       file.Filename,
+		Format:         format,
+		PatientMatch:   match,
+		ColumnMap:      mapping,
+		Status:         models.ImportRunning,
+		TotalRows:      len(rows),
+		StartedAt:      time.Now(),
+	}
+	if err := batch.CreateTelemetryImport(); err != nil {
+		log.Errorf("Failed to create telemetry import: %s", err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create import",
+		})
+	}
+
+	response := *batch
+	go worker.ImportTelemetry(batch, rows)
+
+	return c.JSON(http.StatusAccepted, response)
+}
+
+// listTelemetryImports godoc
+// @Summary List Telemetry Imports
+// @Description List the organization's latest telemetry import batches with their counts, newest first
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param limit query int false "Number of imports (default 20, max 100)"
+// @Success 200 {object} []models.TelemetryImport
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/telemetry-import [get]
+func listTelemetryImports(c echo.Context) error {
+	organizationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role != "admin" {
+		organizationID = int(*self.OrganizationID)
+	}
+
+	limit := 20
+	if raw := c.QueryParam("limit"); raw != "" {
+		l, err := strconv.Atoi(raw)
+		if err != nil || l <= 0 || l > 100 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Invalid limit",
+			})
+		}
+		limit = l
+	}
+
+	imports, err := models.ListTelemetryImports(uint(organizationID), limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list imports",
+		})
+	}
+
+	return c.JSON(http.StatusOK, imports)
+}
+
+// getTelemetryImport godoc
+// @Summary Get Telemetry Import
+// @Description Get a telemetry import batch with its status and counts
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param import path int true "Import ID"
+// @Success 200 {object} models.TelemetryImport
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /organization/{id}/telemetry-import/{import} [get]
+func getTelemetryImport(c echo.Context) error {
+	batch, err := getOrganizationImport(c)
+	if batch == nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, batch)
+}
+
+// getTelemetryImportErrors godoc
+// @Summary Download Telemetry Import Errors
+// @Description Download the rows of an import batch that could not be imported as CSV, with the line, column and reason of each
+// @Tags Organization
+// @Produce text/csv
+// @Param id path int true "Organization ID"
+// @Param import path int true "Import ID"
+// @Success 200 {string} string "CSV error report"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/telemetry-import/{import}/errors [get]
+func getTelemetryImportErrors(c echo.Context) error {
+	batch, err := getOrganizationImport(c)
+	if batch == nil {
+		return err
+	}
+
+	importErrors, err := models.ListTelemetryImportErrors(batch.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list import errors",
+		})
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"line", "column", "error", "row"})
+	for _, e := range importErrors {
+		_ = w.Write([]string{strconv.Itoa(e.Line), e.Column, e.Message, e.Raw})
+	}
+	w.Flush()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"import-%d-errors.csv\"", batch.ID))
+	return c.Blob(http.StatusOK, "text/csv", buf.Bytes())
+}
+
+// revertTelemetryImport godoc
+// @Summary Revert Telemetry Import
+// @Description Delete every reading an import batch wrote. Batches that are still running or older than 30 days can't be reverted. A batch whose import was stopped by a restart is failed after 6 hours and can be reverted then.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param import path int true "Import ID"
+// @Success 200 {object} models.TelemetryImport
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/telemetry-import/{import}/revert [post]
+func revertTelemetryImport(c echo.Context) error {
+	batch, err := getOrganizationImport(c)
+	if batch == nil {
+		return err
+	}
+
+	if batch.Status == models.ImportRunning || batch.Status == models.ImportReverted {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: fmt.Sprintf("Import is %s and can't be reverted", strings.ToLower(string(batch.Status))),
+		})
+	}
+
+	if !batch.Revertable() {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: fmt.Sprintf("Imports can only be reverted within %d days", models.ImportRevertDays),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if err := batch.RevertTelemetryImport(self.ID); err != nil {
+		log.Errorf("Failed to revert telemetry import %d: %s", batch.ID, err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to revert import",
+		})
+	}
+
+	return c.JSON(http.StatusOK, batch)
+}
@@ -0,0 +1,175 @@
+package organization
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/validator"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// upsertThresholdTemplates godoc
+// @Summary Upsert Threshold Templates
+// @Description Upsert organization default alert thresholds, or the thresholds for patients with an ICD-10 diagnosis when diagnosis_code is set
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param upsert body ThresholdTemplateData true "Upsert Request"
+// @Success 201 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/threshold-template [put]
+func upsertThresholdTemplates(c echo.Context) error {
+	req := struct {
+		OrganizationID uint `json:"-" param:"id" validate:"required"`
+		ThresholdTemplateData
+	}{}
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role == "org_admin" {
+		req.OrganizationID = *self.OrganizationID
+	}
+
+	o := models.Organization{
+		ID: req.OrganizationID,
+	}
+
+	if err := o.GetOrganization(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get organization",
+		})
+	}
+
+	if req.DiagnosisCode != "" {
+		d := models.Diagnosis{
+			Code: req.DiagnosisCode,
+		}
+
+		if err := d.GetDiagnosisByCode(); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Invalid Diagnosis code",
+			})
+		}
+	}
+
+	var templates []models.ThresholdTemplate
+
+	for _, measurement := range req.Measurements {
+		if err := models.ValidateThresholdLimits(measurement.CriticalLow, measurement.WarningLow, measurement.WarningHigh, measurement.CriticalHigh); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+		templates = append(templates, models.ThresholdTemplate{
+			OrganizationID:  req.OrganizationID,
+			DiagnosisCode:   req.DiagnosisCode,
+			DeviceType:      req.DeviceType,
+			MeasurementType: measurement.MeasurementType,
+			CriticalLow:     measurement.CriticalLow,
+			WarningLow:      measurement.WarningLow,
+			WarningHigh:     measurement.WarningHigh,
+			CriticalHigh:    measurement.CriticalHigh,
+			Note:            req.Note,
+		})
+	}
+
+	if err := models.UpsertThresholdTemplates(templates); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to upsert threshold templates",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, dto.MessageResponse{
+		Message: "Threshold templates upsert successful",
+	})
+}
+
+// listThresholdTemplates godoc
+// @Summary List Threshold Templates
+// @Description List the organization default and diagnosis alert threshold templates
+// @Tags Organization
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} []models.ThresholdTemplate
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/threshold-template [get]
+func listThresholdTemplates(c echo.Context) error {
+	var param struct {
+		OrganizationID uint `param:"id"`
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role == "org_admin" || self.Role == "care_manager" {
+		param.OrganizationID = *self.OrganizationID
+	}
+
+	templates, err := models.ListThresholdTemplates([]uint{param.OrganizationID})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list threshold templates",
+		})
+	}
+
+	return c.JSON(http.StatusOK, templates)
+}
+
+// deleteThresholdTemplate godoc
+// @Summary Delete Threshold Template
+// @Description Delete an alert threshold template of the organization
+// @Tags Organization
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param template path int true "Template ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organization/{id}/threshold-template/{template} [delete]
+func deleteThresholdTemplate(c echo.Context) error {
+	var param struct {
+		OrganizationID uint `param:"id"`
+		TemplateID     uint `param:"template"`
+	}
+
+	if err := c.Bind(&param); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role == "org_admin" {
+		param.OrganizationID = *self.OrganizationID
+	}
+
+	if err := models.DeleteThresholdTemplate(param.OrganizationID, param.TemplateID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to delete threshold template",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Successfully deleted threshold template",
+	})
+}
@@ -6,35 +6,140 @@ import (
 )
 
 type InteractionSettingData struct {
-	SettingType models.InteractionSettingType `json:"setting_type" validate:"required,oneof=ColorThreshold"`
+	SettingType models.InteractionSettingType `json:"setting_type" validate:"required,oneof=ColorThreshold AutoResolveNormalReadings AlertAckSLAMinutes AlertResolveSLAMinutes DeviceNoReadingDays DeviceLowBatteryPercent DeviceWeakSignal DeviceHeartbeatHours AdherenceStreakBreakDays AdherenceReminders"`
 	Value       int64                         `json:"value"`
 }
 
+type AlertNotificationRuleData struct {
+	Tier                 uint                         `json:"tier" validate:"required,min=1" example:"1"`
+	MinAlertType         models.AlertType             `json:"min_alert_type" validate:"required,oneof=Warning Critical" example:"Critical"`
+	RecipientType        models.NotificationRecipient `json:"recipient_type" validate:"required,oneof=CareManager Provider OnCall" example:"CareManager"`
+	RecipientUserID      *uint                        `json:"recipient_user_id,omitempty" example:"1"`
+	Channel              models.NotificationChannel   `json:"channel" validate:"required,oneof=Email SMS Webhook" example:"Email"`
+	Target               string                       `json:"target,omitempty" example:"https://example.com/hooks/alerts"`
+	EscalateAfterMinutes uint                         `json:"escalate_after_minutes" validate:"required,min=1" example:"15"`
+}
+
+type ResolveTelemetryAlertData struct {
+	Note          string              `json:"note,omitempty" example:"Patient retook BP, back in range"`
+	Outcome       models.AlertOutcome `json:"outcome,omitempty" validate:"omitempty,oneof=CalledPatient MedicationChange SentToER NoActionNeeded Other" example:"CalledPatient"`
+	InteractionID *uint               `json:"interaction_id,omitempty" example:"1"`
+}
+
+type AssignTelemetryAlertData struct {
+	AssignedToID uint `json:"assigned_to_id" validate:"required" example:"1"`
+}
+
+type TelemetryAlertTriageData struct {
+	Outcome       models.AlertOutcome `json:"outcome,omitempty" validate:"omitempty,oneof=CalledPatient MedicationChange SentToER NoActionNeeded Other" example:"MedicationChange"`
+	InteractionID *uint               `json:"interaction_id,omitempty" example:"1"`
+}
+
+type TelemetryAlertNoteData struct {
+	Note string `json:"note" validate:"required" example:"Left voicemail for patient"`
+}
+
+type TelemetryAlertSLAResponse struct {
+	StartDate            string                 `json:"start_date" example:"01-01-2021"`
+	EndDate              string                 `json:"end_date" example:"01-31-2021"`
+	AckTargetMinutes     int64                  `json:"ack_target_minutes" example:"30"`
+	ResolveTargetMinutes int64                  `json:"resolve_target_minutes" example:"1440"`
+	Overall              TelemetryAlertSLAStats `json:"overall"`
+	Critical             TelemetryAlertSLAStats `json:"critical"`
+	Warning              TelemetryAlertSLAStats `json:"warning"`
+}
+
+type TelemetryAlertSLAStats struct {
+	Total               int     `json:"total" example:"20"`
+	Acknowledged        int     `json:"acknowledged" example:"18"`
+	Resolved            int     `json:"resolved" example:"15"`
+	AutoResolved        int     `json:"auto_resolved" example:"3"`
+	AvgAckMinutes       float64 `json:"avg_ack_minutes" example:"12.5"`
+	AvgResolveMinutes   float64 `json:"avg_resolve_minutes" example:"240"`
+	AckWithinTarget     int     `json:"ack_within_target" example:"16"`
+	ResolveWithinTarget int     `json:"resolve_within_target" example:"14"`
+	// AckBreached counts alerts that were acknowledged late or are still waiting past the target
+	AckBreached     int `json:"ack_breached" example:"2"`
+	ResolveBreached int `json:"resolve_breached" example:"1"`
+}
+
+type ThresholdMeasurementData struct {
+	MeasurementType models.MeasurementType `json:"measurement_type" validate:"required,oneof=Systolic Diastolic Pulse Weight FastingGlucose PostMealGlucose"`
+	CriticalLow     *uint                  `json:"critical_low" example:"90"`
+	WarningLow      *uint                  `json:"warning_low" example:"100"`
+	WarningHigh     *uint                  `json:"warning_high" example:"140"`
+	CriticalHigh    *uint                  `json:"critical_high" example:"180"`
+}
+
+type ThresholdTemplateData struct {
+	// DiagnosisCode is the ICD-10 code the template applies to, empty for the organization default
+	DiagnosisCode string                     `json:"diagnosis_code,omitempty" validate:"omitempty,max=10" example:"I10"`
+	DeviceType    models.DeviceType          `json:"device_type" validate:"required,oneof=BloodPressure BloodGlucose WeightScale" example:"BloodPressure"`
+	Measurements  []ThresholdMeasurementData `json:"measurements" validate:"required,min=1,dive,required"`
+	Note          string                     `json:"note" example:"Hypertension protocol"`
+}
+
+type DeviceHealthResponse struct {
+	Limits  models.DeviceHealthLimits `json:"limits"`
+	Summary map[string]int            `json:"summary"`
+	Devices []DeviceHealthRecord      `json:"devices"`
+}
+
+type DeviceHealthRecord struct {
+	DeviceID       uint                 `json:"device_id" example:"1"`
+	Name           string               `json:"name" example:"Sphygmomanometer"`
+	IMEI           string               `json:"imei" example:"123456789"`
+	PatientID      uint                 `json:"patient_id" example:"1"`
+	PatientName    string               `json:"patient_name" example:"John Doe"`
+	BatteryLevel   uint                 `json:"battery_level" example:"80"`
+	SignalStrength string               `json:"signal_strength" example:"20"`
+	LastReadingAt  *time.Time           `json:"last_reading_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	LastStatusAt   *time.Time           `json:"last_status_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	Healthy        bool                 `json:"healthy" example:"false"`
+	Alerts         []models.DeviceAlert `json:"alerts"`
+}
+
 type TelemetryAlertResponse struct {
-	AlertID     uint                   `json:"alert_id" example:"1"`
-	PatientID   uint                   `json:"patient_id" example:"1"`
-	PatientName string                 `json:"patient_name" example:"John Doe"`
-	TelemetryID uint                   `json:"telemetry_id" example:"1"`
-	PhoneNumber string                 `json:"phone_number" example:"08123456789"`
-	Vitals      map[string]interface{} `json:"vitals"`
-	Status      models.AlertType       `json:"status" example:"Critical"`
-	IsActive    bool                   `json:"is_active" example:"true"`
-	ResolvedBy  string                 `json:"resolved_by,omitempty" example:"John Doe"`
-	ResolvedAt  *time.Time             `json:"resolved_at,omitempty" example:"2021-01-01T00:00:00Z"`
-	Time        time.Time              `json:"time" example:"2021-01-01T00:00:00Z"`
+	AlertID        uint                   `json:"alert_id" example:"1"`
+	PatientID      uint                   `json:"patient_id" example:"1"`
+	PatientName    string                 `json:"patient_name" example:"John Doe"`
+	TelemetryID    uint                   `json:"telemetry_id" example:"1"`
+	PhoneNumber    string                 `json:"phone_number" example:"08123456789"`
+	Vitals         map[string]interface{} `json:"vitals"`
+	Status         models.AlertType       `json:"status" example:"Critical"`
+	IsActive       bool                   `json:"is_active" example:"true"`
+	IsAutoResolved bool                   `json:"is_auto_resolved" example:"false"`
+	ResolvedBy     string                 `json:"resolved_by,omitempty" example:"John Doe"`
+	ResolvedAt     *time.Time             `json:"resolved_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	AcknowledgedBy string                 `json:"acknowledged_by,omitempty" example:"John Doe"`
+	AcknowledgedAt *time.Time             `json:"acknowledged_at,omitempty" example:"2021-01-01T00:00:00Z"`
+	AssignedToID   *uint                  `json:"assigned_to_id,omitempty" example:"1"`
+	AssignedTo     string                 `json:"assigned_to,omitempty" example:"Jane Doe"`
+	Outcome        models.AlertOutcome    `json:"outcome,omitempty" example:"CalledPatient"`
+	InteractionID  *uint                  `json:"interaction_id,omitempty" example:"1"`
+	EpisodeCount   uint                   `json:"episode_count" example:"3"`
+	LastTime       time.Time              `json:"last_time" example:"2021-01-03T00:00:00Z"`
+	Time           time.Time              `json:"time" example:"2021-01-01T00:00:00Z"`
 }
 
 func convertModelToResponse(data []models.TelemetryAlert) []TelemetryAlertResponse {
 	res := make([]TelemetryAlertResponse, 0)
 	for _, d := range data {
 		rd := TelemetryAlertResponse{
-			AlertID:     d.ID,
-			PatientID:   d.PatientID,
-			TelemetryID: d.TelemetryID,
-			Vitals:      d.Data,
-			Status:      d.AlertType,
-			IsActive:    d.IsActive,
-			Time:        d.MeasuredAt,
+			AlertID:        d.ID,
+			PatientID:      d.PatientID,
+			TelemetryID:    d.TelemetryID,
+			Vitals:         d.Data,
+			Status:         d.AlertType,
+			IsActive:       d.IsActive,
+			IsAutoResolved: d.IsAutoResolved,
+			AcknowledgedAt: d.AcknowledgedAt,
+			AssignedToID:   d.AssignedToID,
+			Outcome:        d.Outcome,
+			InteractionID:  d.InteractionID,
+			EpisodeCount:   d.EpisodeCount,
+			LastTime:       d.LatestMeasuredAt(),
+			Time:           d.MeasuredAt,
 		}
 		if d.Patient != nil {
 			rd.PatientName = d.Patient.FirstName + " " + d.Patient.LastName
@@ -44,6 +149,15 @@ func convertModelToResponse(data []models.TelemetryAlert) []TelemetryAlertRespon
 			rd.ResolvedBy = d.ResolvedBy.FirstName + " " + d.ResolvedBy.LastName
 			rd.ResolvedAt = d.ResolvedAt
 		}
+		if d.AcknowledgedBy != nil {
+			rd.AcknowledgedBy = d.AcknowledgedBy.FirstName + " " + d.AcknowledgedBy.LastName
+		}
+		if d.AssignedTo != nil {
+			rd.AssignedTo = d.AssignedTo.FirstName + " " + d.AssignedTo.LastName
+		}
+		if d.IsAutoResolved {
+			rd.ResolvedAt = d.ResolvedAt
+		}
 
 		res = append(res, rd)
 	}
@@ -69,3 +183,33 @@ type BillingRecordBody struct {
 	ICD10       string `json:"icd10" example:"A00.0"`
 	ServiceCode string `json:"-"`
 }
+
+type ReadingAdherenceResponse struct {
+	Month      string                    `json:"month" example:"2021-01"`
+	TargetDays int                       `json:"target_days" example:"16"`
+	Summary    map[string]int            `json:"summary"`
+	Patients   []models.ReadingAdherence `json:"patients"`
+}
+
+type ClinicalSummaryPeriodData struct {
+	Period string `json:"period" validate:"required" example:"2021-01"`
+}
+
+type APIKeyData struct {
+	Name   string   `json:"name" validate:"required,max=100" example:"EHR integration"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=user:read user:write organization:read organization:write device:read device:write interaction:read interaction:write careplan:read careplan:write" example:"user:read,device:read"`
+	// UserID is the organization user the key acts as, the caller when not set
+	UserID        *uint `json:"user_id,omitempty" example:"1"`
+	ExpiresInDays *uint `json:"expires_in_days,omitempty" validate:"omitempty,min=1,max=730" example:"90"`
+}
+
+type APIKeyCreatedResponse struct {
+	models.APIKey
+	// Token is only returned when the key is created
+	Token string `json:"token" example:"mk_3fZq9Lw2bV0cJx7TnR1yUe5sKd8hGa4pWm6oQiLz2fE"`
+}
+
+type MFAPolicyData struct {
+	// Roles whose users must use MFA, empty to not require it
+	Roles []string `json:"roles" validate:"dive,oneof=org_admin care_manager doctor nurse patient" example:"org_admin,care_manager"`
+}
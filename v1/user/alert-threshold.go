@@ -92,13 +92,15 @@ func upsertAlertThreshold(c echo.Context) error {
 // @Tags User
 // @Produce json
 // @Param id path int true "User ID"
+// @Param include_inherited query bool false "Include thresholds inherited from organization and diagnosis templates"
 // @Success 200 {object} []AlertThresholdData
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /user/{id}/alert-threshold [get]
 func listAlertThresholds(c echo.Context) error {
 	var req struct {
-		PatientID uint `json:"-" param:"id"`
+		PatientID        uint `json:"-" param:"id"`
+		IncludeInherited bool `json:"-" query:"include_inherited"`
 	}
 
 	if err := c.Bind(&req); err != nil {
@@ -123,7 +125,12 @@ func listAlertThresholds(c echo.Context) error {
 		})
 	}
 
-	alertThresholds, err := models.ListAlertThresholds([]uint{req.PatientID})
+	listThresholds := models.ListAlertThresholds
+	if req.IncludeInherited {
+		listThresholds = models.ListEffectiveAlertThresholds
+	}
+
+	alertThresholds, err := listThresholds([]uint{req.PatientID})
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "Failed to get alert thresholds",
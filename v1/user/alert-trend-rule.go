@@ -0,0 +1,181 @@
+package user
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/validator"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// upsertAlertTrendRules godoc
+// @Summary Upsert Alert Trend Rules
+// @Description Upsert trend based alert rules (change over a time window) for a patient
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param upsert body AlertTrendRuleData true "Upsert Request"
+// @Success 201 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/alert-trend-rule [put]
+func upsertAlertTrendRules(c echo.Context) error {
+	var req struct {
+		PatientID uint `json:"-" param:"id" validate:"required"`
+		AlertTrendRuleData
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	u := models.User{
+		ID: &req.PatientID,
+	}
+
+	if err := u.GetUser(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get user",
+		})
+	}
+
+	if u.Role != "patient" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "User is not a patient",
+		})
+	}
+
+	var alertTrendRules []models.AlertTrendRule
+
+	for _, rule := range req.Rules {
+		if err := rule.validate(); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+
+		direction := rule.Direction
+		if direction == "" {
+			direction = models.TrendIncrease
+		}
+
+		alertTrendRules = append(alertTrendRules, models.AlertTrendRule{
+			PatientID:       req.PatientID,
+			DeviceType:      req.DeviceType,
+			MeasurementType: rule.MeasurementType,
+			RuleType:        rule.RuleType,
+			WindowHours:     rule.WindowHours,
+			Direction:       direction,
+			WarningChange:   rule.WarningChange,
+			CriticalChange:  rule.CriticalChange,
+			Note:            req.Note,
+		})
+	}
+
+	if err := models.UpsertAlertTrendRules(alertTrendRules); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to upsert alert trend rules",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, dto.MessageResponse{
+		Message: "Alert trend rules upsert successful",
+	})
+}
+
+// listAlertTrendRules godoc
+// @Summary List Alert Trend Rules
+// @Description List Alert Trend Rules
+// @Tags User
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} []models.AlertTrendRule
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/alert-trend-rule [get]
+func listAlertTrendRules(c echo.Context) error {
+	var req struct {
+		PatientID uint `json:"-" param:"id"`
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	u := models.User{
+		ID: &req.PatientID,
+	}
+
+	if err := u.GetUser(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get user",
+		})
+	}
+
+	if u.Role != "patient" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "User is not a patient",
+		})
+	}
+
+	alertTrendRules, err := models.ListAlertTrendRules([]uint{req.PatientID})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get alert trend rules",
+		})
+	}
+
+	return c.JSON(http.StatusOK, alertTrendRules)
+}
+
+// deleteAlertTrendRule godoc
+// @Summary Delete Alert Trend Rule
+// @Description Delete Alert Trend Rule
+// @Tags User
+// @Produce json
+// @Param id path int true "User ID"
+// @Param rule path int true "Rule ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/alert-trend-rule/{rule} [delete]
+func deleteAlertTrendRule(c echo.Context) error {
+	var req struct {
+		PatientID uint `json:"-" param:"id" validate:"required"`
+		RuleID    uint `json:"-" param:"rule" validate:"required"`
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := models.DeleteAlertTrendRule(req.PatientID, req.RuleID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to delete alert trend rule",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Alert trend rule deleted",
+	})
+}
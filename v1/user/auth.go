@@ -18,14 +18,24 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+type LoginResponse struct {
+	Message string `json:"message" example:"Successfully logged in"`
+	// MFARequired means the login has to be finished with /auth/mfa/verify using one of MFAMethods
+	MFARequired bool     `json:"mfa_required,omitempty" example:"false"`
+	MFAMethods  []string `json:"mfa_methods,omitempty" example:"totp,recovery"`
+	// MFAEnrollmentRequired means the organization requires MFA and the user has to set it up
+	// before using the rest of the API
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required,omitempty" example:"false"`
+}
+
 // login godoc
 // @Summary Login
-// @Description Login
+// @Description Login. When the user has MFA on, the session only holds a challenge and the login is finished with /auth/mfa/verify.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body LoginRequest true "Login"
-// @Success 200 {object} dto.MessageResponse
+// @Success 200 {object} LoginResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /auth/login [post]
@@ -65,15 +75,52 @@ func login(c echo.Context) error {
 		})
 	}
 
+	// With MFA on, the session gets a challenge instead of the user until the code is entered
+	if mfa, err := models.GetUserMFA(*u.ID); err == nil && mfa.Enabled() {
+		challenge := models.MFAChallenge{
+			UserID: *u.ID,
+		}
+		if err := challenge.CreateMFAChallenge(); err != nil {
+			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error: "Failed to create MFA challenge",
+			})
+		}
+
+		delete(session.Values, "user-id")
+		delete(session.Values, "mfa-enrollment")
+		session.Values["mfa-challenge-id"] = challenge.ID
+		if err := session.Save(c.Request(), c.Response()); err != nil {
+			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error: "Failed to save session",
+			})
+		}
+
+		methods := []string{"totp", "recovery"}
+		if mfa.EmailFallback {
+			methods = append(methods, "email")
+		}
+
+		return c.JSON(http.StatusOK, LoginResponse{
+			Message:     "Enter your verification code to finish logging in",
+			MFARequired: true,
+			MFAMethods:  methods,
+		})
+	}
+
+	required := models.IsMFARequired(u)
+
+	delete(session.Values, "mfa-challenge-id")
 	session.Values["user-id"] = u.ID
+	session.Values["mfa-enrollment"] = required
 	if err := session.Save(c.Request(), c.Response()); err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "Failed to save session",
 		})
 	}
 
-	return c.JSON(http.StatusOK, dto.MessageResponse{
-		Message: "Successfully logged in",
+	return c.JSON(http.StatusOK, LoginResponse{
+		Message:               "Successfully logged in",
+		MFAEnrollmentRequired: required,
 	})
 }
 
@@ -96,6 +143,7 @@ func logout(c echo.Context) error {
 	}
 
 	session.Values["user-id"] = nil
+	delete(session.Values, "mfa-enrollment")
 	if err := session.Save(c.Request(), c.Response()); err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: "Failed to save session",
@@ -0,0 +1,254 @@
+package user
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/s3"
+	"MedKick-backend/pkg/validator"
+	"MedKick-backend/pkg/worker"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+// canReviewPatient reports whether self may read and sign the patient's clinical summaries:
+// whoever can view the patient, and the doctors and nurses of the patient's organization
+func canReviewPatient(self models.User, patient models.User) bool {
+	if self.Role == "doctor" || self.Role == "nurse" {
+		return self.OrganizationID != nil && patient.OrganizationID != nil && *self.OrganizationID == *patient.OrganizationID
+	}
+
+	return self.Role != "patient" && canViewPatient(self, patient)
+}
+
+// getPatientClinicalSummary loads the summary in the path if it belongs to the patient, on
+// failure it writes the response and returns a nil summary
+func getPatientClinicalSummary(c echo.Context, patient *models.User) (*models.ClinicalSummary, error) {
+	summaryID, err := strconv.ParseUint(c.Param("summary"), 10, 32)
+	if err != nil {
+		return nil, c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Invalid summary ID",
+		})
+	}
+
+	summary := &models.ClinicalSummary{
+		ID: uint(summaryID),
+	}
+	if err := summary.GetClinicalSummary(); err != nil || summary.PatientID != *patient.ID {
+		return nil, c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Summary not found",
+		})
+	}
+
+	return summary, nil
+}
+
+// listClinicalSummaries godoc
+// @Summary List Clinical Summaries
+// @Description List the patient's monthly clinical summaries with their sign-off, latest month first
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param id path int true "Patient ID"
+// @Success 200 {object} []models.ClinicalSummary
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/clinical-summary [get]
+func listClinicalSummaries(c echo.Context) error {
+	patient, err := getPatientFor(c, canReviewPatient)
+	if patient == nil {
+		return err
+	}
+
+	summaries, err := models.ListPatientClinicalSummaries(*patient.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list clinical summaries",
+		})
+	}
+
+	return c.JSON(http.StatusOK, summaries)
+}
+
+// generateClinicalSummary godoc
+// @Summary Generate Clinical Summary
+// @Description Generate the patient's one page PDF summary of a month for provider review: BP, weight and glucose trends, averages against the patient's goals, alerts, interventions and RPM management minutes. Regenerating replaces the month's report, signed summaries can't be regenerated.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param id path int true "Patient ID"
+// @Param period body ClinicalSummaryPeriodData true "Month (YYYY-MM)"
+// @Success 200 {object} models.ClinicalSummary
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/clinical-summary [post]
+func generateClinicalSummary(c echo.Context) error {
+	patient, err := getPatientFor(c, canReviewPatient)
+	if patient == nil {
+		return err
+	}
+
+	var req ClinicalSummaryPeriodData
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	start, err := models.ParsePeriod(req.Period, models.GetPatientLocation(*patient.ID))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to parse period, must be YYYY-MM",
+		})
+	}
+	if start.After(time.Now()) {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Period is in the future",
+		})
+	}
+
+	if patient.OrganizationID == nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Patient is not in an organization",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	summary := &models.ClinicalSummary{
+		PatientID:      *patient.ID,
+		OrganizationID: *patient.OrganizationID,
+		Period:         req.Period,
+		GeneratedByID:  self.ID,
+	}
+	if err := summary.PrepareClinicalSummary(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create clinical summary",
+		})
+	}
+
+	if summary.SignedAt != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Summary is signed and can't be regenerated",
+		})
+	}
+
+	if err := worker.GenerateClinicalSummary(summary); err != nil {
+		log.Errorf("Failed to generate clinical summary %d: %s", summary.ID, err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to generate clinical summary",
+		})
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+// downloadClinicalSummary godoc
+// @Summary Download Clinical Summary
+// @Description Download the PDF of a patient's clinical summary
+// @Tags User
+// @Produce application/pdf
+// @Param id path int true "Patient ID"
+// @Param summary path int true "Summary ID"
+// @Success 200 {file} file "PDF report"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/clinical-summary/{summary}/file [get]
+func downloadClinicalSummary(c echo.Context) error {
+	patient, err := getPatientFor(c, canReviewPatient)
+	if patient == nil {
+		return err
+	}
+
+	summary, err := getPatientClinicalSummary(c, patient)
+	if summary == nil {
+		return err
+	}
+
+	if summary.FileKey == "" {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Summary has no report yet",
+		})
+	}
+
+	file, err := s3.DownloadFile(summary.FileKey)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to download file",
+		})
+	}
+
+	defer file.Body.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"clinical-summary-%d-%s.pdf\"", summary.PatientID, summary.Period))
+	return c.Stream(http.StatusOK, "application/pdf", file.Body)
+}
+
+// signClinicalSummary godoc
+// @Summary Sign Clinical Summary
+// @Description Record the provider's sign-off of a generated clinical summary
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param id path int true "Patient ID"
+// @Param summary path int true "Summary ID"
+// @Success 200 {object} models.ClinicalSummary
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/clinical-summary/{summary}/sign [post]
+func signClinicalSummary(c echo.Context) error {
+	patient, err := getPatientFor(c, canReviewPatient)
+	if patient == nil {
+		return err
+	}
+
+	summary, err := getPatientClinicalSummary(c, patient)
+	if summary == nil {
+		return err
+	}
+
+	if summary.Status != models.ClinicalSummaryGenerated {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Summary has not been generated",
+		})
+	}
+
+	if summary.SignedAt != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Summary is already signed",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if err := summary.SignClinicalSummary(*self.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to sign clinical summary",
+		})
+	}
+
+	if err := summary.GetClinicalSummary(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get clinical summary",
+		})
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,30 @@
+package user
+
+import (
+	"MedKick-backend/pkg/event"
+
+	"github.com/labstack/echo/v4"
+)
+
+// streamPatientEvents godoc
+// @Summary Stream Patient Events
+// @Description Server-sent events stream of one patient's new telemetry, alerts and device status reports, see the organization stream for the event types
+// @Tags User
+// @Produce text/event-stream
+// @Param id path int true "Patient ID"
+// @Success 200 {object} event.Event
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /user/{id}/events [get]
+func streamPatientEvents(c echo.Context) error {
+	patient, err := getViewablePatient(c)
+	if patient == nil {
+		return err
+	}
+
+	patientID := *patient.ID
+	return event.Stream(c, func(e event.Event) bool {
+		return e.PatientID == patientID
+	})
+}
@@ -15,6 +15,16 @@ func Routes(r *echo.Group) {
 
 	r.GET("/auth/validate/:id", validateUser)
 
+	r.POST("/auth/mfa/verify", verifyMFA)
+	r.POST("/auth/mfa/email", sendMFAEmail)
+	r.GET("/user/mfa", getMFAStatus, middleware.NotGuest)
+	r.POST("/user/mfa/totp", startMFAEnrollment, middleware.NotGuest)
+	r.POST("/user/mfa/totp/confirm", confirmMFAEnrollment, middleware.NotGuest)
+	r.POST("/user/mfa/recovery-codes", regenerateMFARecoveryCodes, middleware.NotGuest)
+	r.PATCH("/user/mfa/email", updateMFAEmailFallback, middleware.NotGuest)
+	r.POST("/user/mfa/disable", disableMFA, middleware.NotGuest)
+	r.POST("/user/:id/mfa/reset", resetUserMFA, middleware.NotGuest, middleware.HasRole("admin", "org_admin"))
+
 	r.POST("/user", createUser, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
 	r.GET("/user/:id", getUser, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
 	r.GET("/patient/:id", getPatients, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
@@ -36,6 +46,19 @@ func Routes(r *echo.Group) {
 
 	r.PUT("/user/:id/alert-threshold", upsertAlertThreshold, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
 	r.GET("/user/:id/alert-threshold", listAlertThresholds, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
+	r.PUT("/user/:id/alert-trend-rule", upsertAlertTrendRules, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+	r.GET("/user/:id/alert-trend-rule", listAlertTrendRules, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
+	r.DELETE("/user/:id/alert-trend-rule/:rule", deleteAlertTrendRule, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
+
+	r.GET("/user/:id/telemetry-stats", getTelemetryStats, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
+	r.GET("/user/:id/reading-adherence", getReadingAdherence, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
+	r.GET("/user/:id/telemetry-summary", getTelemetrySummary, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
+	r.GET("/user/:id/events", streamPatientEvents, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
+	r.GET("/user/:id/clinical-summary", listClinicalSummaries, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "doctor", "nurse"))
+	r.POST("/user/:id/clinical-summary", generateClinicalSummary, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "doctor", "nurse"))
+	r.GET("/user/:id/clinical-summary/:summary/file", downloadClinicalSummary, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "doctor", "nurse"))
+	r.POST("/user/:id/clinical-summary/:summary/sign", signClinicalSummary, middleware.NotGuest, middleware.HasRole("admin", "doctor", "nurse"))
+	r.GET("/user/:id/adherence-reminder", listAdherenceReminders, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager"))
 
 	r.PUT("/user/:id/diagnoses", upsertDiagnoses, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
 	r.GET("/user/:id/diagnoses", getDiagnoses, middleware.NotGuest, middleware.HasRole("admin", "org_admin", "care_manager", "patient"))
@@ -0,0 +1,650 @@
+package user
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/sendgrid"
+	"MedKick-backend/pkg/totp"
+	"MedKick-backend/pkg/validator"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+const mfaIssuer = "MedKick"
+
+// getSessionMFAChallenge loads the login challenge of the session, on failure it writes the
+// response and returns a nil challenge
+func getSessionMFAChallenge(c echo.Context) (*sessions.Session, *models.MFAChallenge, error) {
+	session, err := middleware.Store.Get(c.Request(), "medkick-session")
+	if err != nil {
+		return nil, nil, c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get session",
+		})
+	}
+
+	challengeID, ok := session.Values["mfa-challenge-id"].(uint)
+	if !ok {
+		return nil, nil, c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "No login is waiting for a verification code",
+		})
+	}
+
+	challenge, err := models.GetMFAChallenge(challengeID)
+	if err != nil {
+		delete(session.Values, "mfa-challenge-id")
+		_ = session.Save(c.Request(), c.Response())
+		return nil, nil, c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "Verification expired, log in again",
+		})
+	}
+
+	return session, challenge, nil
+}
+
+// getEnabledMFA loads self's authenticator for changes to it, which API keys can't make. On
+// failure it writes the response and returns a nil authenticator.
+func getEnabledMFA(c echo.Context) (*models.UserMFA, error) {
+	if middleware.IsAPIKey(c) {
+		return nil, c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: "API keys can't manage MFA",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	mfa, err := models.GetUserMFA(*self.ID)
+	if err != nil || !mfa.Enabled() {
+		return nil, c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "MFA is not turned on",
+		})
+	}
+
+	return mfa, nil
+}
+
+// checkMFACode accepts a code from the user's app or one of their recovery codes. On failure
+// it writes the response and returns false.
+func checkMFACode(c echo.Context, mfa *models.UserMFA, code string) (bool, error) {
+	if ok, err := recordMFAAttempt(c, mfa); !ok {
+		return false, err
+	}
+
+	if !mfa.VerifyTOTP(code) && !models.UseMFARecoveryCode(mfa.UserID, code) {
+		return false, c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Invalid code",
+		})
+	}
+
+	if err := mfa.ResetMFAAttempts(); err != nil {
+		log.Errorf("Failed to reset MFA attempts of user %d: %s", mfa.UserID, err)
+	}
+	return true, nil
+}
+
+// recordMFAAttempt counts a code tried for the user. On failure, or while the user is locked
+// out, it writes the response and returns false.
+func recordMFAAttempt(c echo.Context, mfa *models.UserMFA) (bool, error) {
+	allowed, err := mfa.RecordMFAAttempt()
+	if err != nil {
+		return false, c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to record attempt",
+		})
+	}
+
+	if !allowed {
+		return false, c.JSON(http.StatusTooManyRequests, dto.ErrorResponse{
+			Error: fmt.Sprintf("Too many wrong codes, try again in %d minutes", int(models.MFALockout.Minutes())),
+		})
+	}
+
+	return true, nil
+}
+
+func getMFAStatusFor(u models.User) (MFAStatusResponse, error) {
+	status := MFAStatusResponse{
+		Required: models.IsMFARequired(u),
+	}
+
+	mfa, err := models.GetUserMFA(*u.ID)
+	if err != nil || !mfa.Enabled() {
+		return status, nil
+	}
+
+	count, err := models.CountMFARecoveryCodes(*u.ID)
+	if err != nil {
+		return status, err
+	}
+
+	status.Enabled = true
+	status.EmailFallback = mfa.EmailFallback
+	status.RecoveryCodesRemaining = count
+	return status, nil
+}
+
+// verifyMFA godoc
+// @Summary Verify MFA
+// @Description Finish a login of a user with MFA on with a code from the authenticator app, a recovery code or a code sent by email. After 5 wrong codes, or 10 minutes, the user has to log in again. After 10 wrong codes in a row, over any number of logins, codes are refused for 15 minutes.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body MFAVerifyData true "Verification Code"
+// @Success 200 {object} LoginResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /auth/mfa/verify [post]
+func verifyMFA(c echo.Context) error {
+	var req MFAVerifyData
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	session, challenge, err := getSessionMFAChallenge(c)
+	if challenge == nil {
+		return err
+	}
+
+	mfa, err := models.GetUserMFA(challenge.UserID)
+	if err != nil || !mfa.Enabled() {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "MFA is not turned on, log in again",
+		})
+	}
+
+	// The user's attempts are limited too, new logins don't bring new attempts
+	if ok, err := recordMFAAttempt(c, mfa); !ok {
+		return err
+	}
+
+	allowed, err := challenge.RecordMFAAttempt()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to record attempt",
+		})
+	}
+
+	if !allowed {
+		if err := challenge.DeleteMFAChallenge(); err != nil {
+			log.Errorf("Failed to delete MFA challenge %d: %s", challenge.ID, err)
+		}
+		delete(session.Values, "mfa-challenge-id")
+		_ = session.Save(c.Request(), c.Response())
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "Too many attempts, log in again",
+		})
+	}
+
+	var valid bool
+	switch req.Method {
+	case "totp":
+		valid = mfa.VerifyTOTP(req.Code)
+	case "recovery":
+		valid = models.UseMFARecoveryCode(mfa.UserID, req.Code)
+	case "email":
+		valid = mfa.EmailFallback && challenge.CheckEmailCode(req.Code)
+	}
+
+	if !valid {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Invalid code",
+		})
+	}
+
+	if err := mfa.ResetMFAAttempts(); err != nil {
+		log.Errorf("Failed to reset MFA attempts of user %d: %s", mfa.UserID, err)
+	}
+
+	if err := challenge.DeleteMFAChallenge(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to delete MFA challenge",
+		})
+	}
+
+	delete(session.Values, "mfa-challenge-id")
+	session.Values["user-id"] = challenge.UserID
+	session.Values["mfa-enrollment"] = false
+	if err := session.Save(c.Request(), c.Response()); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to save session",
+		})
+	}
+
+	return c.JSON(http.StatusOK, LoginResponse{
+		Message: "Successfully logged in",
+	})
+}
+
+// sendMFAEmail godoc
+// @Summary Send MFA Email Code
+// @Description Email a verification code for the pending login, for users who turned on the email fallback. A new code can be requested once a minute and replaces the previous one.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /auth/mfa/email [post]
+func sendMFAEmail(c echo.Context) error {
+	_, challenge, err := getSessionMFAChallenge(c)
+	if challenge == nil {
+		return err
+	}
+
+	mfa, err := models.GetUserMFA(challenge.UserID)
+	if err != nil || !mfa.Enabled() || !mfa.EmailFallback {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Email codes are not turned on for this account",
+		})
+	}
+
+	if challenge.EmailCodeSentAt != nil && time.Since(*challenge.EmailCodeSentAt) < models.MFAEmailCodeInterval {
+		return c.JSON(http.StatusTooManyRequests, dto.ErrorResponse{
+			Error: "Wait a minute before requesting another code",
+		})
+	}
+
+	u := models.User{
+		ID: &challenge.UserID,
+	}
+	if err := u.GetUser(); err != nil {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "User not found",
+		})
+	}
+
+	code, err := challenge.CreateEmailCode()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create verification code",
+		})
+	}
+
+	body := fmt.Sprintf("<p>Your MedKick verification code is <b>%s</b>. Enter it within %d minutes of logging in.</p><p>If you did not try to log in, change your password.</p>", code, int(models.MFAChallengeTTL.Minutes()))
+	subject := "MedKick Verification Code"
+	if err := sendgrid.SendEmail(fmt.Sprintf("%s %s", u.FirstName, u.LastName), u.Email, subject, body); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to send verification email",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Verification code sent",
+	})
+}
+
+// getMFAStatus godoc
+// @Summary Get MFA Status
+// @Description Get whether MFA is on for the logged in user, whether it is required for them and how many recovery codes are left
+// @Tags User
+// @Accept json
+// @Produce json
+// @Success 200 {object} MFAStatusResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/mfa [get]
+func getMFAStatus(c echo.Context) error {
+	status, err := getMFAStatusFor(middleware.GetSelf(c))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get MFA status",
+		})
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// startMFAEnrollment godoc
+// @Summary Start MFA Setup
+// @Description Create an authenticator app secret for the logged in user. MFA is turned on once a code from the app is confirmed, starting again replaces an unconfirmed secret.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Success 200 {object} MFAEnrollmentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/mfa/totp [post]
+func startMFAEnrollment(c echo.Context) error {
+	if middleware.IsAPIKey(c) {
+		return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: "API keys can't manage MFA",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if models.IsMFAEnabled(*self.ID) {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "MFA is already turned on, turn it off to set up a new app",
+		})
+	}
+
+	mfa, err := models.StartUserMFA(*self.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create MFA secret",
+		})
+	}
+
+	return c.JSON(http.StatusOK, MFAEnrollmentResponse{
+		Secret: mfa.Secret,
+		URI:    totp.URI(mfaIssuer, self.Email, mfa.Secret),
+	})
+}
+
+// confirmMFAEnrollment godoc
+// @Summary Confirm MFA Setup
+// @Description Turn MFA on with a code from the authenticator app. The response holds the recovery codes, which are only shown once.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body MFACodeData true "Authenticator Code"
+// @Success 200 {object} MFARecoveryCodesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/mfa/totp/confirm [post]
+func confirmMFAEnrollment(c echo.Context) error {
+	if middleware.IsAPIKey(c) {
+		return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: "API keys can't manage MFA",
+		})
+	}
+
+	var req MFACodeData
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	mfa, err := models.GetUserMFA(*self.ID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Start MFA setup first",
+		})
+	}
+
+	if mfa.Enabled() {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "MFA is already turned on",
+		})
+	}
+
+	if !mfa.VerifyTOTP(req.Code) {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Invalid code",
+		})
+	}
+
+	if err := mfa.ConfirmUserMFA(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to turn on MFA",
+		})
+	}
+
+	codes, err := models.GenerateMFARecoveryCodes(*self.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create recovery codes",
+		})
+	}
+
+	session, err := middleware.Store.Get(c.Request(), "medkick-session")
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get session",
+		})
+	}
+
+	session.Values["mfa-enrollment"] = false
+	if err := session.Save(c.Request(), c.Response()); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to save session",
+		})
+	}
+
+	return c.JSON(http.StatusOK, MFARecoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+// regenerateMFARecoveryCodes godoc
+// @Summary Regenerate MFA Recovery Codes
+// @Description Replace the logged in user's recovery codes, the previous ones stop working. The response holds the new codes, which are only shown once.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body MFACodeData true "Authenticator or Recovery Code"
+// @Success 200 {object} MFARecoveryCodesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/mfa/recovery-codes [post]
+func regenerateMFARecoveryCodes(c echo.Context) error {
+	mfa, err := getEnabledMFA(c)
+	if mfa == nil {
+		return err
+	}
+
+	var req MFACodeData
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if ok, err := checkMFACode(c, mfa, req.Code); !ok {
+		return err
+	}
+
+	codes, err := models.GenerateMFARecoveryCodes(mfa.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to create recovery codes",
+		})
+	}
+
+	return c.JSON(http.StatusOK, MFARecoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+// updateMFAEmailFallback godoc
+// @Summary Update MFA Email Fallback
+// @Description Turn logging in with a code sent to the logged in user's email, instead of the authenticator app, on or off
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body MFAEmailFallbackData true "Email Fallback"
+// @Success 200 {object} MFAStatusResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/mfa/email [patch]
+func updateMFAEmailFallback(c echo.Context) error {
+	mfa, err := getEnabledMFA(c)
+	if mfa == nil {
+		return err
+	}
+
+	var req MFAEmailFallbackData
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if ok, err := checkMFACode(c, mfa, req.Code); !ok {
+		return err
+	}
+
+	mfa.EmailFallback = *req.Enabled
+	if err := mfa.UpdateUserMFA(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to update MFA",
+		})
+	}
+
+	status, err := getMFAStatusFor(middleware.GetSelf(c))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get MFA status",
+		})
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// disableMFA godoc
+// @Summary Turn Off MFA
+// @Description Turn MFA off for the logged in user, removing their authenticator app and recovery codes. Not allowed for platform admins, or when the user's organization requires MFA for their role.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body MFACodeData true "Authenticator or Recovery Code"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/mfa/disable [post]
+func disableMFA(c echo.Context) error {
+	mfa, err := getEnabledMFA(c)
+	if mfa == nil {
+		return err
+	}
+
+	var req MFACodeData
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to bind request",
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if models.IsMFARequired(middleware.GetSelf(c)) {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Your organization requires MFA for your role",
+		})
+	}
+
+	if ok, err := checkMFACode(c, mfa, req.Code); !ok {
+		return err
+	}
+
+	if err := models.DeleteUserMFA(mfa.UserID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to turn off MFA",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "MFA turned off",
+	})
+}
+
+// resetUserMFA godoc
+// @Summary Reset User MFA
+// @Description Turn MFA off for a user who lost their authenticator app and recovery codes. If their organization requires MFA, they have to set it up again at their next login.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/mfa/reset [post]
+func resetUserMFA(c echo.Context) error {
+	if middleware.IsAPIKey(c) {
+		return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: "API keys can't manage MFA",
+		})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to convert id to uint",
+		})
+	}
+
+	userID := uint(id)
+	u := models.User{
+		ID: &userID,
+	}
+	if err := u.GetUser(); err != nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "User not found",
+		})
+	}
+
+	self := middleware.GetSelf(c)
+	if self.Role != "admin" {
+		if u.Role == "admin" || u.OrganizationID == nil || self.OrganizationID == nil || *u.OrganizationID != *self.OrganizationID {
+			return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error: "You do not have permission to reset this user's MFA",
+			})
+		}
+	}
+
+	if err := models.DeleteUserMFA(userID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to reset MFA",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "MFA reset",
+	})
+}
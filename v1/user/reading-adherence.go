@@ -0,0 +1,110 @@
+package user
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// getReadingAdherence godoc
+// @Summary Get Reading Adherence
+// @Description Get the patient's progress towards 16 reading days this month, counted in the patient's timezone
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param id path int true "Patient ID"
+// @Success 200 {object} models.ReadingAdherence
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/reading-adherence [get]
+func getReadingAdherence(c echo.Context) error {
+	patient, err := getViewablePatient(c)
+	if patient == nil {
+		return err
+	}
+
+	adherence, err := models.GetPatientReadingAdherence(*patient, time.Now())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get reading adherence",
+		})
+	}
+
+	return c.JSON(http.StatusOK, adherence)
+}
+
+// listAdherenceReminders godoc
+// @Summary List Adherence Reminders
+// @Description List the reading reminders sent to the patient, newest first
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param id path int true "Patient ID"
+// @Success 200 {object} []models.AdherenceReminder
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/adherence-reminder [get]
+func listAdherenceReminders(c echo.Context) error {
+	patient, err := getViewablePatient(c)
+	if patient == nil {
+		return err
+	}
+
+	reminders, err := models.ListAdherenceReminders(*patient.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to list adherence reminders",
+		})
+	}
+
+	return c.JSON(http.StatusOK, reminders)
+}
+
+// getViewablePatient loads the patient in the id path param. On failure it writes the
+// response and returns a nil patient.
+func getViewablePatient(c echo.Context) (*models.User, error) {
+	return getPatientFor(c, canViewPatient)
+}
+
+// getPatientFor loads the patient in the id path param if self may access it
+func getPatientFor(c echo.Context, allowed func(self models.User, patient models.User) bool) (*models.User, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return nil, c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Invalid ID",
+		})
+	}
+
+	patientID := uint(id)
+	patient := models.User{
+		ID: &patientID,
+	}
+	if err := patient.GetUser(); err != nil {
+		return nil, c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "User not found",
+		})
+	}
+
+	if patient.Role != "patient" {
+		return nil, c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "User is not a patient",
+		})
+	}
+
+	if !allowed(middleware.GetSelf(c), patient) {
+		return nil, c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: "Forbidden",
+		})
+	}
+
+	return &patient, nil
+}
@@ -0,0 +1,137 @@
+package user
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"MedKick-backend/pkg/echo/middleware"
+	"MedKick-backend/pkg/validator"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// canViewPatient reports whether self may read the patient's data: admins, staff of the
+// patient's organization and the patient themselves
+func canViewPatient(self models.User, patient models.User) bool {
+	if self.Role == "admin" || (self.ID != nil && patient.ID != nil && *self.ID == *patient.ID) {
+		return true
+	}
+
+	if self.Role != "org_admin" && self.Role != "care_manager" {
+		return false
+	}
+
+	return self.OrganizationID != nil && patient.OrganizationID != nil && *self.OrganizationID == *patient.OrganizationID
+}
+
+// getTelemetryStats godoc
+// @Summary Get Telemetry Stats
+// @Description Aggregate a patient's readings of one measurement into day, week or month buckets with min, max, mean, median, reading days and time in range against the patient's alert threshold. Systolic and diastolic stats also compare morning and evening blood pressure.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param id path int true "Patient ID"
+// @Param measurement_type query string true "Measurement Type" Enums(Systolic, Diastolic, Pulse, Weight, FastingGlucose, PostMealGlucose)
+// @Param start_date query string true "Start Date (MM-DD-YYYY)"
+// @Param end_date query string false "End Date, inclusive (MM-DD-YYYY), defaults to today"
+// @Param bucket query string false "Bucket Size" Enums(day, week, month)
+// @Success 200 {object} models.TelemetryStats
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/telemetry-stats [get]
+func getTelemetryStats(c echo.Context) error {
+	self := middleware.GetSelf(c)
+
+	var req struct {
+		PatientID       uint   `param:"id"`
+		MeasurementType string `query:"measurement_type" validate:"required,oneof=Systolic Diastolic Pulse Weight FastingGlucose PostMealGlucose"`
+		StartDate       string `query:"start_date" validate:"required"`
+		EndDate         string `query:"end_date"`
+		Bucket          string `query:"bucket" validate:"omitempty,oneof=day week month"`
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	patient := models.User{
+		ID: &req.PatientID,
+	}
+	if err := patient.GetUser(); err != nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "User not found",
+		})
+	}
+
+	if patient.Role != "patient" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "User is not a patient",
+		})
+	}
+
+	if !canViewPatient(self, patient) {
+		return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: "Forbidden",
+		})
+	}
+
+	// Dates and buckets follow the patient's timezone
+	loc := models.GetPatientLocation(req.PatientID)
+
+	start, err := time.ParseInLocation("01-02-2006", req.StartDate, loc)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to parse start_date",
+		})
+	}
+
+	end := time.Now().In(loc)
+	if req.EndDate != "" {
+		end, err = time.ParseInLocation("01-02-2006", req.EndDate, loc)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Failed to parse end_date",
+			})
+		}
+	}
+	// The end date is inclusive
+	end = models.StartOfDay(end, loc).AddDate(0, 0, 1)
+
+	if !start.Before(end) {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Start date must be before end date",
+		})
+	}
+
+	bucketSize := models.TelemetryBucketSize(req.Bucket)
+	if bucketSize == "" {
+		bucketSize = models.BucketDay
+	}
+
+	if bucketSize == models.BucketDay && end.Sub(start) > 366*24*time.Hour {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Daily buckets are limited to one year",
+		})
+	}
+
+	stats, err := models.GetTelemetryStats(req.PatientID, models.MeasurementType(req.MeasurementType), start, end, bucketSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get telemetry stats",
+		})
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
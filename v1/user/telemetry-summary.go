@@ -0,0 +1,68 @@
+package user
+
+import (
+	"MedKick-backend/pkg/database/models"
+	"MedKick-backend/pkg/echo/dto"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// getTelemetrySummary godoc
+// @Summary Get Telemetry Summary
+// @Description Get the patient's readings per day and device type with the min, max and mean of each measurement, in the patient's timezone. Summaries cover archived readings too, so long ranges don't need the archive.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param id path int true "Patient ID"
+// @Param start_date query string true "Start Date (MM-DD-YYYY)"
+// @Param end_date query string false "End Date, inclusive (MM-DD-YYYY), defaults to today"
+// @Success 200 {object} []models.DailySummary
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/telemetry-summary [get]
+func getTelemetrySummary(c echo.Context) error {
+	patient, err := getViewablePatient(c)
+	if patient == nil {
+		return err
+	}
+
+	loc := models.GetPatientLocation(*patient.ID)
+
+	start, err := time.ParseInLocation("01-02-2006", c.QueryParam("start_date"), loc)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Failed to parse start_date",
+		})
+	}
+
+	end := time.Now().In(loc)
+	if raw := c.QueryParam("end_date"); raw != "" {
+		end, err = time.ParseInLocation("01-02-2006", raw, loc)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Failed to parse end_date",
+			})
+		}
+	}
+	// The end date is inclusive
+	end = models.StartOfDay(end, loc).AddDate(0, 0, 1)
+
+	if !start.Before(end) {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Start date must be before end date",
+		})
+	}
+
+	summaries, err := models.GetDailyTelemetrySummaries(*patient.ID, start, end, loc)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Failed to get telemetry summary",
+		})
+	}
+
+	return c.JSON(http.StatusOK, summaries)
+}
@@ -6,31 +6,17 @@ import (
 )
 
 type MeasurementData struct {
-	MeasurementType models.MeasurementType `json:"measurement_type" validate:"required,oneof=Systolic Diastolic Pulse Weight"`
+	MeasurementType models.MeasurementType `json:"measurement_type" validate:"required,oneof=Systolic Diastolic Pulse Weight FastingGlucose PostMealGlucose"`
 	CriticalLow     *uint                  `json:"critical_low"`
 	WarningLow      *uint                  `json:"warning_low"`
 	WarningHigh     *uint                  `json:"warning_high"`
 	CriticalHigh    *uint                  `json:"critical_high"`
+	// Source is only returned, it tells whether the threshold is the patient's own or inherited
+	Source string `json:"source,omitempty" example:"Patient"`
 }
 
 func (m MeasurementData) validate() error {
-	if m.CriticalLow != nil && m.CriticalHigh != nil && *m.CriticalLow > *m.CriticalHigh {
-		return errors.New("critical low must be less than critical high")
-	}
-
-	if m.WarningLow != nil && m.WarningHigh != nil && *m.WarningLow > *m.WarningHigh {
-		return errors.New("warning low must be less than warning high")
-	}
-
-	if m.CriticalLow != nil && m.WarningLow != nil && *m.CriticalLow > *m.WarningLow {
-		return errors.New("critical low must be less than warning low")
-	}
-
-	if m.CriticalHigh != nil && m.WarningHigh != nil && *m.CriticalHigh < *m.WarningHigh {
-		return errors.New("critical high must be greater than warning high")
-	}
-
-	return nil
+	return models.ValidateThresholdLimits(m.CriticalLow, m.WarningLow, m.WarningHigh, m.CriticalHigh)
 }
 
 type AlertThresholdData struct {
@@ -49,6 +35,7 @@ func convertAlertThresholdModelToResponse(data []models.AlertThreshold) []AlertT
 			WarningLow:      d.WarningLow,
 			WarningHigh:     d.WarningHigh,
 			CriticalHigh:    d.CriticalHigh,
+			Source:          d.Source,
 		})
 	}
 
@@ -108,3 +95,73 @@ func convertPatientServiceModelToResponse(data []models.PatientService) []Patien
 type PatientServiceData struct {
 	Services []string `json:"services" validate:"required,dive,required,oneof=RPM CCM PCM BHI RTM"`
 }
+
+type TrendRuleData struct {
+	MeasurementType models.MeasurementType `json:"measurement_type" validate:"required,oneof=Systolic Diastolic Pulse Weight FastingGlucose PostMealGlucose IrregularHeartBeat"`
+	RuleType        models.TrendRuleType   `json:"rule_type" validate:"required,oneof=Delta RollingAverage OccurrenceCount"`
+	WindowHours     uint                   `json:"window_hours" validate:"required,max=2160" example:"24"`
+	Direction       models.TrendDirection  `json:"direction" validate:"omitempty,oneof=Increase Decrease Any"`
+	WarningChange   *uint                  `json:"warning_change" example:"2"`
+	CriticalChange  *uint                  `json:"critical_change" example:"3"`
+}
+
+func (r TrendRuleData) validate() error {
+	if (r.MeasurementType == models.IrregularHeartBeat) != (r.RuleType == models.TrendOccurrenceCount) {
+		return errors.New("irregular heartbeat rules must use the occurrence count rule type")
+	}
+
+	if r.WarningChange == nil && r.CriticalChange == nil {
+		return errors.New("warning change or critical change is required")
+	}
+
+	if r.WarningChange != nil && r.CriticalChange != nil && *r.WarningChange > *r.CriticalChange {
+		return errors.New("warning change must be less than critical change")
+	}
+
+	return nil
+}
+
+type AlertTrendRuleData struct {
+	DeviceType models.DeviceType `json:"device_type" validate:"required,oneof=BloodPressure BloodGlucose WeightScale"`
+	Rules      []TrendRuleData   `json:"rules" validate:"required,min=1,dive,required"`
+	Note       string            `json:"note"`
+}
+
+type ClinicalSummaryPeriodData struct {
+	Period string `json:"period" validate:"required" example:"2021-01"`
+}
+
+type MFAVerifyData struct {
+	// Method is the kind of code, from the app, a recovery code or a code sent by email
+	Method string `json:"method" validate:"required,oneof=totp recovery email" example:"totp"`
+	Code   string `json:"code" validate:"required" example:"123456"`
+}
+
+type MFACodeData struct {
+	// Code is from the authenticator app, or one of the recovery codes
+	Code string `json:"code" validate:"required" example:"123456"`
+}
+
+type MFAEmailFallbackData struct {
+	Enabled *bool  `json:"enabled" validate:"required" example:"true"`
+	Code    string `json:"code" validate:"required" example:"123456"`
+}
+
+type MFAStatusResponse struct {
+	Enabled                bool  `json:"enabled" example:"true"`
+	EmailFallback          bool  `json:"email_fallback" example:"false"`
+	RecoveryCodesRemaining int64 `json:"recovery_codes_remaining" example:"10"`
+	// Required means MFA can't be turned off, platform admins and roles in the organization's policy require it
+	Required bool `json:"required" example:"true"`
+}
+
+type MFAEnrollmentResponse struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	// URI is the otpauth URI to show as a QR code
+	URI string `json:"uri" example:"otpauth://totp/MedKick:john@example.com?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP&issuer=MedKick"`
+}
+
+type MFARecoveryCodesResponse struct {
+	// RecoveryCodes are only shown once, each signs in one time
+	RecoveryCodes []string `json:"recovery_codes" example:"k3pxpjbs-wy3dpehp"`
+}
@@ -38,10 +38,13 @@ type CreateRequest struct {
 	Role              string `json:"role" validate:"required"` // Roles: admin, doctor, patient, doctornv, patientnv (nv = not verified email)
 	DOB               string `json:"dob" validate:"required"`
 	Location          string `json:"location" validate:"required"`
+	Address           string `json:"address"`
 	City              string `json:"city"`
 	ZipCode           string `json:"zipcode"`
 	State             string `json:"state"`
 	Country           string `json:"country"`
+	Timezone          string `json:"timezone" example:"America/Chicago"`
+	MRN               string `json:"mrn" example:"A-10023"`
 	InsuranceProvider string `json:"insurance_provider" validate:"required"`
 	InsuranceID       string `json:"insurance_id" validate:"required"`
 	OrganizationID    uint   `json:"organization_id" validate:"required"`
@@ -85,6 +88,14 @@ func createUser(c echo.Context) error {
 		})
 	}
 
+	if request.Timezone != "" {
+		if _, ok := models.ParseTimezone(request.Timezone); !ok {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Invalid timezone, must be an IANA name like America/Chicago or an offset like UTC-05:00",
+			})
+		}
+	}
+
 	if !isValidRole(request.Role) {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
 			Error: "Invalid role, must be 'admin', 'doctor', 'nurse', 'patient', 'doctornv', 'nursenv', 'care_manager', 'org_admin', or 'patientnv'.",
@@ -132,10 +143,13 @@ func createUser(c echo.Context) error {
 		Role:              request.Role,
 		DOB:               request.DOB,
 		Location:          request.Location,
+		Address:           request.Address,
 		City:              request.City,
 		ZipCode:           request.ZipCode,
 		State:             request.State,
 		Country:           request.Country,
+		Timezone:          request.Timezone,
+		MRN:               request.MRN,
 		InsuranceProvider: request.InsuranceProvider,
 		InsuranceID:       request.InsuranceID,
 		OrganizationID:    &request.OrganizationID,
@@ -923,10 +937,13 @@ type UpdateRequest struct {
 	Role              string `json:"role"`
 	DOB               string `json:"dob"`
 	Location          string `json:"location"`
+	Address           string `json:"address"`
 	City              string `json:"city"`
 	ZipCode           string `json:"zipcode"`
 	State             string `json:"state"`
 	Country           string `json:"country"`
+	Timezone          string `json:"timezone" example:"America/Chicago"`
+	MRN               string `json:"mrn" example:"A-10023"`
 	InsuranceProvider string `json:"insurance_provider"`
 	InsuranceID       string `json:"insurance_id"`
 	OrganizationID    *uint  `json:"organization_id"`
@@ -935,7 +952,7 @@ type UpdateRequest struct {
 
 // updateUser godoc
 // @Summary Update User
-// @Description Updates user, if ID is specified, updates specific user, if ID is not specified, updates self
+// @Description Updates user, if ID is specified, updates specific user, if ID is not specified, updates self. API keys can't change email or password.
 // @Tags User
 // @Accept json
 // @Produce json
@@ -963,6 +980,21 @@ func updateUser(c echo.Context) error {
 		})
 	}
 
+	// A leaked integration key must not be able to take over an account
+	if middleware.IsAPIKey(c) && (request.Email != "" || request.Password != "") {
+		return c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: "API keys can't change email or password",
+		})
+	}
+
+	if request.Timezone != "" {
+		if _, ok := models.ParseTimezone(request.Timezone); !ok {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "Invalid timezone, must be an IANA name like America/Chicago or an offset like UTC-05:00",
+			})
+		}
+	}
+
 	self := middleware.GetSelf(c)
 
 	idInt, err := strconv.ParseUint(id, 10, 32)
@@ -1004,6 +1036,9 @@ func updateUser(c echo.Context) error {
 		if request.Location != "" {
 			self.Location = request.Location
 		}
+		if request.Address != "" {
+			self.Address = request.Address
+		}
 		if request.City != "" {
 			self.City = request.City
 		}
@@ -1016,6 +1051,12 @@ func updateUser(c echo.Context) error {
 		if request.Country != "" {
 			self.Country = request.Country
 		}
+		if request.Timezone != "" {
+			self.Timezone = request.Timezone
+		}
+		if request.MRN != "" {
+			self.MRN = request.MRN
+		}
 		if request.InsuranceProvider != "" {
 			self.InsuranceProvider = request.InsuranceProvider
 		}
@@ -1075,6 +1116,9 @@ func updateUser(c echo.Context) error {
 		if request.Location != "" {
 			u.Location = request.Location
 		}
+		if request.Address != "" {
+			u.Address = request.Address
+		}
 		if request.City != "" {
 			u.City = request.City
 		}
@@ -1087,6 +1131,12 @@ func updateUser(c echo.Context) error {
 		if request.Country != "" {
 			u.Country = request.Country
 		}
+		if request.Timezone != "" {
+			u.Timezone = request.Timezone
+		}
+		if request.MRN != "" {
+			u.MRN = request.MRN
+		}
 		if request.InsuranceProvider != "" {
 			u.InsuranceProvider = request.InsuranceProvider
 		}
@@ -1362,7 +1412,7 @@ func filterCriticalPatient(users []models.User, status string) (filteredPatients
 
 	latestTelemetryData := models.GetLatestPatientTelemetryData(telemetryData)
 
-	thresholdList, err := models.ListAlertThresholds(patientList)
+	thresholdList, err := models.ListEffectiveAlertThresholds(patientList)
 	if err != nil {
 		return nil, errors.New("failed to get alert thresholds")
 	}
@@ -1376,6 +1426,21 @@ func filterCriticalPatient(users []models.User, status string) (filteredPatients
 		}
 	}
 
+	// Trend alerts (irregular heartbeats, weight gain) are not visible from the latest reading
+	alertType := models.AlertWarning
+	if status == "critical" {
+		alertType = models.AlertCritical
+	}
+
+	trendAlertPatients, err := models.ListPatientIDsWithActiveTrendAlerts(patientList, alertType)
+	if err != nil {
+		return nil, errors.New("failed to get trend alerts")
+	}
+
+	for _, patientID := range trendAlertPatients {
+		patientSelected[patientID] = struct{}{}
+	}
+
 	for _, user := range users {
 		if _, ok := patientSelected[*user.ID]; ok {
 			filteredPatients = append(filteredPatients, user)